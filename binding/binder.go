@@ -0,0 +1,190 @@
+package binding
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// Invoker runs functions on a UI thread, window.Window satisfies it.
+type Invoker interface {
+	// Invoke queues fn to run on the UI thread and returns without waiting for it.
+	Invoke(fn func())
+}
+
+// Binder connects observables to the components of one window.
+// Observables may change on any goroutine, the Binder carries every change over to the window's event loop
+// and coalesces bursts of changes into a single update that applies the latest value.
+type Binder struct {
+	mu       sync.Mutex
+	invoker  Invoker
+	bindings []*Binding
+}
+
+// NewBinder creates a binder applying updates through invoker, usually the window owning the bound components.
+//
+// Parameters:
+//   - invoker: The Invoker running updates on the UI thread.
+//
+// Returns:
+//   - *Binder: A pointer to the newly created binder.
+func NewBinder(invoker Invoker) *Binder {
+	return &Binder{invoker: invoker}
+}
+
+// Unbind removes every binding created through the binder.
+func (b *Binder) Unbind() {
+	b.mu.Lock()
+	bindings := b.bindings
+	b.bindings = nil
+	b.mu.Unlock()
+
+	for _, binding := range bindings {
+		binding.Unbind()
+	}
+}
+
+// Binding is a connection between an observable and a component, created by the Bind functions.
+type Binding struct {
+	once      sync.Once
+	stopped   atomic.Bool
+	scheduled atomic.Bool
+	handles   []event.Handle
+}
+
+// Unbind stops the binding. An update already queued on the UI thread is dropped. Calling Unbind more than once is a no-op.
+func (b *Binding) Unbind() {
+	b.once.Do(func() {
+		b.stopped.Store(true)
+		for _, h := range b.handles {
+			h.Unsubscribe()
+		}
+	})
+}
+
+// Bind connects obs to an arbitrary apply function, it is the building block of the component bindings.
+// apply is called right away with the current value, so bindings are expected to be created on the UI thread
+// or before the window runs. Later changes call apply on the UI thread with the latest value,
+// several changes made before the UI thread got to the update result in a single call.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable to bind.
+//   - apply: The function applying a value to the UI.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func Bind[T any](b *Binder, obs *Observable[T], apply func(T)) *Binding {
+	binding := &Binding{}
+	apply(obs.Get())
+	binding.handles = append(binding.handles, obs.OnChange(func(event.ValueChangedEvent[T]) {
+		if binding.scheduled.Swap(true) {
+			return
+		}
+		b.invoker.Invoke(func() {
+			binding.scheduled.Store(false)
+			if !binding.stopped.Load() {
+				apply(obs.Get())
+			}
+		})
+	}))
+	b.add(binding)
+	return binding
+}
+
+// BindLabelText keeps the text of a label in sync with obs.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable holding the text.
+//   - l: The label to update.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func BindLabelText(b *Binder, obs *Observable[string], l component.Label) *Binding {
+	return Bind(b, obs, l.SetText)
+}
+
+// BindButtonLabel keeps the label of a button in sync with obs.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable holding the label.
+//   - btn: The button to update.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func BindButtonLabel(b *Binder, obs *Observable[string], btn component.Button) *Binding {
+	return Bind(b, obs, btn.SetLabel)
+}
+
+// BindEnabled keeps the enabled state of a component, for example a button, in sync with obs.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable holding the enabled state.
+//   - c: The component to update.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func BindEnabled(b *Binder, obs *Observable[bool], c component.Component) *Binding {
+	return Bind(b, obs, c.SetEnabled)
+}
+
+// BindVisible keeps the visibility of a component in sync with obs.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable holding the visibility.
+//   - c: The component to update.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func BindVisible(b *Binder, obs *Observable[bool], c component.Component) *Binding {
+	return Bind(b, obs, c.SetVisible)
+}
+
+// BindTextInputValue binds the value of a text input to obs in both directions.
+// Changes of obs update the text input, and edits in the text input are set on obs.
+// Edits rejected by a validator or converter leave obs unchanged and are reported to the handler set with ErrorHandlerOpt.
+//
+// Parameters:
+//   - b: The Binder the binding belongs to.
+//   - obs: The observable holding the value.
+//   - ti: The text input to bind.
+//   - options: A variadic list of BindOption functions to customize the binding.
+//
+// Returns:
+//   - *Binding: The binding, used to unbind it on its own.
+func BindTextInputValue(b *Binder, obs *Observable[string], ti component.TextInput, options ...BindOption) *Binding {
+	opts := newBindOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	// set while the binding writes to the text input, so the resulting event is not set back on obs
+	pushing := false
+	binding := Bind(b, obs, func(value string) {
+		pushing = true
+		defer func() { pushing = false }()
+		ti.SetValue(value)
+	})
+	binding.handles = append(binding.handles, event.Subscribe(ti.Events(), func(e event.ValueChangedEvent[string]) {
+		if pushing || binding.stopped.Load() {
+			return
+		}
+		if err := obs.Set(e.New); err != nil && opts.OnError != nil {
+			opts.OnError(err)
+		}
+	}))
+	return binding
+}
+
+// add records a binding so Unbind can remove it.
+func (b *Binder) add(binding *Binding) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bindings = append(b.bindings, binding)
+}
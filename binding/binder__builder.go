@@ -0,0 +1,27 @@
+package binding
+
+type bindOptions struct {
+	OnError func(error)
+}
+
+type BindOption func(*bindOptions)
+
+func newBindOptions() *bindOptions {
+	return &bindOptions{
+		OnError: nil,
+	}
+}
+
+// ErrorHandlerOpt sets the function called on the UI thread when a value entered in a component is rejected
+// by a validator or converter of the bound observable.
+//
+// Parameters:
+//   - onError: The function called with the rejection error.
+//
+// Returns:
+//   - BindOption: A function that takes a pointer to bindOptions and sets the OnError field.
+func ErrorHandlerOpt(onError func(error)) BindOption {
+	return func(opts *bindOptions) {
+		opts.OnError = onError
+	}
+}
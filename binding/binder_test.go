@@ -0,0 +1,156 @@
+package binding
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Carmen-Shannon/gooey/component"
+)
+
+// queueInvoker collects invoked functions until the test runs them, standing in for a window's event loop.
+type queueInvoker struct {
+	mu    sync.Mutex
+	queue []func()
+}
+
+func (q *queueInvoker) Invoke(fn func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue = append(q.queue, fn)
+}
+
+// run runs the queued functions, including those queued while running, and reports how many ran.
+func (q *queueInvoker) run() int {
+	ran := 0
+	for {
+		q.mu.Lock()
+		queue := q.queue
+		q.queue = nil
+		q.mu.Unlock()
+		if len(queue) == 0 {
+			return ran
+		}
+		for _, fn := range queue {
+			fn()
+			ran++
+		}
+	}
+}
+
+func TestBindCoalesces(t *testing.T) {
+	q := &queueInvoker{}
+	obs := NewObservable(0)
+	var applied []int
+	Bind(NewBinder(q), obs, func(v int) { applied = append(applied, v) })
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obs.Set(i)
+		}()
+	}
+	wg.Wait()
+	obs.Set(1000)
+	// every change before the UI thread got to the update results in a single update applying the latest value
+	if ran := q.run(); ran != 1 {
+		t.Errorf("queued %d updates for a burst of changes, want 1", ran)
+	}
+	if len(applied) != 2 || applied[0] != 0 || applied[1] != 1000 {
+		t.Fatalf("applied %v, want [0 1000]", applied)
+	}
+
+	// once the update ran the next change queues a new one
+	obs.Set(5)
+	obs.Set(6)
+	if ran := q.run(); ran != 1 || applied[len(applied)-1] != 6 {
+		t.Errorf("ran %d updates applying %v, want 1 applying 6", ran, applied)
+	}
+}
+
+func TestUnbindDropsQueuedUpdate(t *testing.T) {
+	q := &queueInvoker{}
+	obs := NewObservable("a")
+	b := NewBinder(q)
+	applied := 0
+	Bind(b, obs, func(string) { applied++ })
+	obs.Set("b")
+	b.Unbind()
+	b.Unbind()
+	q.run()
+	obs.Set("c")
+	if ran := q.run(); applied != 1 || ran != 0 {
+		t.Errorf("applied %d values and queued %d updates after Unbind, want 1 and 0", applied, ran)
+	}
+}
+
+func TestBindTextInputValue(t *testing.T) {
+	q := &queueInvoker{}
+	obs := NewObservable("start", ValidatorOpt(NotEmpty))
+	ti := component.NewTextInput()
+	var rejected []error
+	binding := BindTextInputValue(NewBinder(q), obs, ti, ErrorHandlerOpt(func(err error) { rejected = append(rejected, err) }))
+	sets := changes(obs)
+	if ti.Value() != "start" {
+		t.Fatalf("text input value = %q, want the observable's %q", ti.Value(), "start")
+	}
+
+	// a change of obs updates the text input once, without being set back on obs
+	obs.Set("model")
+	q.run()
+	if ti.Value() != "model" || len(*sets) != 1 {
+		t.Errorf("text input = %q after %d changes of obs, want %q after 1", ti.Value(), len(*sets), "model")
+	}
+
+	// an edit in the text input is set on obs, and the update it queues leaves the text input as it is
+	ti.SetValue("typed")
+	if obs.Get() != "typed" {
+		t.Errorf("obs = %q after an edit, want %q", obs.Get(), "typed")
+	}
+	if ran := q.run(); ran != 1 || ti.Value() != "typed" || len(*sets) != 2 {
+		t.Errorf("ran %d updates leaving %q after %d changes of obs, want 1 leaving %q after 2", ran, ti.Value(), len(*sets), "typed")
+	}
+
+	// a rejected edit leaves obs unchanged and is reported
+	ti.SetValue(" ")
+	q.run()
+	if obs.Get() != "typed" || len(rejected) != 1 {
+		t.Errorf("obs = %q with %d rejections after a blank edit, want %q with 1", obs.Get(), len(rejected), "typed")
+	}
+
+	binding.Unbind()
+	ti.SetValue("after")
+	obs.Set("later")
+	q.run()
+	if obs.Get() != "later" || ti.Value() != "after" {
+		t.Errorf("obs = %q and text input = %q after Unbind, want them independent", obs.Get(), ti.Value())
+	}
+}
+
+func TestBindTextInputConverter(t *testing.T) {
+	q := &queueInvoker{}
+	count := NewObservable(1, ValidatorOpt(Range(0, 9)))
+	text := IntString(count)
+	defer text.Close()
+	ti := component.NewTextInput()
+	var rejected []error
+	BindTextInputValue(NewBinder(q), text, ti, ErrorHandlerOpt(func(err error) { rejected = append(rejected, err) }))
+
+	ti.SetValue("4")
+	q.run()
+	if count.Get() != 4 {
+		t.Errorf("count = %d after typing 4, want 4", count.Get())
+	}
+	for _, typed := range []string{"x", "12"} {
+		ti.SetValue(typed)
+		q.run()
+	}
+	if count.Get() != 4 || len(rejected) != 2 {
+		t.Fatalf("count = %d with %d rejections, want 4 with 2", count.Get(), len(rejected))
+	}
+	if errors.Is(rejected[0], ErrReadOnly) {
+		t.Errorf("rejection = %v, want the converter's error", rejected[0])
+	}
+}
@@ -0,0 +1,114 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// IntString derives a string observable from an int observable, suitable for binding to a TextInput.
+// Text that does not parse as an int is rejected with the strconv error.
+//
+// Parameters:
+//   - src: The int observable.
+//
+// Returns:
+//   - *Observable[string]: The derived string observable.
+func IntString(src *Observable[int]) *Observable[string] {
+	return Convert(src, strconv.Itoa, func(s string) (int, error) {
+		return strconv.Atoi(strings.TrimSpace(s))
+	})
+}
+
+// FloatString derives a string observable from a float64 observable, formatting with the given precision.
+// A negative precision uses the fewest digits needed to represent the value exactly.
+//
+// Parameters:
+//   - src: The float64 observable.
+//   - precision: The number of digits after the decimal point.
+//
+// Returns:
+//   - *Observable[string]: The derived string observable.
+func FloatString(src *Observable[float64], precision int) *Observable[string] {
+	return Convert(src, func(f float64) string {
+		return strconv.FormatFloat(f, 'f', precision, 64)
+	}, func(s string) (float64, error) {
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	})
+}
+
+// Format derives a read-only string observable formatting the value of src with fmt.Sprintf, for example "%d items".
+//
+// Parameters:
+//   - src: The observable to format.
+//   - format: The fmt format string, taking the value as its only argument.
+//
+// Returns:
+//   - *Observable[string]: The derived read-only string observable.
+func Format[T any](src *Observable[T], format string) *Observable[string] {
+	return Convert(src, func(v T) string {
+		return fmt.Sprintf(format, v)
+	}, nil)
+}
+
+// Not derives a bool observable holding the negation of src, for example to disable a button while a task runs.
+//
+// Parameters:
+//   - src: The bool observable.
+//
+// Returns:
+//   - *Observable[bool]: The derived bool observable.
+func Not(src *Observable[bool]) *Observable[bool] {
+	not := func(b bool) bool { return !b }
+	return Convert(src, not, func(b bool) (bool, error) {
+		return !b, nil
+	})
+}
+
+// NotEmpty is a validator rejecting strings that are empty or only whitespace.
+//
+// Parameters:
+//   - s: The string to validate.
+//
+// Returns:
+//   - error: An error if s is blank, nil otherwise.
+func NotEmpty(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return fmt.Errorf("binding: value must not be empty")
+	}
+	return nil
+}
+
+// MaxLength returns a validator rejecting strings longer than n characters.
+//
+// Parameters:
+//   - n: The maximum number of characters.
+//
+// Returns:
+//   - func(string) error: The validator.
+func MaxLength(n int) func(string) error {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("binding: value must not be longer than %d characters", n)
+		}
+		return nil
+	}
+}
+
+// Range returns a validator rejecting values outside [lo, hi].
+//
+// Parameters:
+//   - lo: The smallest accepted value.
+//   - hi: The largest accepted value.
+//
+// Returns:
+//   - func(T) error: The validator.
+func Range[T int | int32 | int64 | float32 | float64](lo, hi T) func(T) error {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("binding: value must be between %v and %v", lo, hi)
+		}
+		return nil
+	}
+}
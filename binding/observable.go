@@ -0,0 +1,191 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+var (
+	// ErrReadOnly is returned when setting an observable derived with a one-way conversion.
+	ErrReadOnly = errors.New("binding: observable is read-only")
+	// ErrClosed is returned when setting a derived observable that was closed.
+	ErrClosed = errors.New("binding: observable is closed")
+)
+
+// Observable holds a value and publishes an event.ValueChangedEvent whenever it changes.
+// Unlike components, an Observable is safe for concurrent use, so worker goroutines may Set it directly
+// and bindings carry the change over to the window's event loop.
+type Observable[T any] struct {
+	mu         sync.Mutex
+	value      T
+	events     *event.Dispatcher
+	equal      func(a, b T) bool
+	validators []func(T) error
+	// set replaces the default setter of observables derived with Convert, it forwards the value to the source.
+	set func(T) error
+	// source is the subscription of an observable derived with Convert to its source, released by Close.
+	source event.Handle
+	closed bool
+}
+
+// NewObservable creates an observable holding the initial value.
+// The initial value is not validated.
+//
+// Parameters:
+//   - initial: The value the observable starts with.
+//   - options: A variadic list of ObservableOption functions to customize the observable.
+//
+// Returns:
+//   - *Observable[T]: A pointer to the newly created observable.
+func NewObservable[T any](initial T, options ...ObservableOption[T]) *Observable[T] {
+	opts := newObservableOptions[T]()
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &Observable[T]{
+		value:      initial,
+		events:     event.NewDispatcher(),
+		equal:      opts.Equal,
+		validators: opts.Validators,
+	}
+}
+
+// Get returns the current value.
+//
+// Returns:
+//   - T: The current value of the observable.
+func (o *Observable[T]) Get() T {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// Set validates the value and stores it, publishing an event.ValueChangedEvent if it differs from the current value.
+// Listeners run on the calling goroutine after the value was stored.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - error: The error of the first validator rejecting the value, or nil if the value was accepted.
+func (o *Observable[T]) Set(value T) error {
+	if o.set != nil {
+		return o.set(value)
+	}
+	if err := o.Validate(value); err != nil {
+		return err
+	}
+	o.store(value)
+	return nil
+}
+
+// Validate runs the observable's validators against a value without storing it.
+//
+// Parameters:
+//   - value: The value to validate.
+//
+// Returns:
+//   - error: The error of the first validator rejecting the value, or nil if all validators accept it.
+func (o *Observable[T]) Validate(value T) error {
+	for _, validate := range o.validators {
+		if err := validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnChange subscribes fn to changes of the value.
+//
+// Parameters:
+//   - fn: The function called with each event.
+//
+// Returns:
+//   - event.Handle: The handle used to unsubscribe fn.
+func (o *Observable[T]) OnChange(fn func(event.ValueChangedEvent[T])) event.Handle {
+	return event.Subscribe(o.events, fn)
+}
+
+// Events returns the dispatcher the observable publishes its event.ValueChangedEvent on.
+//
+// Returns:
+//   - *event.Dispatcher: The event dispatcher of the observable.
+func (o *Observable[T]) Events() *event.Dispatcher {
+	return o.events
+}
+
+// Close detaches an observable derived with Convert from its source, so it no longer follows the source and can be
+// garbage collected together with its listeners. Setting it afterwards returns ErrClosed.
+// Closing an observable that was not derived has no effect. Calling Close more than once is a no-op.
+func (o *Observable[T]) Close() {
+	o.mu.Lock()
+	o.closed = true
+	source := o.source
+	o.mu.Unlock()
+	source.Unsubscribe()
+}
+
+// isClosed reports whether Close was called.
+func (o *Observable[T]) isClosed() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.closed
+}
+
+// store replaces the value without validation and publishes the change.
+func (o *Observable[T]) store(value T) {
+	o.mu.Lock()
+	old := o.value
+	if o.equal(old, value) {
+		o.mu.Unlock()
+		return
+	}
+	o.value = value
+	o.mu.Unlock()
+
+	event.Publish(o.events, event.ValueChangedEvent[T]{Old: old, New: value})
+}
+
+// Convert derives an observable whose value is the value of src passed through to.
+// Setting the derived observable converts the value back with from and sets src, so src's validators still apply.
+// With a nil from the derived observable is read-only and Set returns ErrReadOnly.
+// The derived observable follows src until it is closed, call Close once it is no longer used.
+//
+// Parameters:
+//   - src: The observable to derive from.
+//   - to: The function converting values of src.
+//   - from: The function converting values back to src, or nil for a one-way conversion.
+//
+// Returns:
+//   - *Observable[D]: The derived observable.
+func Convert[S, D any](src *Observable[S], to func(S) D, from func(D) (S, error)) *Observable[D] {
+	dst := NewObservable(to(src.Get()))
+	dst.set = func(value D) error {
+		if dst.isClosed() {
+			return ErrClosed
+		}
+		if from == nil {
+			return ErrReadOnly
+		}
+		converted, err := from(value)
+		if err != nil {
+			return err
+		}
+		return src.Set(converted)
+	}
+	dst.source = src.OnChange(func(e event.ValueChangedEvent[S]) {
+		// a change published while Close runs may still arrive
+		if !dst.isClosed() {
+			dst.store(to(e.New))
+		}
+	})
+	return dst
+}
+
+// deepEqual is the default equality of observables.
+func deepEqual[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,42 @@
+package binding
+
+type observableOptions[T any] struct {
+	Equal      func(a, b T) bool
+	Validators []func(T) error
+}
+
+type ObservableOption[T any] func(*observableOptions[T])
+
+func newObservableOptions[T any]() *observableOptions[T] {
+	return &observableOptions[T]{
+		Equal:      deepEqual[T],
+		Validators: nil,
+	}
+}
+
+// EqualOpt sets the function deciding whether a new value differs from the current one.
+// By default values are compared with reflect.DeepEqual.
+//
+// Parameters:
+//   - equal: The function reporting whether two values are equal.
+//
+// Returns:
+//   - ObservableOption[T]: A function that takes a pointer to observableOptions and sets the Equal field.
+func EqualOpt[T any](equal func(a, b T) bool) ObservableOption[T] {
+	return func(opts *observableOptions[T]) {
+		opts.Equal = equal
+	}
+}
+
+// ValidatorOpt adds a validator rejecting values passed to Set. Validators run in the order they were added.
+//
+// Parameters:
+//   - validate: The function returning an error for values that must not be stored.
+//
+// Returns:
+//   - ObservableOption[T]: A function that takes a pointer to observableOptions and appends to the Validators field.
+func ValidatorOpt[T any](validate func(T) error) ObservableOption[T] {
+	return func(opts *observableOptions[T]) {
+		opts.Validators = append(opts.Validators, validate)
+	}
+}
@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// changes records the values published by an observable.
+func changes[T any](o *Observable[T]) *[]T {
+	var got []T
+	o.OnChange(func(e event.ValueChangedEvent[T]) {
+		got = append(got, e.New)
+	})
+	return &got
+}
+
+func TestObservableSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []ObservableOption[string]
+		sets        []string
+		want        string
+		wantChanges int
+		wantErrs    int
+	}{
+		{name: "publishes changes", sets: []string{"a", "b"}, want: "b", wantChanges: 2},
+		{name: "skips equal values", sets: []string{"a", "a", ""}, want: "", wantChanges: 2},
+		{name: "validator rejects", options: []ObservableOption[string]{ValidatorOpt(NotEmpty)}, sets: []string{"a", " ", ""}, want: "a", wantChanges: 1, wantErrs: 2},
+		{name: "validators run in order", options: []ObservableOption[string]{ValidatorOpt(MaxLength(3)), ValidatorOpt(NotEmpty)}, sets: []string{"abcd", "abc"}, want: "abc", wantChanges: 1, wantErrs: 1},
+		{name: "custom equality", options: []ObservableOption[string]{EqualOpt(func(a, b string) bool { return len(a) == len(b) })}, sets: []string{"a", "b", "cd"}, want: "cd", wantChanges: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewObservable("", tt.options...)
+			got := changes(o)
+			errs := 0
+			for _, v := range tt.sets {
+				if err := o.Set(v); err != nil {
+					errs++
+				}
+			}
+			if o.Get() != tt.want || len(*got) != tt.wantChanges || errs != tt.wantErrs {
+				t.Errorf("Get() = %q with %d changes and %d errors, want %q with %d changes and %d errors",
+					o.Get(), len(*got), errs, tt.want, tt.wantChanges, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	src := NewObservable(1, ValidatorOpt(Range(0, 10)))
+	dst := IntString(src)
+	if dst.Get() != "1" {
+		t.Fatalf("derived value = %q, want %q", dst.Get(), "1")
+	}
+	src.Set(7)
+	if dst.Get() != "7" {
+		t.Errorf("derived value after setting the source = %q, want %q", dst.Get(), "7")
+	}
+	if err := dst.Set(" 3 "); err != nil || src.Get() != 3 || dst.Get() != "3" {
+		t.Errorf("Set(\" 3 \") = %v with source %d and derived %q, want nil, 3, %q", err, src.Get(), dst.Get(), "3")
+	}
+	// the converter and the source's validators both reject values
+	var numErr *strconv.NumError
+	if err := dst.Set("abc"); !errors.As(err, &numErr) || src.Get() != 3 {
+		t.Errorf("Set(\"abc\") = %v with source %d, want a strconv error and 3", err, src.Get())
+	}
+	if err := dst.Set("11"); err == nil || src.Get() != 3 {
+		t.Errorf("Set(\"11\") = %v with source %d, want a range error and 3", err, src.Get())
+	}
+	if err := Format(src, "%d items").Set("4 items"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Set on a one-way conversion = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestConvertClose(t *testing.T) {
+	src := NewObservable(false)
+	dst := Not(src)
+	got := changes(dst)
+	src.Set(true)
+
+	dst.Close()
+	dst.Close()
+	if event.HasListeners[event.ValueChangedEvent[bool]](src.Events()) {
+		t.Errorf("closed observable is still subscribed to its source")
+	}
+	src.Set(false)
+	if dst.Get() != false || len(*got) != 1 {
+		t.Errorf("closed observable = %v after %d changes, want false after 1", dst.Get(), len(*got))
+	}
+	if err := dst.Set(true); !errors.Is(err, ErrClosed) || src.Get() != false {
+		t.Errorf("Set on a closed observable = %v with source %v, want ErrClosed and false", err, src.Get())
+	}
+	// closing an observable that was not derived leaves it working
+	src.Close()
+	if err := src.Set(true); err != nil || src.Get() != true {
+		t.Errorf("Set after closing a source = %v with %v, want nil and true", err, src.Get())
+	}
+}
@@ -0,0 +1,58 @@
+// Package clipboard reads and writes the system clipboard, for text and images.
+// On Linux the clipboard is the X11 CLIPBOARD selection, whose data is served by the application which set it for as
+// long as it runs. Reading from another application waits for it to hand the data over.
+package clipboard
+
+import (
+	"errors"
+	"image"
+)
+
+var (
+	// ErrEmpty is returned when the clipboard holds nothing of the requested kind.
+	ErrEmpty = errors.New("clipboard: no data of the requested kind")
+	// ErrUnavailable is returned when the clipboard could not be reached, for example without an X display,
+	// while another application keeps it open or when the application owning it does not respond.
+	ErrUnavailable = errors.New("clipboard: unavailable")
+)
+
+// GetText returns the text on the clipboard.
+//
+// Returns:
+//   - string: The text on the clipboard.
+//   - error: ErrEmpty if the clipboard holds no text, an error wrapping ErrUnavailable if it could not be read.
+func GetText() (string, error) {
+	return getText()
+}
+
+// SetText replaces the contents of the clipboard with text.
+//
+// Parameters:
+//   - text: The text to put on the clipboard.
+//
+// Returns:
+//   - error: An error wrapping ErrUnavailable if the clipboard could not be written.
+func SetText(text string) error {
+	return setText(text)
+}
+
+// GetImage returns the image on the clipboard.
+//
+// Returns:
+//   - image.Image: The image on the clipboard.
+//   - error: ErrEmpty if the clipboard holds no image, an error wrapping ErrUnavailable if it could not be read,
+//     or the error decoding an image in a format that is not supported.
+func GetImage() (image.Image, error) {
+	return getImage()
+}
+
+// SetImage replaces the contents of the clipboard with an image.
+//
+// Parameters:
+//   - img: The image to put on the clipboard.
+//
+// Returns:
+//   - error: An error wrapping ErrUnavailable if the clipboard could not be written.
+func SetImage(img image.Image) error {
+	return setImage(img)
+}
@@ -0,0 +1,54 @@
+//go:build linux
+// +build linux
+
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/Carmen-Shannon/gooey/internal/linux"
+)
+
+// imageTarget is the selection target images are exchanged as.
+const imageTarget = "image/png"
+
+func getText() (string, error) {
+	text, err := linux.GetSelectionText(linux.SelectionClipboard)
+	return text, clipboardError(err)
+}
+
+func setText(text string) error {
+	return clipboardError(linux.SetSelectionText(linux.SelectionClipboard, text))
+}
+
+func getImage() (image.Image, error) {
+	data, err := linux.GetSelection(linux.SelectionClipboard, imageTarget)
+	if err != nil {
+		return nil, clipboardError(err)
+	}
+	return png.Decode(bytes.NewReader(data))
+}
+
+func setImage(img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	return clipboardError(linux.SetSelection(linux.SelectionClipboard, map[string][]byte{imageTarget: buf.Bytes()}))
+}
+
+// clipboardError turns an error of the selection backend into ErrEmpty or an error wrapping ErrUnavailable.
+func clipboardError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, linux.ErrSelectionEmpty):
+		return ErrEmpty
+	default:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+}
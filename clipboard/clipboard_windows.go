@@ -0,0 +1,154 @@
+//go:build windows
+// +build windows
+
+package clipboard
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+)
+
+const (
+	// bitmapHeaderSize is the size of the BITMAPINFOHEADER starting a CF_DIB.
+	bitmapHeaderSize = 40
+	biRGB            = 0
+	biBitfields      = 3
+)
+
+func getText() (string, error) {
+	text, err := wdws.GetClipboardText()
+	return text, clipboardError(err)
+}
+
+func setText(text string) error {
+	return clipboardError(wdws.SetClipboardText(text))
+}
+
+// getImage prefers the PNG format browsers and image editors put on the clipboard, which keeps transparency,
+// over the device independent bitmap every application understands.
+func getImage() (image.Image, error) {
+	if format := wdws.RegisterClipboardFormat("PNG"); format != 0 {
+		data, err := wdws.GetClipboardData(format)
+		if err == nil {
+			return png.Decode(bytes.NewReader(data))
+		}
+		if !errors.Is(err, wdws.ErrClipboardEmpty) {
+			return nil, clipboardError(err)
+		}
+	}
+	data, err := wdws.GetClipboardData(wdws.CF_DIB)
+	if err != nil {
+		return nil, clipboardError(err)
+	}
+	return decodeDIB(data)
+}
+
+func setImage(img image.Image) error {
+	data := map[uint32][]byte{wdws.CF_DIB: encodeDIB(img)}
+	if format := wdws.RegisterClipboardFormat("PNG"); format != 0 {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		data[format] = buf.Bytes()
+	}
+	return clipboardError(wdws.SetClipboardData(data))
+}
+
+// clipboardError turns an error of the clipboard backend into ErrEmpty or an error wrapping ErrUnavailable.
+func clipboardError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, wdws.ErrClipboardEmpty):
+		return ErrEmpty
+	default:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+}
+
+// decodeDIB decodes an uncompressed 24 or 32 bit device independent bitmap.
+// 32 bit bitmaps whose alpha channel is all zero, as most applications write them, are taken as opaque.
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < bitmapHeaderSize {
+		return nil, errors.New("clipboard: bitmap header is truncated")
+	}
+	headerSize := int(binary.LittleEndian.Uint32(data[0:]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:])))
+	bits := int(binary.LittleEndian.Uint16(data[14:]))
+	compression := binary.LittleEndian.Uint32(data[16:])
+	if bits != 24 && bits != 32 || compression != biRGB && compression != biBitfields {
+		return nil, fmt.Errorf("clipboard: unsupported bitmap with %d bits per pixel and compression %d", bits, compression)
+	}
+
+	offset := headerSize
+	if compression == biBitfields && headerSize == bitmapHeaderSize {
+		// the color masks follow the header, the standard ones are assumed
+		offset += 12
+	}
+	bottomUp := height > 0
+	if !bottomUp {
+		height = -height
+	}
+	stride := (width*bits + 31) / 32 * 4
+	if width <= 0 || height <= 0 || offset+stride*height > len(data) {
+		return nil, errors.New("clipboard: bitmap data is truncated")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	opaque := bits == 24
+	for y := 0; y < height; y++ {
+		row := y
+		if bottomUp {
+			row = height - 1 - y
+		}
+		src := data[offset+row*stride:]
+		for x := 0; x < width; x++ {
+			px := src[x*bits/8:]
+			a := uint8(0xff)
+			if bits == 32 {
+				a = px[3]
+				opaque = opaque || a != 0
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: px[2], G: px[1], B: px[0], A: a})
+		}
+	}
+	if !opaque {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+	return img, nil
+}
+
+// encodeDIB encodes an image as a 32 bit bottom-up device independent bitmap.
+func encodeDIB(img image.Image) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	data := make([]byte, bitmapHeaderSize+width*height*4)
+	binary.LittleEndian.PutUint32(data[0:], bitmapHeaderSize)
+	binary.LittleEndian.PutUint32(data[4:], uint32(int32(width)))
+	binary.LittleEndian.PutUint32(data[8:], uint32(int32(height)))
+	binary.LittleEndian.PutUint16(data[12:], 1)
+	binary.LittleEndian.PutUint16(data[14:], 32)
+	binary.LittleEndian.PutUint32(data[16:], biRGB)
+	binary.LittleEndian.PutUint32(data[20:], uint32(width*height*4))
+
+	px := data[bitmapHeaderSize:]
+	for y := 0; y < height; y++ {
+		row := px[(height-1-y)*width*4:]
+		for x := 0; x < width; x++ {
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			row[x*4], row[x*4+1], row[x*4+2], row[x*4+3] = c.B, c.G, c.R, c.A
+		}
+	}
+	return data
+}
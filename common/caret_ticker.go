@@ -14,6 +14,7 @@ type CaretTicker struct {
 	Visible   bool
 	FocusedID uintptr
 	WindowID  uintptr
+	stop      chan struct{}
 }
 
 // NewCaretTicker creates a new instance of CaretTicker with default values.
@@ -49,10 +50,18 @@ func (c *CaretTicker) Start(windowID, focusedID uintptr) {
 	c.WindowID = windowID
 	c.Visible = true
 	c.T = time.NewTicker(500 * time.Millisecond)
+	c.stop = make(chan struct{})
+	// the goroutine keeps its own references, Stop replaces the fields while it may still be running
+	ticker, stop := c.T, c.stop
 
 	c.Mu.Unlock()
 	go func() {
-		for range c.T.C {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
 			c.Mu.Lock()
 			if !c.Active {
 				c.Mu.Unlock()
@@ -64,6 +73,17 @@ func (c *CaretTicker) Start(windowID, focusedID uintptr) {
 	}()
 }
 
+// IsVisible reports whether the caret is currently shown.
+// Unlike reading Visible directly it is safe while the ticker goroutine toggles the caret.
+//
+// Returns:
+//   - bool: True if the caret should be drawn, false otherwise.
+func (c *CaretTicker) IsVisible() bool {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	return c.Visible
+}
+
 // Stop stops the caret ticker and resets its state.
 // It stops the ticker and sets the Active, Visible, and FocusedID fields to their default values.
 func (c *CaretTicker) Stop() {
@@ -73,6 +93,10 @@ func (c *CaretTicker) Stop() {
 		c.T.Stop()
 		c.T = nil
 	}
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
 	if !c.Active && !c.Visible && c.FocusedID == 0 {
 		return
 	}
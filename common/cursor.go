@@ -0,0 +1,89 @@
+package common
+
+import "image"
+
+// CursorShape identifies a standard mouse pointer shape of the platform.
+type CursorShape int
+
+const (
+	CursorShapeArrow CursorShape = iota
+	CursorShapeIBeam
+	CursorShapeHand
+	CursorShapeCrosshair
+	// CursorShapeResizeEW is the horizontal resize arrow, CursorShapeResizeNS the vertical one.
+	CursorShapeResizeEW
+	CursorShapeResizeNS
+	// CursorShapeResizeNWSE is the diagonal resize arrow from top-left to bottom-right, CursorShapeResizeNESW the other diagonal.
+	CursorShapeResizeNWSE
+	CursorShapeResizeNESW
+	// CursorShapeMove is the four-way arrow used for moving things.
+	CursorShapeMove
+	CursorShapeWait
+	CursorShapeNotAllowed
+	// CursorShapeImage is a custom cursor drawn from an image, see ImageCursor.
+	CursorShapeImage
+)
+
+// Cursor describes a mouse pointer, either a standard shape of the platform or a custom image.
+// Backends create the native cursor the first time it is shown and reuse it afterwards,
+// so a cursor must not be modified once it is in use.
+type Cursor struct {
+	Shape CursorShape
+	// Image is the picture of a CursorShapeImage cursor, its alpha channel is kept.
+	Image image.Image
+	// HotX and HotY are the point of the image that is the pointer's position.
+	HotX int32
+	HotY int32
+}
+
+// The standard cursors, can be used anywhere throughout the code.
+var (
+	CursorArrow      = &Cursor{Shape: CursorShapeArrow}
+	CursorIBeam      = &Cursor{Shape: CursorShapeIBeam}
+	CursorHand       = &Cursor{Shape: CursorShapeHand}
+	CursorCrosshair  = &Cursor{Shape: CursorShapeCrosshair}
+	CursorResizeEW   = &Cursor{Shape: CursorShapeResizeEW}
+	CursorResizeNS   = &Cursor{Shape: CursorShapeResizeNS}
+	CursorResizeNWSE = &Cursor{Shape: CursorShapeResizeNWSE}
+	CursorResizeNESW = &Cursor{Shape: CursorShapeResizeNESW}
+	CursorMove       = &Cursor{Shape: CursorShapeMove}
+	CursorWait       = &Cursor{Shape: CursorShapeWait}
+	CursorNotAllowed = &Cursor{Shape: CursorShapeNotAllowed}
+)
+
+// ImageCursor creates a custom cursor from an image.
+//
+// Parameters:
+//   - img: The picture of the cursor.
+//   - hotX: The x-coordinate of the image point that is the pointer's position.
+//   - hotY: The y-coordinate of the image point that is the pointer's position.
+//
+// Returns:
+//   - *Cursor: A pointer to the newly created cursor.
+func ImageCursor(img image.Image, hotX, hotY int32) *Cursor {
+	return &Cursor{Shape: CursorShapeImage, Image: img, HotX: hotX, HotY: hotY}
+}
+
+// ARGB returns the pixels of the cursor's image as premultiplied 0xAARRGGBB values, row by row from the top.
+// It returns nil for cursors without an image.
+//
+// Returns:
+//   - []uint32: The pixels of the image.
+//   - int: The width of the image.
+//   - int: The height of the image.
+func (c *Cursor) ARGB() ([]uint32, int, int) {
+	if c.Image == nil {
+		return nil, 0, 0
+	}
+	bounds := c.Image.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	pixels := make([]uint32, 0, w*h)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// RGBA returns 16-bit premultiplied channels
+			r, g, b, a := c.Image.At(x, y).RGBA()
+			pixels = append(pixels, (a>>8)<<24|(r>>8)<<16|(g>>8)<<8|b>>8)
+		}
+	}
+	return pixels, w, h
+}
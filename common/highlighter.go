@@ -8,6 +8,10 @@ type Highlighter struct {
 	SelectionEnd      int32
 	Active            bool
 	SuppressSelection bool
+	// GoalX is the horizontal offset moving the caret of a text area up and down aims for.
+	// It only applies while the caret is still at GoalPos, where the last vertical move left it.
+	GoalX   int32
+	GoalPos int32
 }
 
 // NewHighlighter creates a new instance of a Highlighter with default values.
@@ -21,5 +25,7 @@ func NewHighlighter() *Highlighter {
 		SelectionEnd:      0,
 		Active:            false,
 		SuppressSelection: false,
+		GoalX:             -1,
+		GoalPos:           -1,
 	}
 }
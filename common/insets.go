@@ -0,0 +1,21 @@
+package common
+
+// Insets represents the space reserved on each side of a rectangle.
+// It is used for padding inside of containers and margins around components.
+type Insets struct {
+	Top    int32
+	Right  int32
+	Bottom int32
+	Left   int32
+}
+
+// UniformInsets creates Insets with the same value on every side.
+//
+// Parameters:
+//   - v: The value to use for each side.
+//
+// Returns:
+//   - Insets: The created Insets.
+func UniformInsets(v int32) Insets {
+	return Insets{Top: v, Right: v, Bottom: v, Left: v}
+}
@@ -0,0 +1,20 @@
+package common
+
+import "github.com/Carmen-Shannon/gooey/event"
+
+// KeyInputKind identifies the kind of raw keyboard input a backend reports to its window.
+type KeyInputKind int
+
+const (
+	KeyInputDown KeyInputKind = iota
+	KeyInputUp
+)
+
+// KeyInput is a single key press or release as reported by a backend.
+// The window publishes it as a key event and matches it against its shortcuts.
+type KeyInput struct {
+	Kind      KeyInputKind
+	Key       event.Key
+	Modifiers event.Modifiers
+	Repeat    bool
+}
@@ -0,0 +1,26 @@
+package common
+
+import "github.com/Carmen-Shannon/gooey/event"
+
+// MouseInputKind identifies the kind of raw mouse input a backend reports to its window.
+type MouseInputKind int
+
+const (
+	MouseInputMove MouseInputKind = iota
+	MouseInputDown
+	MouseInputUp
+	MouseInputWheel
+	MouseInputLeave
+)
+
+// MouseInput is a single piece of raw mouse input in window coordinates as reported by a backend.
+// The window turns it into the component events of the event package.
+type MouseInput struct {
+	Kind      MouseInputKind
+	X         int32
+	Y         int32
+	Button    event.MouseButton
+	Modifiers event.Modifiers
+	DeltaX    int32
+	DeltaY    int32
+}
@@ -9,3 +9,37 @@ type Rect struct {
 	W int32
 	H int32
 }
+
+// Contains reports whether the point lies inside the rectangle.
+//
+// Parameters:
+//   - x: The x-coordinate of the point.
+//   - y: The y-coordinate of the point.
+//
+// Returns:
+//   - bool: True if the point is inside the rectangle, false otherwise.
+func (r Rect) Contains(x, y int32) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// Intersect returns the area shared by both rectangles.
+// The result has a zero width or height when the rectangles do not overlap.
+//
+// Parameters:
+//   - o: The rectangle to intersect with.
+//
+// Returns:
+//   - Rect: The intersection of the two rectangles.
+func (r Rect) Intersect(o Rect) Rect {
+	x1, y1 := max(r.X, o.X), max(r.Y, o.Y)
+	x2, y2 := min(r.X+r.W, o.X+o.W), min(r.Y+r.H, o.Y+o.H)
+	return Rect{X: x1, Y: y1, W: max(0, x2-x1), H: max(0, y2-y1)}
+}
+
+// Empty reports whether the rectangle has no area.
+//
+// Returns:
+//   - bool: True if the width or height is zero or negative, false otherwise.
+func (r Rect) Empty() bool {
+	return r.W <= 0 || r.H <= 0
+}
@@ -0,0 +1,30 @@
+package common
+
+import "github.com/Carmen-Shannon/gooey/event"
+
+// ScrollViewState represents the state of a scroll view component.
+//
+// The mouse input reaches the scroll view through its window, the backends only hit-test the bounds to remember
+// the scroll view last pressed or wheeled over and publish the scrolling keys to it as event.KeyDownEvent on Events.
+// The scrolling math itself lives in the component so it behaves the same on every platform.
+type ScrollViewState struct {
+	ID     uintptr
+	Bounds Rect
+	Clip   *Rect
+	Events *event.Dispatcher
+}
+
+// HitTest reports whether the point lies inside the visible part of the scroll view.
+//
+// Parameters:
+//   - x: The x-coordinate of the point.
+//   - y: The y-coordinate of the point.
+//
+// Returns:
+//   - bool: True if the point is inside the scroll view and its clip, false otherwise.
+func (s *ScrollViewState) HitTest(x, y int32) bool {
+	if !s.Bounds.Contains(x, y) {
+		return false
+	}
+	return s.Clip == nil || s.Clip.Contains(x, y)
+}
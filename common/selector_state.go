@@ -1,16 +1,22 @@
 package common
 
+import "github.com/Carmen-Shannon/gooey/event"
+
 // SelectorState represents the state of a selector component.
-// It contains information about the selector's ID, drawing state, blocking state, bounds, color, opacity, visibility, and a callback map.
+// It contains information about the selector's ID, drawing state, blocking state, bounds, color, opacity, visibility, and an event dispatcher.
+// ID holds the handle of the overlay window while it is open, the events carry ComponentID as their source instead.
+// Like TextInputState, the update functions record events which the backend publishes with TakeEvents after releasing its lock.
 type SelectorState struct {
-	ID       uintptr
-	Drawing  bool
-	Blocking bool
-	Bounds   Rect
-	Color    *Color
-	Opacity  float32
-	Visible  bool
-	CbMap    map[string]func(any)
+	ID          uintptr
+	ComponentID uintptr
+	Drawing     bool
+	Blocking    bool
+	Bounds      Rect
+	Color       *Color
+	Opacity     float32
+	Visible     bool
+	Events      *event.Dispatcher
+	pending     []func()
 }
 
 type UpdateSelectorState func(state *SelectorState)
@@ -37,8 +43,10 @@ func UpdateSelectorID(id uintptr) UpdateSelectorState {
 //   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its Drawing field.
 func UpdateSelectorDrawing(drawing bool) UpdateSelectorState {
 	return func(state *SelectorState) {
-		state.Drawing = drawing
-		state.CbMap["drawing"](drawing)
+		if state.Drawing != drawing {
+			state.Drawing = drawing
+			queueSelectorEvent(state, event.DrawingChangedEvent{Source: state.ComponentID, Drawing: drawing})
+		}
 	}
 }
 
@@ -51,8 +59,10 @@ func UpdateSelectorDrawing(drawing bool) UpdateSelectorState {
 //   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its Blocking field.
 func UpdateSelectorBlocking(blocking bool) UpdateSelectorState {
 	return func(state *SelectorState) {
-		state.Blocking = blocking
-		state.CbMap["blocking"](blocking)
+		if state.Blocking != blocking {
+			state.Blocking = blocking
+			queueSelectorEvent(state, event.BlockingChangedEvent{Source: state.ComponentID, Blocking: blocking})
+		}
 	}
 }
 
@@ -65,8 +75,10 @@ func UpdateSelectorBlocking(blocking bool) UpdateSelectorState {
 //   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its Bounds field.
 func UpdateSelectorBounds(bounds Rect) UpdateSelectorState {
 	return func(state *SelectorState) {
-		state.Bounds = bounds
-		state.CbMap["bounds"](bounds)
+		if state.Bounds != bounds {
+			state.Bounds = bounds
+			queueSelectorEvent(state, event.BoundsChangedEvent{Source: state.ComponentID, X: bounds.X, Y: bounds.Y, Width: bounds.W, Height: bounds.H})
+		}
 	}
 }
 
@@ -105,20 +117,51 @@ func UpdateSelectorOpacity(opacity float32) UpdateSelectorState {
 //   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its Visible field.
 func UpdateSelectorVisible(visible bool) UpdateSelectorState {
 	return func(state *SelectorState) {
-		state.Visible = visible
-		state.CbMap["visible"](visible)
+		if state.Visible != visible {
+			state.Visible = visible
+			queueSelectorEvent(state, event.VisibilityChangedEvent{Source: state.ComponentID, Visible: visible})
+		}
 	}
 }
 
-// UpdateSelectorCbMap updates the callback map of the selector.
+// UpdateSelectorEvents updates the event dispatcher of the selector.
 //
 // Parameters:
-//   - cbMap: A map of string keys to callback functions.
+//   - events: The dispatcher the selector's events are published on.
 //
 // Returns:
-//   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its CbMap field.
-func UpdateSelectorCbMap(cbMap map[string]func(any)) UpdateSelectorState {
+//   - UpdateSelectorState: A function that takes a pointer to SelectorState and updates its Events field.
+func UpdateSelectorEvents(events *event.Dispatcher) UpdateSelectorState {
 	return func(state *SelectorState) {
-		state.CbMap = cbMap
+		state.Events = events
 	}
 }
+
+// TakeEvents returns the events recorded by the update functions since the last call and clears them.
+// Calling each returned function publishes one event on the selector's dispatcher.
+//
+// Returns:
+//   - []func(): The recorded events in the order they occurred.
+func (state *SelectorState) TakeEvents() []func() {
+	pending := state.pending
+	state.pending = nil
+	return pending
+}
+
+// Snapshot returns a copy of the state without the events still waiting to be published.
+//
+// Returns:
+//   - SelectorState: The copy of the state.
+func (state *SelectorState) Snapshot() SelectorState {
+	snap := *state
+	snap.pending = nil
+	return snap
+}
+
+// queueSelectorEvent records an event of the selector state to be published by the backend later.
+func queueSelectorEvent[T any](state *SelectorState, ev T) {
+	events := state.Events
+	state.pending = append(state.pending, func() {
+		event.Publish(events, ev)
+	})
+}
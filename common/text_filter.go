@@ -0,0 +1,75 @@
+package common
+
+import "regexp"
+
+// TextFilter limits what typing and pasting may insert into a text input.
+// Characters Rune rejects are dropped while the rest still go in, then the value the insertion results in is checked
+// once with Value and the insertion dropped as a whole if it is rejected.
+// Deleting text is never filtered, nor is setting the value from code.
+type TextFilter struct {
+	// Rune accepts or rejects a single character, nil accepts every character.
+	Rune func(ch rune) bool
+	// Value accepts or rejects the value typing or pasting would result in, cut to the maximum length,
+	// nil accepts every value. It sees incomplete input while the user types, so it must accept every beginning of a
+	// valid value.
+	Value func(value string) bool
+}
+
+// The standard filters, can be used anywhere throughout the code.
+var (
+	// FilterDigits accepts the digits 0 to 9.
+	FilterDigits = &TextFilter{Rune: isDigit}
+	// FilterDecimal accepts a decimal number with an optional leading minus and at most one decimal point.
+	FilterDecimal = &TextFilter{Value: isDecimalPrefix}
+	// FilterHex accepts hexadecimal digits in either case.
+	FilterHex = &TextFilter{Rune: isHexDigit}
+)
+
+// RuneFilter creates a filter accepting the characters accept returns true for.
+//
+// Parameters:
+//   - accept: Reports whether a character may be inserted.
+//
+// Returns:
+//   - *TextFilter: The filter.
+func RuneFilter(accept func(ch rune) bool) *TextFilter {
+	return &TextFilter{Rune: accept}
+}
+
+// RegexpFilter creates a filter accepting the values re matches.
+// The expression is matched against incomplete input as well, so it should be anchored and allow every beginning of
+// a valid value, for example `^[A-Z]{0,3}-?[0-9]{0,4}$` rather than `^[A-Z]{3}-[0-9]{4}$`.
+//
+// Parameters:
+//   - re: The expression values must match.
+//
+// Returns:
+//   - *TextFilter: The filter.
+func RegexpFilter(re *regexp.Regexp) *TextFilter {
+	return &TextFilter{Value: re.MatchString}
+}
+
+// isDigit reports whether ch is one of the ASCII digits.
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// isHexDigit reports whether ch is a hexadecimal digit.
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+// isDecimalPrefix reports whether value is the beginning of a decimal number such as -12.5.
+func isDecimalPrefix(value string) bool {
+	point := false
+	for i, ch := range value {
+		switch {
+		case ch == '-' && i == 0:
+		case ch == '.' && !point:
+			point = true
+		case !isDigit(ch):
+			return false
+		}
+	}
+	return true
+}
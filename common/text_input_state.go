@@ -1,9 +1,19 @@
 package common
 
+import (
+	"strings"
+
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
+)
+
 // TextInputState represents the state of a text input component.
 //
 // This is required for managing the values between the UI and the backend.
+// The update functions record typed events on the state instead of publishing them directly, because they run
+// while the backend holds its state lock. The backend publishes them with TakeEvents once the lock is released.
 type TextInputState struct {
+	ID             uintptr
 	Value          string
 	MaxLength      int32
 	Font           Font
@@ -17,9 +27,40 @@ type TextInputState struct {
 		Width  int32
 		Height int32
 	}
-	CbMap map[string]func(any)
+	// Clip limits the area of the text input that reacts to the mouse, nil meaning the whole bounds.
+	// It is set when the text input sits inside a scrolling container.
+	Clip *Rect
+	// Multiline is set for text areas, whose value may hold line breaks and is laid out by Layout.
+	Multiline bool
+	// MaxLines limits the number of lines of a text area, 0 for no limit.
+	MaxLines int32
+	// Wrap makes a text area break lines which are wider than its text rectangle.
+	Wrap bool
+	// LineHeight is the height of a line of a text area in pixels, set by the component as it draws.
+	LineHeight int32
+	// Scroll is how far the text is scrolled, owned by the component. Text inputs only scroll horizontally.
+	Scroll struct {
+		X int32
+		Y int32
+	}
+	// Mask is the character drawn for each character of a password field's value, 0 when the value is shown.
+	// A masked value is never copied or cut, and the mouse maps onto the masked text.
+	Mask rune
+	// Filter limits what typing and pasting insert, nil when anything goes.
+	Filter *TextFilter
+	// Preedit is the text an input method is composing at the caret, drawn underlined until it is committed to the value.
+	Preedit string
+	// PreeditCaret is the position of the input method's cursor inside Preedit, in characters.
+	PreeditCaret int32
+	// History holds the undo and redo steps of the text input's edits, nil when it keeps none.
+	History *textedit.History
+	Events  *event.Dispatcher
+	pending []func()
 }
 
+// TextPadding is the space between the border of a text input and its text, in pixels.
+const TextPadding = 4
+
 type UpdateTextInputState func(state *TextInputState)
 
 // UpdateTIValue updates the value of the text input state.
@@ -31,8 +72,11 @@ type UpdateTextInputState func(state *TextInputState)
 //   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its value.
 func UpdateTIStateValue(value string) UpdateTextInputState {
 	return func(state *TextInputState) {
+		old := state.Value
 		state.Value = value
-		state.CbMap["value"](value)
+		if old != value {
+			queueEvent(state, event.ValueChangedEvent[string]{Source: state.ID, Old: old, New: value})
+		}
 	}
 }
 
@@ -46,7 +90,6 @@ func UpdateTIStateValue(value string) UpdateTextInputState {
 func UpdateTIMaxLength(maxLength int32) UpdateTextInputState {
 	return func(state *TextInputState) {
 		state.MaxLength = maxLength
-		state.CbMap["maxLength"](maxLength)
 	}
 }
 
@@ -72,8 +115,10 @@ func UpdateTIFont(font Font) UpdateTextInputState {
 //   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its SelectionStart field.
 func UpdateTISelectionStart(selectionStart int32) UpdateTextInputState {
 	return func(state *TextInputState) {
-		state.SelectionStart = selectionStart
-		state.CbMap["selectionStart"](selectionStart)
+		if state.SelectionStart != selectionStart {
+			state.SelectionStart = selectionStart
+			queueEvent(state, event.SelectionChangedEvent{Source: state.ID, Start: state.SelectionStart, End: state.SelectionEnd})
+		}
 	}
 }
 
@@ -86,8 +131,10 @@ func UpdateTISelectionStart(selectionStart int32) UpdateTextInputState {
 //   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its SelectionEnd field.
 func UpdateTISelectionEnd(selectionEnd int32) UpdateTextInputState {
 	return func(state *TextInputState) {
-		state.SelectionEnd = selectionEnd
-		state.CbMap["selectionEnd"](selectionEnd)
+		if state.SelectionEnd != selectionEnd {
+			state.SelectionEnd = selectionEnd
+			queueEvent(state, event.SelectionChangedEvent{Source: state.ID, Start: state.SelectionStart, End: state.SelectionEnd})
+		}
 	}
 }
 
@@ -100,8 +147,10 @@ func UpdateTISelectionEnd(selectionEnd int32) UpdateTextInputState {
 //   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its CaretPos field.
 func UpdateTICaretPos(caretPos int32) UpdateTextInputState {
 	return func(state *TextInputState) {
-		state.CaretPos = caretPos
-		state.CbMap["caretPos"](caretPos)
+		if state.CaretPos != caretPos {
+			state.CaretPos = caretPos
+			queueEvent(state, event.CaretMovedEvent{Source: state.ID, Pos: caretPos})
+		}
 	}
 }
 
@@ -114,8 +163,10 @@ func UpdateTICaretPos(caretPos int32) UpdateTextInputState {
 //   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its Focused field.
 func UpdateTIFocused(focused bool) UpdateTextInputState {
 	return func(state *TextInputState) {
-		state.Focused = focused
-		state.CbMap["focused"](focused)
+		if state.Focused != focused {
+			state.Focused = focused
+			queueEvent(state, event.FocusEvent{Source: state.ID, Focused: focused})
+		}
 	}
 }
 
@@ -135,19 +186,194 @@ func UpdateTIBounds(x, y, width, height int32) UpdateTextInputState {
 		state.Bounds.Y = y
 		state.Bounds.Width = width
 		state.Bounds.Height = height
-		state.CbMap["bounds"](state.Bounds)
+		queueEvent(state, event.BoundsChangedEvent{Source: state.ID, X: x, Y: y, Width: width, Height: height})
+	}
+}
+
+// UpdateTISelection updates both ends of the selection of the text input state and records a single SelectionChangedEvent.
+//
+// Parameters:
+//   - start: The start position of the selection.
+//   - end: The end position of the selection.
+//
+// Returns:
+//   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its selection fields.
+func UpdateTISelection(start, end int32) UpdateTextInputState {
+	return func(state *TextInputState) {
+		if state.SelectionStart != start || state.SelectionEnd != end {
+			state.SelectionStart = start
+			state.SelectionEnd = end
+			queueEvent(state, event.SelectionChangedEvent{Source: state.ID, Start: start, End: end})
+		}
+	}
+}
+
+// UpdateTIPreedit updates the text an input method is composing at the caret of the text input state.
+//
+// Parameters:
+//   - preedit: The composed text, empty once the composition is committed or cancelled.
+//   - caret: The position of the input method's cursor inside preedit, in characters.
+//
+// Returns:
+//   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its Preedit fields.
+func UpdateTIPreedit(preedit string, caret int32) UpdateTextInputState {
+	return func(state *TextInputState) {
+		state.Preedit = preedit
+		state.PreeditCaret = min(max(caret, 0), int32(len([]rune(preedit))))
 	}
 }
 
-// UpdateTICbMap updates the callback map of the text input state.
+// UpdateTIEvents updates the event dispatcher of the text input state.
 //
 // Parameters:
-//   - cbMap: The map of callback functions to set for the text input state.
+//   - events: The dispatcher the state's events are published on.
 //
 // Returns:
-//   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its CbMap field.
-func UpdateTICbMap(cbMap map[string]func(any)) UpdateTextInputState {
+//   - UpdateTextInputState: A function that takes a pointer to TextInputState and updates its Events field.
+func UpdateTIEvents(events *event.Dispatcher) UpdateTextInputState {
 	return func(state *TextInputState) {
-		state.CbMap = cbMap
+		state.Events = events
+	}
+}
+
+// DisplayText returns the text the text input shows, its value or, while it is masked, one mask character per character.
+//
+// Returns:
+//   - string: The shown text.
+func (state *TextInputState) DisplayText() string {
+	if state.Mask == 0 {
+		return state.Value
+	}
+	return strings.Repeat(string(state.Mask), len([]rune(state.Value)))
+}
+
+// WordAt returns the word around pos, which double-clicking selects.
+// A masked value is selected as a whole so the selection does not give away where its words are.
+//
+// Parameters:
+//   - pos: The position inside or directly after the word.
+//
+// Returns:
+//   - int32: The start of the word.
+//   - int32: The end of the word.
+func (state *TextInputState) WordAt(pos int32) (int32, int32) {
+	if state.Mask != 0 {
+		return 0, int32(len([]rune(state.Value)))
+	}
+	return textedit.WordAt(state.Value, pos)
+}
+
+// TextRect returns the area of the window the text of the text input is drawn in, inside of its padding.
+//
+// Returns:
+//   - Rect: The text rectangle.
+func (state *TextInputState) TextRect() Rect {
+	return Rect{
+		X: state.Bounds.X + TextPadding,
+		Y: state.Bounds.Y + TextPadding,
+		W: max(state.Bounds.Width-2*TextPadding, 0),
+		H: max(state.Bounds.Height-2*TextPadding, 0),
+	}
+}
+
+// CaretRect returns where the caret of the text input is drawn in window coordinates, which input methods place their
+// candidate windows next to.
+//
+// Parameters:
+//   - measure: Returns the width of a piece of text drawn in the text input's font.
+//
+// Returns:
+//   - Rect: The caret, 2 pixels wide and as high as a line of text.
+func (state *TextInputState) CaretRect(measure func(text string) int32) Rect {
+	rect := state.TextRect()
+	if state.Multiline {
+		layout := state.Layout(measure)
+		return Rect{
+			X: rect.X + layout.X(state.CaretPos) - state.Scroll.X,
+			Y: rect.Y + int32(layout.LineOf(state.CaretPos))*state.LineHeight - state.Scroll.Y,
+			W: 2,
+			H: state.LineHeight,
+		}
+	}
+	runes := []rune(state.DisplayText())
+	caret := min(max(state.CaretPos, 0), int32(len(runes)))
+	return Rect{X: rect.X + measure(string(runes[:caret])) - state.Scroll.X, Y: rect.Y, W: 2, H: rect.H}
+}
+
+// EdgeStep returns the direction a drag selection moves past the visible text while the pointer is beyond the left or
+// right edge of the text rectangle, so that each pointer move out there scrolls the text a character further.
+//
+// Parameters:
+//   - x: The x-coordinate of the pointer in window coordinates.
+//
+// Returns:
+//   - int32: -1 left of the text rectangle, 1 right of it and 0 otherwise.
+func (state *TextInputState) EdgeStep(x int32) int32 {
+	rect := state.TextRect()
+	switch {
+	case x < rect.X:
+		return -1
+	case x >= rect.X+rect.W:
+		return 1
+	}
+	return 0
+}
+
+// Layout lays the value of a text area out into visual lines, wrapping at the width of its text rectangle when Wrap is set.
+// Page moves go by the number of lines fitting into the text rectangle.
+//
+// Parameters:
+//   - measure: Returns the width of a piece of text drawn in the text area's font.
+//
+// Returns:
+//   - *textedit.Layout: The layout of the value.
+func (state *TextInputState) Layout(measure func(text string) int32) *textedit.Layout {
+	rect := state.TextRect()
+	width := int32(0)
+	if state.Wrap {
+		width = max(rect.W, 1)
+	}
+	layout := textedit.NewLayout(state.Value, width, measure)
+	if state.LineHeight > 0 {
+		layout.PageLines = max(int(rect.H/state.LineHeight), 1)
+	}
+	return layout
+}
+
+// PosAt returns the position in the value of a text area closest to a point, taking its scroll offset into account.
+//
+// Parameters:
+//   - x: The x-coordinate of the point in window coordinates.
+//   - y: The y-coordinate of the point in window coordinates.
+//   - measure: Returns the width of a piece of text drawn in the text area's font.
+//
+// Returns:
+//   - int32: The position in characters.
+func (state *TextInputState) PosAt(x, y int32, measure func(text string) int32) int32 {
+	rect := state.TextRect()
+	line := 0
+	if dy := y - rect.Y + state.Scroll.Y; dy > 0 && state.LineHeight > 0 {
+		line = int(dy / state.LineHeight)
 	}
+	return state.Layout(measure).PosAt(line, x-rect.X+state.Scroll.X)
+}
+
+// TakeEvents returns the events recorded by the update functions since the last call and clears them.
+// Calling each returned function publishes one event on the state's dispatcher.
+// It must be called while the state is protected by the same lock the updates were applied under.
+//
+// Returns:
+//   - []func(): The recorded events in the order they occurred.
+func (state *TextInputState) TakeEvents() []func() {
+	pending := state.pending
+	state.pending = nil
+	return pending
+}
+
+// queueEvent records an event of the text input state to be published by the backend later.
+func queueEvent[T any](state *TextInputState, ev T) {
+	events := state.Events
+	state.pending = append(state.pending, func() {
+		event.Publish(events, ev)
+	})
 }
@@ -0,0 +1,45 @@
+package common
+
+const (
+	// tooltipOffset is how far below the pointer a tooltip is placed, so it does not cover the cursor.
+	tooltipOffset = 20
+	// tooltipGap is the space kept between the pointer and a tooltip flipped above it.
+	tooltipGap = 4
+)
+
+// Tooltip describes the popup the backends show for a component's tooltip.
+type Tooltip struct {
+	Text       string
+	Font       string
+	FontSize   int32
+	Color      *Color
+	Background *Color
+	Border     *Color
+	// Padding is the space between the border and the text.
+	Padding int32
+}
+
+// PlaceTooltip returns the screen position of a tooltip shown for the pointer, keeping the tooltip within the screen.
+// The tooltip is placed below the pointer, and flipped above it when there is no room below.
+//
+// Parameters:
+//   - pointerX: The x-coordinate of the pointer on the screen.
+//   - pointerY: The y-coordinate of the pointer on the screen.
+//   - w: The width of the tooltip.
+//   - h: The height of the tooltip.
+//   - screenW: The width of the screen.
+//   - screenH: The height of the screen.
+//
+// Returns:
+//   - int32: The x-coordinate of the tooltip's top-left corner.
+//   - int32: The y-coordinate of the tooltip's top-left corner.
+func PlaceTooltip(pointerX, pointerY, w, h, screenW, screenH int32) (int32, int32) {
+	x, y := pointerX, pointerY+tooltipOffset
+	if x+w > screenW {
+		x = screenW - w
+	}
+	if y+h > screenH {
+		y = pointerY - h - tooltipGap
+	}
+	return max(0, x), max(0, y)
+}
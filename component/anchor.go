@@ -0,0 +1,115 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+// Anchor describes which edges of the window a component keeps its distance to when the window is resized.
+// Anchoring to two opposite edges stretches the component, anchoring to neither keeps it centered on that axis.
+type Anchor int
+
+const (
+	AnchorNone Anchor = 0
+	AnchorLeft Anchor = 1 << (iota - 1)
+	AnchorTop
+	AnchorRight
+	AnchorBottom
+
+	AnchorFill = AnchorLeft | AnchorTop | AnchorRight | AnchorBottom
+)
+
+// Reflow re-positions and re-sizes a top-level component after its window changed size.
+// The distances to the anchored edges are captured the first time the component is reflowed
+// and kept until the component is moved or sized by user code, so repeated resizes never drift.
+//
+// Parameters:
+//   - c: The component to reflow.
+//   - oldWidth: The width of the window before the resize.
+//   - oldHeight: The height of the window before the resize.
+//   - newWidth: The width of the window after the resize.
+//   - newHeight: The height of the window after the resize.
+func Reflow(c Component, oldWidth, oldHeight, newWidth, newHeight int32) {
+	base := baseOf(c)
+	if base == nil || c.Parent() != nil {
+		return
+	}
+
+	x, y := c.Position()
+	w, h := c.Size()
+	margins := base.anchorMargins
+	if margins == nil {
+		margins = &common.Insets{
+			Top:    y,
+			Right:  oldWidth - x - w,
+			Bottom: oldHeight - y - h,
+			Left:   x,
+		}
+	}
+
+	anchor := c.Anchor()
+	x, w = reflowAxis(anchor&AnchorLeft != 0, anchor&AnchorRight != 0, margins.Left, margins.Right, w, newWidth)
+	y, h = reflowAxis(anchor&AnchorTop != 0, anchor&AnchorBottom != 0, margins.Top, margins.Bottom, h, newHeight)
+	w = clampSize(w, base.minSize.Width, base.maxSize.Width)
+	h = clampSize(h, base.minSize.Height, base.maxSize.Height)
+	if anchor&(AnchorLeft|AnchorRight) == AnchorRight {
+		x = newWidth - margins.Right - w
+	}
+	if anchor&(AnchorTop|AnchorBottom) == AnchorBottom {
+		y = newHeight - margins.Bottom - h
+	}
+
+	c.SetSize(w, h)
+	c.SetPosition(x, y)
+	base.anchorMargins = margins
+}
+
+// reflowAxis computes the offset and length of a component along one axis of the window.
+//
+// Parameters:
+//   - near: Whether the component is anchored to the left or top edge.
+//   - far: Whether the component is anchored to the right or bottom edge.
+//   - nearMargin: The distance to the left or top edge.
+//   - farMargin: The distance to the right or bottom edge.
+//   - length: The current width or height of the component.
+//   - total: The new width or height of the window.
+//
+// Returns:
+//   - int32: The new offset of the component.
+//   - int32: The new length of the component.
+func reflowAxis(near, far bool, nearMargin, farMargin, length, total int32) (int32, int32) {
+	switch {
+	case near && far:
+		return nearMargin, max(0, total-nearMargin-farMargin)
+	case near:
+		return nearMargin, length
+	case far:
+		return total - farMargin - length, length
+	default:
+		return (total-length)/2 + (nearMargin-farMargin)/2, length
+	}
+}
+
+// clampSize clamps a single dimension to a minimum and maximum, where 0 means unconstrained.
+func clampSize(v, minV, maxV int32) int32 {
+	if maxV > 0 && v > maxV {
+		v = maxV
+	}
+	if minV > 0 && v < minV {
+		v = minV
+	}
+	return v
+}
+
+// preferredSize returns the preferred size of a component clamped to its minimum and maximum size.
+func preferredSize(c Component) (int32, int32) {
+	w, h := c.PreferredSize()
+	minW, minH := c.MinSize()
+	maxW, maxH := c.MaxSize()
+	return clampSize(w, minW, maxW), clampSize(h, minH, maxH)
+}
+
+// baseOf returns the embedded baseComponent of one of the package's component types.
+func baseOf(c Component) *baseComponent {
+	if b, ok := c.(interface{ base() *baseComponent }); ok {
+		return b.base()
+	}
+	return nil
+}
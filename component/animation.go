@@ -0,0 +1,479 @@
+package component
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells an Animator the current time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock that only moves when it is advanced, used to step animations deterministically in tests.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a fake clock standing at start.
+//
+// Parameters:
+//   - start: The time the clock starts at.
+//
+// Returns:
+//   - *FakeClock: A pointer to the newly created clock.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward.
+//
+// Parameters:
+//   - d: The duration to move the clock by.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Animator steps running animations.
+// While any animation is active a ticker posts a step to the event loop of each window hosting an animated component,
+// so animated components are only touched on their UI thread. Once the last animation ended the ticker stops.
+// The window is looked up on every tick, an animation of a component not yet added to a window waits until it is.
+type Animator struct {
+	mu      sync.Mutex
+	clock   Clock
+	frame   time.Duration
+	manual  bool
+	active  []*Animation
+	running bool
+	// pending holds the windows that have a step queued on their event loop, so a slow loop is not flooded with steps.
+	pending map[uintptr]bool
+}
+
+// DefaultAnimator runs the animations created with Animate, Tween and Sequence.
+var DefaultAnimator = NewAnimator()
+
+// NewAnimator creates an animator with the specified options.
+//
+// Parameters:
+//   - options: A variadic list of CreateAnimatorOption functions to customize the animator.
+//
+// Returns:
+//   - *Animator: A pointer to the newly created animator.
+func NewAnimator(options ...CreateAnimatorOption) *Animator {
+	opts := newCreateAnimatorOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &Animator{
+		clock:   opts.Clock,
+		frame:   time.Second / time.Duration(opts.FrameRate),
+		manual:  opts.Manual,
+		pending: make(map[uintptr]bool),
+	}
+}
+
+// animationState describes where an animation is in its life cycle.
+type animationState int
+
+const (
+	animationIdle animationState = iota
+	animationRunning
+	animationDone
+	animationStopped
+)
+
+// Animation moves a property of a component to a target value over time, or plays a sequence of animations.
+// Animations are started, stopped and stepped on the UI thread.
+type Animation struct {
+	animator *Animator
+	target   Component
+	prop     Property
+	duration time.Duration
+	delay    time.Duration
+	easing   Easing
+	onDone   func()
+	// steps are the animations of a sequence, played one after the other.
+	steps   []*Animation
+	current int
+	// hwnd is the window the animation was last stepped for, guarded by the animator's lock.
+	hwnd  uintptr
+	state animationState
+	// begin is the time the animation starts moving, after its delay.
+	begin time.Time
+	apply func(p float64)
+	bound bool
+}
+
+// Animate starts animating a property of a component with the DefaultAnimator, for example
+// component.Animate(btn, component.Position(200, 100), 300*time.Millisecond, component.EaseOutCubic).
+// A running animation of the same property of the component is replaced.
+//
+// Parameters:
+//   - c: The component to animate.
+//   - prop: The property and its target value.
+//   - duration: The time the animation takes once started.
+//   - easing: The easing curve, nil for Linear.
+//   - options: A variadic list of AnimateOption functions to customize the animation.
+//
+// Returns:
+//   - *Animation: The running animation.
+func Animate(c Component, prop Property, duration time.Duration, easing Easing, options ...AnimateOption) *Animation {
+	return DefaultAnimator.Animate(c, prop, duration, easing, options...)
+}
+
+// Tween creates an animation with the DefaultAnimator without starting it, to be started later or played in a Sequence.
+//
+// Parameters:
+//   - c: The component to animate.
+//   - prop: The property and its target value.
+//   - duration: The time the animation takes once started.
+//   - easing: The easing curve, nil for Linear.
+//   - options: A variadic list of AnimateOption functions to customize the animation.
+//
+// Returns:
+//   - *Animation: The animation, not yet started.
+func Tween(c Component, prop Property, duration time.Duration, easing Easing, options ...AnimateOption) *Animation {
+	return DefaultAnimator.Tween(c, prop, duration, easing, options...)
+}
+
+// Sequence creates an animation with the DefaultAnimator playing steps one after the other, without starting it.
+// Each step captures its starting value when it begins, so a step continues from where the previous one left off.
+// A sequence without steps completes as soon as it is started.
+//
+// Parameters:
+//   - steps: The animations to play, created with Tween.
+//   - options: A variadic list of AnimateOption functions to customize the sequence.
+//
+// Returns:
+//   - *Animation: The sequence, not yet started.
+func Sequence(steps []*Animation, options ...AnimateOption) *Animation {
+	return DefaultAnimator.Sequence(steps, options...)
+}
+
+// Animate starts animating a property of a component, see the package level Animate.
+func (a *Animator) Animate(c Component, prop Property, duration time.Duration, easing Easing, options ...AnimateOption) *Animation {
+	an := a.Tween(c, prop, duration, easing, options...)
+	an.Start()
+	return an
+}
+
+// Tween creates an animation without starting it, see the package level Tween.
+func (a *Animator) Tween(c Component, prop Property, duration time.Duration, easing Easing, options ...AnimateOption) *Animation {
+	opts := newAnimateOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+	if easing == nil {
+		easing = Linear
+	}
+	return &Animation{
+		animator: a,
+		target:   c,
+		prop:     prop,
+		duration: max(0, duration),
+		delay:    opts.Delay,
+		easing:   easing,
+		onDone:   opts.OnDone,
+	}
+}
+
+// Sequence creates an animation playing steps one after the other, see the package level Sequence.
+func (a *Animator) Sequence(steps []*Animation, options ...AnimateOption) *Animation {
+	opts := newAnimateOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &Animation{
+		animator: a,
+		steps:    slices.Clone(steps),
+		delay:    opts.Delay,
+		onDone:   opts.OnDone,
+	}
+}
+
+// Active reports whether the animator has animations that did not finish yet.
+//
+// Returns:
+//   - bool: True if any animation is active, false otherwise.
+func (a *Animator) Active() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.active) > 0
+}
+
+// Step advances every active animation to the current time of the animator's clock on the calling goroutine.
+// It is used with AnimatorManualOpt, animators running on their own ticker step themselves.
+func (a *Animator) Step() {
+	a.step(func(*Animation) bool { return true })
+}
+
+// Start starts the animation. Starting a running or finished animation has no effect.
+func (an *Animation) Start() {
+	if an.state != animationIdle {
+		return
+	}
+	an.start(an.animator.clock.Now())
+	if an.target == nil && len(an.steps) == 0 {
+		// nothing to animate and no window to step it on
+		an.finish()
+		return
+	}
+	an.animator.add(an)
+}
+
+// Stop stops the animation where it is, without completing it or calling its completion callback.
+func (an *Animation) Stop() {
+	if an.state != animationRunning {
+		return
+	}
+	an.state = animationStopped
+	if an.current < len(an.steps) {
+		an.steps[an.current].Stop()
+	}
+	an.animator.remove(an)
+}
+
+// Done reports whether the animation completed.
+//
+// Returns:
+//   - bool: True if the animation played to its end, false otherwise.
+func (an *Animation) Done() bool {
+	return an.state == animationDone
+}
+
+// window returns the handle of the window hosting the animated component, 0 if it is not part of a window.
+// A sequence is stepped on the window of its first step.
+func (an *Animation) window() uintptr {
+	target := an.target
+	if target == nil && len(an.steps) > 0 {
+		return an.steps[0].window()
+	}
+	if target == nil {
+		return 0
+	}
+	if b := baseOf(target); b != nil {
+		treeMu.RLock()
+		defer treeMu.RUnlock()
+		if fm := b.rootFocusManager(); fm != nil {
+			return fm.hwnd
+		}
+	}
+	return 0
+}
+
+// start marks the animation as running from the given time on, its delay counting from there.
+func (an *Animation) start(at time.Time) {
+	an.state = animationRunning
+	an.begin = at.Add(an.delay)
+	an.current = 0
+	an.bound = false
+	if len(an.steps) > 0 {
+		an.steps[0].start(an.begin)
+	}
+}
+
+// advance moves the animation to the given time.
+//
+// Parameters:
+//   - now: The current time.
+//
+// Returns:
+//   - bool: True if the animation completed.
+//   - time.Time: The time the animation completed at, which the next step of a sequence starts from.
+func (an *Animation) advance(now time.Time) (bool, time.Time) {
+	if len(an.steps) > 0 || an.target == nil {
+		var end time.Time
+		for an.current < len(an.steps) {
+			done, stepEnd := an.steps[an.current].advance(now)
+			if !done {
+				return false, time.Time{}
+			}
+			end = stepEnd
+			an.current++
+			if an.current < len(an.steps) {
+				an.steps[an.current].start(end)
+			}
+		}
+		if end.IsZero() {
+			end = an.begin
+		}
+		an.finish()
+		return true, end
+	}
+
+	if now.Before(an.begin) {
+		return false, time.Time{}
+	}
+	if !an.bound {
+		// the starting value is captured only once the delay passed, so sequences continue from the previous step
+		if an.prop != nil {
+			an.apply = an.prop.bind(an.target)
+		}
+		an.bound = true
+	}
+	elapsed := now.Sub(an.begin)
+	if elapsed < an.duration {
+		if an.apply != nil {
+			an.apply(an.easing(float64(elapsed) / float64(an.duration)))
+		}
+		return false, time.Time{}
+	}
+	if an.apply != nil {
+		an.apply(an.easing(1))
+	}
+	an.finish()
+	return true, an.begin.Add(an.duration)
+}
+
+// finish marks the animation as done and calls its completion callback.
+func (an *Animation) finish() {
+	an.state = animationDone
+	if an.onDone != nil {
+		an.onDone()
+	}
+}
+
+// add makes an animation active, replacing a running animation of the same property of the same component,
+// and starts the ticker if it is not running yet.
+func (a *Animator) add(an *Animation) {
+	a.mu.Lock()
+	var replaced []*Animation
+	if an.target != nil && an.prop != nil {
+		for _, other := range a.active {
+			if other.target == an.target && other.prop != nil && other.prop.key() == an.prop.key() {
+				replaced = append(replaced, other)
+			}
+		}
+	}
+	a.active = append(a.active, an)
+	startTicker := !a.manual && !a.running
+	a.running = a.running || startTicker
+	a.mu.Unlock()
+
+	for _, other := range replaced {
+		other.Stop()
+	}
+	if startTicker {
+		go a.run()
+	}
+}
+
+// remove drops an animation from the active ones.
+func (a *Animator) remove(an *Animation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active = slices.DeleteFunc(a.active, func(other *Animation) bool {
+		return other == an
+	})
+}
+
+// run is the ticker goroutine, it exits once no animation is active.
+func (a *Animator) run() {
+	ticker := time.NewTicker(a.frame)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !a.tick() {
+			return
+		}
+	}
+}
+
+// tick queues a step on the event loop of every window with active animations.
+// Animations of components outside of a window are skipped until the component is added to one.
+//
+// Returns:
+//   - bool: False once no animation is active and the ticker should stop.
+func (a *Animator) tick() bool {
+	a.mu.Lock()
+	if len(a.active) == 0 {
+		a.running = false
+		a.mu.Unlock()
+		return false
+	}
+	animations := slices.Clone(a.active)
+	a.mu.Unlock()
+
+	// the windows are looked up outside of the lock, walking up to the window takes the lock of the component tree
+	hwnds := make([]uintptr, len(animations))
+	for i, an := range animations {
+		hwnds[i] = an.window()
+	}
+
+	a.mu.Lock()
+	var windows []uintptr
+	for i, an := range animations {
+		an.hwnd = hwnds[i]
+		if an.hwnd != 0 && !a.pending[an.hwnd] {
+			a.pending[an.hwnd] = true
+			windows = append(windows, an.hwnd)
+		}
+	}
+	a.mu.Unlock()
+
+	for _, hwnd := range windows {
+		if !postInvoke(hwnd, func() { a.stepWindow(hwnd) }) {
+			// the window is closed, nothing is left to animate in it
+			a.drop(hwnd)
+		}
+	}
+	return true
+}
+
+// stepWindow advances the animations of one window.
+func (a *Animator) stepWindow(hwnd uintptr) {
+	a.mu.Lock()
+	delete(a.pending, hwnd)
+	a.mu.Unlock()
+
+	a.step(func(an *Animation) bool { return an.hwnd == hwnd })
+}
+
+// step advances the active animations matching a filter and removes the completed ones.
+// Animations are advanced outside of the lock, as setters and completion callbacks may start new animations.
+func (a *Animator) step(match func(*Animation) bool) {
+	now := a.clock.Now()
+	a.mu.Lock()
+	var animations []*Animation
+	for _, an := range a.active {
+		if match(an) {
+			animations = append(animations, an)
+		}
+	}
+	a.mu.Unlock()
+
+	for _, an := range animations {
+		if an.state != animationRunning {
+			continue
+		}
+		if done, _ := an.advance(now); done {
+			a.remove(an)
+		}
+	}
+}
+
+// drop removes the animations of a closed window.
+func (a *Animator) drop(hwnd uintptr) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, hwnd)
+	a.active = slices.DeleteFunc(a.active, func(an *Animation) bool {
+		return an.hwnd == hwnd
+	})
+}
@@ -0,0 +1,97 @@
+package component
+
+import "time"
+
+type createAnimatorOptions struct {
+	Clock     Clock
+	FrameRate int
+	Manual    bool
+}
+
+type CreateAnimatorOption func(*createAnimatorOptions)
+
+func newCreateAnimatorOptions() *createAnimatorOptions {
+	return &createAnimatorOptions{
+		Clock:     systemClock{},
+		FrameRate: 60,
+		Manual:    false,
+	}
+}
+
+// AnimatorClockOpt sets the clock the animator reads the time from, for example a FakeClock in tests.
+//
+// Parameters:
+//   - clock: The clock of the animator.
+//
+// Returns:
+//   - CreateAnimatorOption: A function that takes a pointer to createAnimatorOptions and sets the Clock field.
+func AnimatorClockOpt(clock Clock) CreateAnimatorOption {
+	return func(opts *createAnimatorOptions) {
+		opts.Clock = clock
+	}
+}
+
+// AnimatorFrameRateOpt sets how many times per second the animator steps its animations while any are active.
+//
+// Parameters:
+//   - fps: The number of steps per second.
+//
+// Returns:
+//   - CreateAnimatorOption: A function that takes a pointer to createAnimatorOptions and sets the FrameRate field.
+func AnimatorFrameRateOpt(fps int) CreateAnimatorOption {
+	return func(opts *createAnimatorOptions) {
+		opts.FrameRate = max(1, fps)
+	}
+}
+
+// AnimatorManualOpt makes the animator step only when Step is called instead of on its own ticker.
+// Combined with a FakeClock it makes animations fully deterministic.
+//
+// Returns:
+//   - CreateAnimatorOption: A function that takes a pointer to createAnimatorOptions and sets the Manual field.
+func AnimatorManualOpt() CreateAnimatorOption {
+	return func(opts *createAnimatorOptions) {
+		opts.Manual = true
+	}
+}
+
+type animateOptions struct {
+	Delay  time.Duration
+	OnDone func()
+}
+
+type AnimateOption func(*animateOptions)
+
+func newAnimateOptions() *animateOptions {
+	return &animateOptions{
+		Delay:  0,
+		OnDone: nil,
+	}
+}
+
+// AnimateDelayOpt delays the start of the animation. The starting value is captured once the delay has passed.
+//
+// Parameters:
+//   - delay: The time to wait before the animation starts.
+//
+// Returns:
+//   - AnimateOption: A function that takes a pointer to animateOptions and sets the Delay field.
+func AnimateDelayOpt(delay time.Duration) AnimateOption {
+	return func(opts *animateOptions) {
+		opts.Delay = max(0, delay)
+	}
+}
+
+// AnimateOnDoneOpt sets the function called on the UI thread when the animation completed.
+// It is not called for animations that were stopped or replaced.
+//
+// Parameters:
+//   - onDone: The function called on completion.
+//
+// Returns:
+//   - AnimateOption: A function that takes a pointer to animateOptions and sets the OnDone field.
+func AnimateOnDoneOpt(onDone func()) AnimateOption {
+	return func(opts *animateOptions) {
+		opts.OnDone = onDone
+	}
+}
@@ -0,0 +1,148 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+// Property is a value of a component an animation moves towards a target, created by Position, Size,
+// BackgroundColor, TextColor and Opacity.
+type Property interface {
+	// key identifies the animated value, a new animation of the same key on a component replaces the running one.
+	key() string
+
+	// bind captures the current value of the component and returns the function applying the value at a given progress,
+	// 0 being the captured value and 1 the target. It returns nil if the property does not apply to the component.
+	bind(c Component) func(p float64)
+}
+
+type propertyFunc struct {
+	name string
+	fn   func(c Component) func(p float64)
+}
+
+func (pf propertyFunc) key() string {
+	return pf.name
+}
+
+func (pf propertyFunc) bind(c Component) func(p float64) {
+	return pf.fn(c)
+}
+
+// Position animates the position of a component.
+//
+// Parameters:
+//   - x: The target x-coordinate.
+//   - y: The target y-coordinate.
+//
+// Returns:
+//   - Property: The animated property.
+func Position(x, y int32) Property {
+	return propertyFunc{name: "position", fn: func(c Component) func(p float64) {
+		fromX, fromY := c.Position()
+		return func(p float64) {
+			c.SetPosition(lerpInt(fromX, x, p), lerpInt(fromY, y, p))
+		}
+	}}
+}
+
+// Size animates the size of a component.
+//
+// Parameters:
+//   - width: The target width.
+//   - height: The target height.
+//
+// Returns:
+//   - Property: The animated property.
+func Size(width, height int32) Property {
+	return propertyFunc{name: "size", fn: func(c Component) func(p float64) {
+		fromW, fromH := c.Size()
+		return func(p float64) {
+			c.SetSize(max(0, lerpInt(fromW, width, p)), max(0, lerpInt(fromH, height, p)))
+		}
+	}}
+}
+
+// BackgroundColor animates the background color of buttons, text inputs and scroll views.
+// The animated color is set on the component, so it no longer follows the theme afterwards.
+//
+// Parameters:
+//   - color: The target color.
+//
+// Returns:
+//   - Property: The animated property.
+func BackgroundColor(color common.Color) Property {
+	return propertyFunc{name: "backgroundColor", fn: func(c Component) func(p float64) {
+		switch comp := c.(type) {
+		case Button:
+			return colorTween(comp.BackgroundColor(), color, comp.SetBackgroundColor)
+		case TextInput:
+			return colorTween(comp.Color(), color, comp.SetColor)
+		case ScrollView:
+			return colorTween(comp.BackgroundColor(), color, comp.SetBackgroundColor)
+		}
+		return nil
+	}}
+}
+
+// TextColor animates the text color of labels, buttons and text inputs.
+// The animated color is set on the component, so it no longer follows the theme afterwards.
+//
+// Parameters:
+//   - color: The target color.
+//
+// Returns:
+//   - Property: The animated property.
+func TextColor(color common.Color) Property {
+	return propertyFunc{name: "textColor", fn: func(c Component) func(p float64) {
+		switch comp := c.(type) {
+		case Label:
+			return colorTween(comp.Color(), color, comp.SetColor)
+		case Button:
+			return colorTween(comp.LabelColor(), color, comp.SetLabelColor)
+		case TextInput:
+			return colorTween(comp.TextColor(), color, comp.SetTextColor)
+		}
+		return nil
+	}}
+}
+
+// Opacity animates the opacity of a component.
+//
+// Parameters:
+//   - opacity: The target opacity between 0 and 1.
+//
+// Returns:
+//   - Property: The animated property.
+func Opacity(opacity float32) Property {
+	return propertyFunc{name: "opacity", fn: func(c Component) func(p float64) {
+		from := c.Opacity()
+		return func(p float64) {
+			c.SetOpacity(from + (opacity-from)*float32(p))
+		}
+	}}
+}
+
+// colorTween returns the function blending from one color to another, a nil starting color jumps to the target.
+func colorTween(from *common.Color, to common.Color, set func(*common.Color)) func(p float64) {
+	start := to
+	if from != nil {
+		start = *from
+	}
+	return func(p float64) {
+		set(&common.Color{
+			Red:   lerpChannel(start.Red, to.Red, p),
+			Green: lerpChannel(start.Green, to.Green, p),
+			Blue:  lerpChannel(start.Blue, to.Blue, p),
+		})
+	}
+}
+
+func lerpInt(from, to int32, p float64) int32 {
+	v := float64(from) + float64(to-from)*p
+	if v < 0 {
+		return int32(v - 0.5)
+	}
+	return int32(v + 0.5)
+}
+
+func lerpChannel(from, to uint8, p float64) uint8 {
+	return uint8(max(0, min(255, float64(from)+(float64(to)-float64(from))*p+0.5)))
+}
@@ -0,0 +1,255 @@
+package component
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// newTestAnimator returns an animator only stepping when told, reading the time from a fake clock.
+func newTestAnimator() (*Animator, *FakeClock) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewAnimator(AnimatorClockOpt(clock), AnimatorManualOpt()), clock
+}
+
+// advance moves the clock forward and steps the animator.
+func advance(a *Animator, clock *FakeClock, d time.Duration) {
+	clock.Advance(d)
+	a.Step()
+}
+
+func assertPosition(t *testing.T, c Component, wantX, wantY int32) {
+	t.Helper()
+	if x, y := c.Position(); x != wantX || y != wantY {
+		t.Errorf("Position() = %d, %d, want %d, %d", x, y, wantX, wantY)
+	}
+}
+
+func TestEasingEndpoints(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear":         Linear,
+		"EaseInQuad":     EaseInQuad,
+		"EaseOutQuad":    EaseOutQuad,
+		"EaseInOutQuad":  EaseInOutQuad,
+		"EaseInCubic":    EaseInCubic,
+		"EaseOutCubic":   EaseOutCubic,
+		"EaseInOutCubic": EaseInOutCubic,
+		"EaseOutBack":    EaseOutBack,
+		"EaseOutBounce":  EaseOutBounce,
+	}
+	for name, easing := range easings {
+		t.Run(name, func(t *testing.T) {
+			if got := easing(0); math.Abs(got) > 1e-9 {
+				t.Errorf("%s(0) = %v, want 0", name, got)
+			}
+			if got := easing(1); math.Abs(got-1) > 1e-9 {
+				t.Errorf("%s(1) = %v, want 1", name, got)
+			}
+		})
+	}
+}
+
+func TestAnimateProgress(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	an := a.Animate(c, Position(100, 200), 100*time.Millisecond, nil)
+
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 50, 100)
+	if an.Done() || !a.Active() {
+		t.Fatalf("animation finished halfway through")
+	}
+	advance(a, clock, 60*time.Millisecond)
+	assertPosition(t, c, 100, 200)
+	if !an.Done() || a.Active() {
+		t.Errorf("Done() = %v, Active() = %v after the duration, want true, false", an.Done(), a.Active())
+	}
+}
+
+func TestAnimateEasing(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	a.Animate(c, Position(100, 0), 100*time.Millisecond, EaseInQuad)
+
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 25, 0)
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 100, 0)
+}
+
+func TestAnimateDelay(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	c.SetPosition(10, 10)
+	a.Animate(c, Position(110, 10), 100*time.Millisecond, nil, AnimateDelayOpt(50*time.Millisecond))
+
+	advance(a, clock, 40*time.Millisecond)
+	assertPosition(t, c, 10, 10)
+	// the starting value is captured once the delay passed, so a move during the delay is animated from
+	c.SetPosition(60, 10)
+	advance(a, clock, 60*time.Millisecond)
+	assertPosition(t, c, 85, 10)
+	advance(a, clock, 90*time.Millisecond)
+	assertPosition(t, c, 110, 10)
+}
+
+func TestSequenceChaining(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	var order []string
+	seq := a.Sequence([]*Animation{
+		a.Tween(c, Position(100, 0), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() { order = append(order, "first") })),
+		a.Tween(c, Position(100, 100), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() { order = append(order, "second") })),
+	}, AnimateOnDoneOpt(func() { order = append(order, "sequence") }))
+	seq.Start()
+
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 50, 0)
+	// the second step starts where the first one ended, not at the time of the step
+	advance(a, clock, 100*time.Millisecond)
+	assertPosition(t, c, 100, 50)
+	if len(order) != 1 || order[0] != "first" {
+		t.Errorf("completed %v halfway through the second step, want [first]", order)
+	}
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 100, 100)
+	if len(order) != 3 || order[1] != "second" || order[2] != "sequence" {
+		t.Errorf("completed %v, want [first second sequence]", order)
+	}
+	if !seq.Done() || a.Active() {
+		t.Errorf("Done() = %v, Active() = %v after the sequence, want true, false", seq.Done(), a.Active())
+	}
+}
+
+func TestEmptySequence(t *testing.T) {
+	a, _ := newTestAnimator()
+	done := false
+	seq := a.Sequence(nil, AnimateOnDoneOpt(func() { done = true }))
+	seq.Start()
+	if !done || !seq.Done() || a.Active() {
+		t.Errorf("empty sequence: onDone called = %v, Done() = %v, Active() = %v, want true, true, false", done, seq.Done(), a.Active())
+	}
+}
+
+func TestAnimateReplacesSameProperty(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	firstDone := false
+	first := a.Animate(c, Position(100, 0), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() { firstDone = true }))
+	fade := a.Animate(c, Opacity(0), 100*time.Millisecond, nil)
+
+	advance(a, clock, 50*time.Millisecond)
+	second := a.Animate(c, Position(0, 100), 100*time.Millisecond, nil)
+	advance(a, clock, 50*time.Millisecond)
+	// the second animation starts from where the first one was replaced
+	assertPosition(t, c, 25, 50)
+	if first.Done() || firstDone {
+		t.Errorf("replaced animation completed")
+	}
+	if !fade.Done() {
+		t.Errorf("animation of another property was replaced")
+	}
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 0, 100)
+	if !second.Done() || a.Active() {
+		t.Errorf("Done() = %v, Active() = %v after the replacing animation, want true, false", second.Done(), a.Active())
+	}
+}
+
+func TestAnimationStop(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	done := false
+	an := a.Animate(c, Position(100, 0), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() { done = true }))
+
+	advance(a, clock, 50*time.Millisecond)
+	an.Stop()
+	advance(a, clock, 100*time.Millisecond)
+	assertPosition(t, c, 50, 0)
+	if done || an.Done() || a.Active() {
+		t.Errorf("stopped animation: onDone called = %v, Done() = %v, Active() = %v, want all false", done, an.Done(), a.Active())
+	}
+	an.Start()
+	if a.Active() {
+		t.Errorf("stopped animation started again")
+	}
+}
+
+func TestAnimateOnDone(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	calls := 0
+	var x int32
+	a.Animate(c, Position(100, 0), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() {
+		calls++
+		x, _ = c.Position()
+	}))
+
+	advance(a, clock, 99*time.Millisecond)
+	if calls != 0 {
+		t.Fatalf("onDone called before the animation completed")
+	}
+	advance(a, clock, time.Millisecond)
+	advance(a, clock, time.Second)
+	if calls != 1 {
+		t.Errorf("onDone called %d times, want 1", calls)
+	}
+	if x != 100 {
+		t.Errorf("position in onDone = %d, want the target 100", x)
+	}
+}
+
+func TestAnimateOnDoneStartsAnimation(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	a.Animate(c, Position(100, 0), 100*time.Millisecond, nil, AnimateOnDoneOpt(func() {
+		a.Animate(c, Position(0, 0), 100*time.Millisecond, nil)
+	}))
+
+	advance(a, clock, 100*time.Millisecond)
+	if !a.Active() {
+		t.Fatalf("animation started from onDone is not active")
+	}
+	advance(a, clock, 50*time.Millisecond)
+	assertPosition(t, c, 50, 0)
+}
+
+func TestAnimateNilProperty(t *testing.T) {
+	a, clock := newTestAnimator()
+	c := NewComponent()
+	done := false
+	a.Animate(c, Position(100, 0), 100*time.Millisecond, nil)
+	a.Animate(c, nil, 100*time.Millisecond, nil, AnimateOnDoneOpt(func() { done = true }))
+
+	advance(a, clock, 100*time.Millisecond)
+	assertPosition(t, c, 100, 0)
+	if !done {
+		t.Errorf("animation without a property did not complete")
+	}
+}
+
+func TestAnimatorWaitsForWindow(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	a := NewAnimator(AnimatorClockOpt(clock), AnimatorFrameRateOpt(1000))
+	c := NewComponent()
+	a.Animate(c, Position(100, 0), time.Millisecond, nil)
+	clock.Advance(time.Second)
+
+	// the component is not in a window, the ticker must not step it on its own goroutine
+	time.Sleep(20 * time.Millisecond)
+	assertPosition(t, c, 0, 0)
+	if !a.Active() {
+		t.Fatalf("animation of a component outside of a window was dropped")
+	}
+
+	// a window that is not open takes no steps, the animation is dropped without touching the component
+	NewFocusManager(1, func() []Component { return []Component{c} }).Attach(c)
+	deadline := time.Now().Add(time.Second)
+	for a.Active() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if a.Active() {
+		t.Fatalf("animation in a closed window is still active")
+	}
+	assertPosition(t, c, 0, 0)
+}
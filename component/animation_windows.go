@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+)
+
+// windowBackground returns the background color of the window, which translucent components are blended with.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//
+// Returns:
+//   - *common.Color: The background color, or nil if the window is unknown.
+func windowBackground(hwnd uintptr) *common.Color {
+	return wdws.GetWindowColor(hwnd)
+}
+
+// postInvoke queues fn to run on the event loop of the window.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - fn: The function to run on the event loop.
+//
+// Returns:
+//   - bool: True if fn was queued, false if the window is closed.
+func postInvoke(hwnd uintptr, fn func()) bool {
+	return wdws.PostInvoke(hwnd, fn)
+}
@@ -0,0 +1,142 @@
+package component
+
+type boxLayout struct {
+	baseLayout
+	orientation Orientation
+}
+
+type BoxLayout interface {
+	Layout
+
+	// AddChild adds a component to the end of the box.
+	//
+	// Parameters:
+	//  - c: The component to add.
+	//  - options: A variadic list of LayoutChildOption functions describing how the child is placed.
+	AddChild(c Component, options ...LayoutChildOption)
+
+	// Orientation returns the main axis along which the children are stacked.
+	//
+	// Returns:
+	//  - Orientation: The orientation of the box.
+	Orientation() Orientation
+}
+
+var _ BoxLayout = (*boxLayout)(nil)
+
+// NewVBox creates a new layout that stacks its children vertically.
+// It accepts a variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Parameters:
+//   - options: A variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Returns:
+//   - BoxLayout: A pointer to the newly created vertical box layout.
+func NewVBox(options ...CreateLayoutOption) BoxLayout {
+	return newBoxLayout(OrientationVertical, options...)
+}
+
+// NewHBox creates a new layout that places its children side by side horizontally.
+// It accepts a variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Parameters:
+//   - options: A variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Returns:
+//   - BoxLayout: A pointer to the newly created horizontal box layout.
+func NewHBox(options ...CreateLayoutOption) BoxLayout {
+	return newBoxLayout(OrientationHorizontal, options...)
+}
+
+func newBoxLayout(orientation Orientation, options ...CreateLayoutOption) BoxLayout {
+	opts := newCreateLayoutOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	b := &boxLayout{
+		baseLayout:  newBaseLayout(opts),
+		orientation: orientation,
+	}
+	b.self = b
+	return b
+}
+
+func (b *boxLayout) AddChild(c Component, options ...LayoutChildOption) {
+	params := newLayoutParams()
+	for _, opt := range options {
+		opt(&params)
+	}
+	b.addChild(c, params)
+}
+
+func (b *boxLayout) Orientation() Orientation {
+	return b.orientation
+}
+
+// axes returns the main and cross axis sizes of a component for the box orientation.
+func (b *boxLayout) axes(w, h int32) (int32, int32) {
+	if b.orientation == OrientationVertical {
+		return h, w
+	}
+	return w, h
+}
+
+func (b *boxLayout) PreferredSize() (int32, int32) {
+	children, _ := b.visibleChildren()
+	var mainSize, crossSize int32
+	for _, c := range children {
+		m, x := b.axes(preferredSize(c))
+		mainSize += m
+		crossSize = max(crossSize, x)
+	}
+	if len(children) > 1 {
+		mainSize += b.Spacing() * int32(len(children)-1)
+	}
+	w, h := b.axes(mainSize, crossSize)
+	return w + b.padding.Left + b.padding.Right, h + b.padding.Top + b.padding.Bottom
+}
+
+func (b *boxLayout) Relayout() {
+	b.fitToContent()
+	children, params := b.visibleChildren()
+	if len(children) == 0 {
+		return
+	}
+	inner := b.innerRect()
+	mainAvail, crossAvail := b.axes(inner.W, inner.H)
+
+	sizes := make([]int32, len(children))
+	crosses := make([]int32, len(children))
+	stretch := make([]int32, len(children))
+	total := b.Spacing() * int32(len(children)-1)
+	for i, c := range children {
+		sizes[i], crosses[i] = b.axes(preferredSize(c))
+		stretch[i] = params[i].Stretch
+		total += sizes[i]
+	}
+
+	extra := mainAvail - total
+	offset := int32(0)
+	if !distribute(sizes, stretch, extra) && extra > 0 {
+		switch b.alignment {
+		case LayoutAlignCenter:
+			offset = extra / 2
+		case LayoutAlignEnd:
+			offset = extra
+		}
+	}
+
+	for i, c := range children {
+		if b.orientation == OrientationVertical {
+			w, x := alignSpan(params[i].HAlign, crosses[i], crossAvail)
+			c.SetPosition(inner.X+x, inner.Y+offset)
+			c.SetSize(w, sizes[i])
+		} else {
+			h, y := alignSpan(params[i].VAlign, crosses[i], crossAvail)
+			c.SetPosition(inner.X+offset, inner.Y+y)
+			c.SetSize(sizes[i], h)
+		}
+		offset += sizes[i] + b.Spacing()
+	}
+}
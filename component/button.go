@@ -2,6 +2,8 @@ package component
 
 import (
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/theme"
 )
 
 type button struct {
@@ -17,7 +19,8 @@ type button struct {
 	bgHover    *common.Color
 	bgPressed  *common.Color
 	bgDisabled *common.Color
-	roundness  int32
+	// roundness is nil while the button uses the roundness of its theme.
+	roundness *int32
 }
 
 // NewButton creates a new Button component with the specified options.
@@ -40,9 +43,17 @@ func NewButton(options ...CreateButtonOption) Button {
 
 	b := &button{
 		baseComponent: baseComponent{
-			id:      cOpts.ID,
-			visible: cOpts.Visible,
-			enabled: cOpts.Enabled,
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(true),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
 			size: struct {
 				Width  int32
 				Height int32
@@ -72,22 +83,36 @@ func NewButton(options ...CreateButtonOption) Button {
 		roundness:  opts.Roundness,
 	}
 
-	cbMap := make(map[string]func(any))
-	cbMap["onClick"] = func(_ any) {
+	event.Subscribe(b.events, func(e event.PressedEvent) {
+		b.pressed = e.Pressed
+	})
+	event.Subscribe(b.events, func(e event.MouseEnterEvent) {
+		b.hovered = true
+	})
+	event.Subscribe(b.events, func(e event.MouseLeaveEvent) {
+		b.hovered = false
+	})
+	event.Subscribe(b.events, func(e event.ClickEvent) {
 		if b.onClick != nil {
 			b.onClick()
 		}
-	}
-	cbMap["pressed"] = func(p any) {
-		b.pressed = p.(bool)
-	}
+	})
 
-	mapBtnCb(b, cbMap)
+	registerBtnEvents(b)
 	registerBtnBounds(b)
 
 	return b
 }
 
+// register hands the button's events and bounds to the backend, see Register.
+func (b *button) register() {
+	registerBtnEvents(b)
+	registerBtnBounds(b)
+}
+
+// Button is a clickable component with a label.
+// The label font, label size, colors and roundness come from the button's theme until they are set explicitly,
+// setting a nil color restores the theme's color.
 type Button interface {
 	Component
 
@@ -143,6 +168,7 @@ type Button interface {
 	SetLabelSize(labelSize int32)
 
 	// OnClick returns the function to be called when the button is clicked.
+	// It is a shorthand for a single event.ClickEvent listener, further listeners can be added through Events.
 	//
 	// Returns:
 	//  - func(): The function to be called on button click.
@@ -154,6 +180,10 @@ type Button interface {
 	//  - onClick: The function to set for button click.
 	SetOnClick(onClick func())
 
+	// Click activates the button as if it had been clicked, publishing an event.ClickEvent.
+	// It does nothing if the button is disabled. Focused buttons are clicked this way when Space or Enter is pressed.
+	Click()
+
 	// Pressed returns whether the button is currently pressed.
 	//
 	// Returns:
@@ -245,12 +275,31 @@ func (b *button) Draw(ctx *common.DrawCtx) {
 	drawComponent(b, ctx)
 }
 
+// SetSize sets the size of the button and keeps the bounds used for hit-testing in sync.
+func (b *button) SetSize(width, height int32) {
+	b.baseComponent.SetSize(width, height)
+	registerBtnBounds(b)
+}
+
+// SetPosition sets the position of the button and keeps the bounds used for hit-testing in sync.
+func (b *button) SetPosition(x, y int32) {
+	b.baseComponent.SetPosition(x, y)
+	registerBtnBounds(b)
+}
+
+// PreferredSize returns the size of the button's label plus room for the button's padding.
+func (b *button) PreferredSize() (int32, int32) {
+	w, h := measureText(b.LabelFont(), b.LabelSize(), b.label)
+	return w + 32, h + 16
+}
+
 func (b *button) Label() string {
 	return b.label
 }
 
 func (b *button) SetLabel(label string) {
 	b.label = label
+	b.invalidateLayout()
 }
 
 func (b *button) OnClick() func() {
@@ -261,6 +310,13 @@ func (b *button) SetOnClick(onClick func()) {
 	b.onClick = onClick
 }
 
+func (b *button) Click() {
+	if !b.enabled {
+		return
+	}
+	event.Publish(b.events, event.ClickEvent{Source: b.id})
+}
+
 func (b *button) Pressed() bool {
 	return b.pressed
 }
@@ -278,15 +334,22 @@ func (b *button) SetHovered(hovered bool) {
 }
 
 func (b *button) LabelFont() string {
-	return b.labelFont
+	if b.labelFont != "" {
+		return b.labelFont
+	}
+	return b.Theme().Button.Font.Family
 }
 
 func (b *button) SetLabelFont(labelFont string) {
 	b.labelFont = labelFont
+	b.invalidateLayout()
 }
 
 func (b *button) LabelColor() *common.Color {
-	return b.labelColor
+	if b.labelColor != nil {
+		return b.labelColor
+	}
+	return b.Theme().Button.Resolve(theme.StateNormal).Foreground
 }
 
 func (b *button) SetLabelColor(labelColor *common.Color) {
@@ -294,15 +357,22 @@ func (b *button) SetLabelColor(labelColor *common.Color) {
 }
 
 func (b *button) LabelSize() int32 {
-	return b.labelSize
+	if b.labelSize > 0 {
+		return b.labelSize
+	}
+	return b.Theme().Button.Font.Size
 }
 
 func (b *button) SetLabelSize(labelSize int32) {
 	b.labelSize = labelSize
+	b.invalidateLayout()
 }
 
 func (b *button) BackgroundColor() *common.Color {
-	return b.bgDefault
+	if b.bgDefault != nil {
+		return b.bgDefault
+	}
+	return b.Theme().Button.Resolve(theme.StateNormal).Background
 }
 
 func (b *button) SetBackgroundColor(backgroundColor *common.Color) {
@@ -310,7 +380,10 @@ func (b *button) SetBackgroundColor(backgroundColor *common.Color) {
 }
 
 func (b *button) BackgroundColorHover() *common.Color {
-	return b.bgHover
+	if b.bgHover != nil {
+		return b.bgHover
+	}
+	return b.Theme().Button.Resolve(theme.StateHover).Background
 }
 
 func (b *button) SetBackgroundColorHover(backgroundColorHover *common.Color) {
@@ -318,7 +391,10 @@ func (b *button) SetBackgroundColorHover(backgroundColorHover *common.Color) {
 }
 
 func (b *button) BackgroundColorPressed() *common.Color {
-	return b.bgPressed
+	if b.bgPressed != nil {
+		return b.bgPressed
+	}
+	return b.Theme().Button.Resolve(theme.StatePressed).Background
 }
 
 func (b *button) SetBackgroundColorPressed(backgroundColorPressed *common.Color) {
@@ -326,7 +402,10 @@ func (b *button) SetBackgroundColorPressed(backgroundColorPressed *common.Color)
 }
 
 func (b *button) BackgroundColorDisabled() *common.Color {
-	return b.bgDisabled
+	if b.bgDisabled != nil {
+		return b.bgDisabled
+	}
+	return b.Theme().Button.Resolve(theme.StateDisabled).Background
 }
 
 func (b *button) SetBackgroundColorDisabled(backgroundColorDisabled *common.Color) {
@@ -334,9 +413,38 @@ func (b *button) SetBackgroundColorDisabled(backgroundColorDisabled *common.Colo
 }
 
 func (b *button) Roundness() int32 {
-	return b.roundness
+	if b.roundness != nil {
+		return *b.roundness
+	}
+	return b.Theme().Button.Roundness
 }
 
 func (b *button) SetRoundness(roundness int32) {
-	b.roundness = roundness
+	b.roundness = &roundness
+}
+
+// stateColors returns the background and label colors for the button's current state.
+// Colors set on the button take precedence over the colors of its theme.
+//
+// Returns:
+//   - *common.Color: The background color.
+//   - *common.Color: The label color.
+func (b *button) stateColors() (*common.Color, *common.Color) {
+	var bg *common.Color
+	state := theme.StateNormal
+	if !b.enabled {
+		bg, state = b.BackgroundColorDisabled(), theme.StateDisabled
+	} else if b.pressed {
+		bg, state = b.BackgroundColorPressed(), theme.StatePressed
+	} else if b.hovered {
+		bg, state = b.BackgroundColorHover(), theme.StateHover
+	} else {
+		bg = b.BackgroundColor()
+	}
+
+	fg := b.labelColor
+	if fg == nil {
+		fg = b.Theme().Button.Resolve(state).Foreground
+	}
+	return bg, fg
 }
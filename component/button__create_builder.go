@@ -13,9 +13,10 @@ type createButtonOptions struct {
 	BackgroundColorHover    *common.Color
 	BackgroundColorPressed  *common.Color
 	BackgroundColorDisabled *common.Color
-	Roundness               int32
-	OnClick                 func()
-	ComponentOptions        []CreateComponentOption
+	// Roundness is nil while the button uses the roundness of its theme.
+	Roundness        *int32
+	OnClick          func()
+	ComponentOptions []CreateComponentOption
 }
 
 type CreateButtonOption func(*createButtonOptions)
@@ -23,14 +24,14 @@ type CreateButtonOption func(*createButtonOptions)
 func newCreateButtonOptions() *createButtonOptions {
 	return &createButtonOptions{
 		Label:                   "Button",
-		LabelFont:               "Arial",
-		LabelColor:              &common.Color{Red: 0, Green: 0, Blue: 0},
-		LabelSize:               12,
-		BackgroundColor:         &common.Color{Red: 240, Green: 240, Blue: 240},
-		BackgroundColorHover:    &common.Color{Red: 200, Green: 200, Blue: 200},
-		BackgroundColorPressed:  &common.Color{Red: 150, Green: 150, Blue: 150},
-		BackgroundColorDisabled: &common.Color{Red: 200, Green: 200, Blue: 200},
-		Roundness:               0,
+		LabelFont:               "",
+		LabelColor:              nil,
+		LabelSize:               0,
+		BackgroundColor:         nil,
+		BackgroundColorHover:    nil,
+		BackgroundColorPressed:  nil,
+		BackgroundColorDisabled: nil,
+		Roundness:               nil,
 		OnClick:                 nil,
 		ComponentOptions:        nil,
 	}
@@ -122,7 +123,8 @@ func ButtonBackgroundColorDisabledOpt(color *common.Color) CreateButtonOption {
 //   - roundness: The roundness to set for the button, this is a value clamped between 0 and 100.
 func ButtonRoundnessOpt(roundness int32) CreateButtonOption {
 	return func(opts *createButtonOptions) {
-		opts.Roundness = max(0, min(100, roundness))
+		roundness = max(0, min(100, roundness))
+		opts.Roundness = &roundness
 	}
 }
 
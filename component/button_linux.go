@@ -16,16 +16,8 @@ func drawButton(ctx *common.DrawCtx, b Button) {
 	}
 	radius := (b.Roundness() * min(w, h)) / 200
 
-	var color *common.Color
-	if !b.Enabled() {
-		color = b.BackgroundColorDisabled()
-	} else if b.Pressed() {
-		color = b.BackgroundColorPressed()
-	} else if b.Hovered() {
-		color = b.BackgroundColorHover()
-	} else {
-		color = b.BackgroundColor()
-	}
+	color, labelColor := b.(*button).stateColors()
+	color, labelColor = fade(ctx, b, color), fade(ctx, b, labelColor)
 
 	display := linux.GetDisplay(ctx.Hwnd)
 	if display == nil {
@@ -44,28 +36,25 @@ func drawButton(ctx *common.DrawCtx, b Button) {
 	fontName := b.LabelFont()
 	fontSize := b.LabelSize()
 	label := b.Label()
-	labelColor := b.LabelColor()
 
 	linux.XDrawTextCentered(display, drawable, int(x), int(y), int(w), int(h), fontName, int(fontSize), label, labelColor)
 }
 
-// mapBtnCb maps a button callback to a specific button ID.
-// This function registers the button's callback function in the Windows API.
+// registerBtnEvents registers the button's event dispatcher with the backend.
+// The backend publishes the button's PressedEvent and ClickEvent on it.
 //
 // Parameters:
-//   - b: The Button component to map the callback for.
-//   - cbMap: A map of callback functions for the button.
-func mapBtnCb(b Button, cbMap map[string]func(any)) {
-	linux.RegisterButtonCallback(b.ID(), cbMap)
+//   - b: The Button component to register the events for.
+func registerBtnEvents(b Button) {
+	linux.RegisterButtonEvents(b.ID(), b.Events())
 }
 
 // registerBtnBounds registers the button's bounds in the Windows API.
+// Parts of the button scrolled out of a surrounding ScrollView are left out so they cannot be clicked.
 // This function registers the button's position and size in the Windows API.
 //
 // Parameters:
 //   - b: The Button component to register the bounds for.
 func registerBtnBounds(b Button) {
-	x, y := b.Position()
-	w, h := b.Size()
-	linux.RegisterButtonBounds(b.ID(), [4]int32{x, y, w, h})
+	linux.RegisterButtonBounds(b.ID(), clippedBounds(b))
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/theme"
 )
 
 type baseComponent struct {
@@ -18,6 +20,29 @@ type baseComponent struct {
 	}
 	visible bool
 	enabled bool
+	parent  Container
+	anchor  Anchor
+	minSize struct {
+		Width  int32
+		Height int32
+	}
+	maxSize struct {
+		Width  int32
+		Height int32
+	}
+	anchorMargins *common.Insets
+	events        *event.Dispatcher
+	focusable     bool
+	tabIndex      int
+	tooltip       string
+	cursor        *common.Cursor
+	focused       bool
+	// focusManager is set on the top-level components of a window, nested components reach it through their parents.
+	focusManager *FocusManager
+	// theme is set on the top-level components of a window, nested components reach it through their parents.
+	theme *theme.Theme
+	// transparency is 1 - opacity, so the zero value of a component is fully opaque.
+	transparency float32
 }
 
 type TextAlignment int
@@ -37,15 +62,23 @@ const (
 // Returns:
 //   - Component: A pointer to the newly created component.
 func NewComponent(options ...CreateComponentOption) Component {
-	opts := &createComponentOptions{}
+	opts := newCreateComponentOptions()
 	for _, opt := range options {
 		opt(opts)
 	}
 
 	c := &baseComponent{
-		id:      opts.ID,
-		visible: opts.Visible,
-		enabled: opts.Enabled,
+		id:        opts.ID,
+		events:    event.NewDispatcher(),
+		visible:   opts.Visible,
+		enabled:   opts.Enabled,
+		anchor:    opts.Anchor,
+		minSize:   opts.MinSize,
+		maxSize:   opts.MaxSize,
+		focusable: opts.focusable(false),
+		tabIndex:  opts.TabIndex,
+		tooltip:   opts.Tooltip,
+		cursor:    opts.Cursor,
 		size: struct {
 			Width  int32
 			Height int32
@@ -64,6 +97,9 @@ func NewComponent(options ...CreateComponentOption) Component {
 	return c
 }
 
+// Component is the interface shared by every element placed in a window.
+// Components are not safe for concurrent use, once their window runs they must only be used on its event loop,
+// see window.Window.Invoke for updating them from other goroutines.
 type Component interface {
 	// ID returns the unique identifier for the component.
 	//
@@ -129,6 +165,235 @@ type Component interface {
 	//  - enabled: True to enable the component, false to disable it.
 	SetEnabled(enabled bool)
 
+	// PreferredSize returns the size the component would like to occupy, measured from its content.
+	// Layout managers use this value to compute the positions and sizes of their children.
+	//
+	// Returns:
+	//  - int32: The preferred width of the component.
+	//  - int32: The preferred height of the component.
+	PreferredSize() (int32, int32)
+
+	// Parent returns the container that manages the component's layout, if any.
+	//
+	// Returns:
+	//  - Container: The parent container, or nil if the component is not managed by a layout.
+	Parent() Container
+
+	// SetParent sets the container that manages the component's layout.
+	// This is called by containers when the component is added or removed and should rarely be called directly.
+	//
+	// Parameters:
+	//  - parent: The parent container, or nil to detach the component.
+	SetParent(parent Container)
+
+	// Anchor returns the edges of the window the component is anchored to.
+	//
+	// Returns:
+	//  - Anchor: The anchor flags of the component.
+	Anchor() Anchor
+
+	// SetAnchor sets the edges of the window the component is anchored to.
+	// Anchored edges keep their distance to the matching window edge when the window is resized.
+	//
+	// Parameters:
+	//  - anchor: The anchor flags to set for the component.
+	SetAnchor(anchor Anchor)
+
+	// MinSize returns the minimum width and height of the component, 0 meaning unconstrained.
+	//
+	// Returns:
+	//  - int32: The minimum width of the component.
+	//  - int32: The minimum height of the component.
+	MinSize() (int32, int32)
+
+	// SetMinSize sets the minimum width and height of the component, 0 meaning unconstrained.
+	// The constraint is applied the next time the component is sized by SetSize, a layout or a window resize.
+	//
+	// Parameters:
+	//  - width: The minimum width of the component.
+	//  - height: The minimum height of the component.
+	SetMinSize(width, height int32)
+
+	// MaxSize returns the maximum width and height of the component, 0 meaning unconstrained.
+	//
+	// Returns:
+	//  - int32: The maximum width of the component.
+	//  - int32: The maximum height of the component.
+	MaxSize() (int32, int32)
+
+	// SetMaxSize sets the maximum width and height of the component, 0 meaning unconstrained.
+	// The constraint is applied the next time the component is sized by SetSize, a layout or a window resize.
+	//
+	// Parameters:
+	//  - width: The maximum width of the component.
+	//  - height: The maximum height of the component.
+	SetMaxSize(width, height int32)
+
+	// OnMouseDown subscribes fn to the mouse button presses over the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseDown(fn func(event.MouseDownEvent)) event.Handle
+
+	// OnMouseUp subscribes fn to the releases of mouse buttons pressed over the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseUp(fn func(event.MouseUpEvent)) event.Handle
+
+	// OnMouseMove subscribes fn to the pointer moving over the component, or anywhere while dragging from it.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseMove(fn func(event.MouseMoveEvent)) event.Handle
+
+	// OnMouseEnter subscribes fn to the pointer moving onto the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseEnter(fn func(event.MouseEnterEvent)) event.Handle
+
+	// OnMouseLeave subscribes fn to the pointer moving off the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseLeave(fn func(event.MouseLeaveEvent)) event.Handle
+
+	// OnMouseWheel subscribes fn to the mouse wheel turning over the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnMouseWheel(fn func(event.WheelEvent)) event.Handle
+
+	// OnDoubleClick subscribes fn to double clicks on the component.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnDoubleClick(fn func(event.DoubleClickEvent)) event.Handle
+
+	// Focusable returns whether the component can take keyboard focus.
+	//
+	// Returns:
+	//  - bool: True if the component can take keyboard focus, false otherwise.
+	Focusable() bool
+
+	// SetFocusable sets whether the component can take keyboard focus.
+	// Making the focused component unfocusable blurs it.
+	//
+	// Parameters:
+	//  - focusable: True if the component can take keyboard focus, false otherwise.
+	SetFocusable(focusable bool)
+
+	// TabIndex returns the position of the component in the tab order of its window.
+	//
+	// Returns:
+	//  - int: The tab index, 0 for layout order and negative to skip the component when tabbing.
+	TabIndex() int
+
+	// SetTabIndex sets the position of the component in the tab order of its window.
+	// Components with a positive index are visited first in increasing order, followed by the components with index 0 in layout order.
+	//
+	// Parameters:
+	//  - index: The tab index, 0 for layout order and negative to skip the component when tabbing.
+	SetTabIndex(index int)
+
+	// Tooltip returns the tooltip of the component.
+	//
+	// Returns:
+	//  - string: The tooltip text, empty if the component has no tooltip.
+	Tooltip() string
+
+	// SetTooltip sets the tooltip of the component, shown near the pointer after it rested over the component.
+	// Over a nested component without a tooltip the tooltip of its closest ancestor with one is shown.
+	//
+	// Parameters:
+	//  - text: The tooltip text, empty for no tooltip.
+	SetTooltip(text string)
+
+	// Cursor returns the mouse pointer shown while the pointer is over the component.
+	//
+	// Returns:
+	//  - *common.Cursor: The cursor, nil to show the cursor of the component's parent or the arrow.
+	Cursor() *common.Cursor
+
+	// SetCursor sets the mouse pointer shown while the pointer is over the component.
+	// The window switches the cursor when the pointer enters or leaves the component.
+	//
+	// Parameters:
+	//  - cursor: The cursor, for example common.CursorHand, or nil for the cursor of the component type.
+	SetCursor(cursor *common.Cursor)
+
+	// Focused returns whether the component currently has keyboard focus.
+	//
+	// Returns:
+	//  - bool: True if the component has keyboard focus, false otherwise.
+	Focused() bool
+
+	// Focus moves the keyboard focus of the component's window to the component.
+	// It does nothing if the component is not focusable, hidden, disabled or not added to a window.
+	Focus()
+
+	// Blur removes the keyboard focus from the component if it has it.
+	Blur()
+
+	// Opacity returns the opacity of the component between 0 (invisible) and 1 (opaque).
+	//
+	// Returns:
+	//  - float32: The opacity of the component.
+	Opacity() float32
+
+	// SetOpacity sets the opacity of the component, clamped between 0 and 1.
+	// Children of containers are drawn with the product of their own opacity and that of their parents.
+	// Colors are blended with the window's background, so overlapping translucent components do not show through each other.
+	//
+	// Parameters:
+	//  - opacity: The opacity between 0 (invisible) and 1 (opaque).
+	SetOpacity(opacity float32)
+
+	// OnFocus subscribes fn to the component gaining and losing keyboard focus.
+	//
+	// Parameters:
+	//  - fn: The function called with each event, e.Focused is false when the component lost focus.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnFocus(fn func(event.FocusEvent)) event.Handle
+
+	// Events returns the dispatcher the component publishes its events on.
+	// Subscribe to it with event.Subscribe, for example event.Subscribe(btn.Events(), func(e event.ClickEvent) { ... }).
+	//
+	// Returns:
+	//  - *event.Dispatcher: The event dispatcher of the component.
+	Events() *event.Dispatcher
+
+	// Theme returns the theme the component is drawn with, the theme of its window or the default light theme.
+	// Colors, fonts and sizes the component was not given explicitly are taken from it.
+	//
+	// Returns:
+	//  - *theme.Theme: The theme of the component.
+	Theme() *theme.Theme
+
 	// Draw draws the component using the provided context.
 	//
 	// Parameters:
@@ -138,6 +403,20 @@ type Component interface {
 
 var _ Component = (*baseComponent)(nil)
 
+// KeyConsumer is implemented by components that handle keys themselves while they have focus.
+// Window shortcuts which yield to focus do not fire for keys the focused component consumes.
+type KeyConsumer interface {
+	// ConsumesKey reports whether the component handles the key combination itself.
+	//
+	// Parameters:
+	//  - key: The key that was pressed.
+	//  - mods: The modifiers held while the key was pressed.
+	//
+	// Returns:
+	//  - bool: True if the component handles the key, false otherwise.
+	ConsumesKey(key event.Key, mods event.Modifiers) bool
+}
+
 func (c *baseComponent) ID() uintptr {
 	return c.id
 }
@@ -151,8 +430,8 @@ func (c *baseComponent) Size() (int32, int32) {
 }
 
 func (c *baseComponent) SetSize(width, height int32) {
-	c.size.Width = width
-	c.size.Height = height
+	c.size.Width, c.size.Height = c.constrain(width, height)
+	c.anchorMargins = nil
 }
 
 func (c *baseComponent) Position() (int32, int32) {
@@ -162,6 +441,7 @@ func (c *baseComponent) Position() (int32, int32) {
 func (c *baseComponent) SetPosition(x, y int32) {
 	c.position.X = x
 	c.position.Y = y
+	c.anchorMargins = nil
 }
 
 func (c *baseComponent) Visible() bool {
@@ -169,7 +449,14 @@ func (c *baseComponent) Visible() bool {
 }
 
 func (c *baseComponent) SetVisible(visible bool) {
+	if c.visible == visible {
+		return
+	}
 	c.visible = visible
+	if !visible {
+		c.Blur()
+	}
+	c.invalidateLayout()
 }
 
 func (c *baseComponent) Enabled() bool {
@@ -178,6 +465,165 @@ func (c *baseComponent) Enabled() bool {
 
 func (c *baseComponent) SetEnabled(enabled bool) {
 	c.enabled = enabled
+	if !enabled {
+		c.Blur()
+	}
+}
+
+func (c *baseComponent) Opacity() float32 {
+	return 1 - c.transparency
+}
+
+func (c *baseComponent) SetOpacity(opacity float32) {
+	c.transparency = 1 - max(0, min(1, opacity))
+}
+
+func (c *baseComponent) PreferredSize() (int32, int32) {
+	return c.size.Width, c.size.Height
+}
+
+func (c *baseComponent) Parent() Container {
+	return c.parent
+}
+
+func (c *baseComponent) SetParent(parent Container) {
+	treeMu.Lock()
+	defer treeMu.Unlock()
+	c.parent = parent
+}
+
+func (c *baseComponent) Anchor() Anchor {
+	return c.anchor
+}
+
+func (c *baseComponent) SetAnchor(anchor Anchor) {
+	c.anchor = anchor
+	c.anchorMargins = nil
+}
+
+func (c *baseComponent) MinSize() (int32, int32) {
+	return c.minSize.Width, c.minSize.Height
+}
+
+func (c *baseComponent) SetMinSize(width, height int32) {
+	c.minSize.Width = width
+	c.minSize.Height = height
+	c.invalidateLayout()
+}
+
+func (c *baseComponent) MaxSize() (int32, int32) {
+	return c.maxSize.Width, c.maxSize.Height
+}
+
+func (c *baseComponent) SetMaxSize(width, height int32) {
+	c.maxSize.Width = width
+	c.maxSize.Height = height
+	c.invalidateLayout()
+}
+
+func (c *baseComponent) Events() *event.Dispatcher {
+	return c.events
+}
+
+func (c *baseComponent) OnMouseDown(fn func(event.MouseDownEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnMouseUp(fn func(event.MouseUpEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnMouseMove(fn func(event.MouseMoveEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnMouseEnter(fn func(event.MouseEnterEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnMouseLeave(fn func(event.MouseLeaveEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnMouseWheel(fn func(event.WheelEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) OnDoubleClick(fn func(event.DoubleClickEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+func (c *baseComponent) Focusable() bool {
+	return c.focusable
+}
+
+func (c *baseComponent) SetFocusable(focusable bool) {
+	c.focusable = focusable
+	if !focusable {
+		c.Blur()
+	}
+}
+
+func (c *baseComponent) TabIndex() int {
+	return c.tabIndex
+}
+
+func (c *baseComponent) SetTabIndex(index int) {
+	c.tabIndex = index
+}
+
+func (c *baseComponent) Tooltip() string {
+	return c.tooltip
+}
+
+func (c *baseComponent) SetTooltip(text string) {
+	c.tooltip = text
+}
+
+func (c *baseComponent) Cursor() *common.Cursor {
+	return c.cursor
+}
+
+func (c *baseComponent) SetCursor(cursor *common.Cursor) {
+	c.cursor = cursor
+}
+
+func (c *baseComponent) Focused() bool {
+	return c.focused
+}
+
+func (c *baseComponent) Focus() {
+	if fm := c.rootFocusManager(); fm != nil {
+		fm.focusID(c.id)
+	}
+}
+
+func (c *baseComponent) Blur() {
+	if fm := c.rootFocusManager(); fm != nil {
+		fm.blurID(c.id)
+	}
+}
+
+func (c *baseComponent) OnFocus(fn func(event.FocusEvent)) event.Handle {
+	return event.Subscribe(c.events, fn)
+}
+
+// base exposes the embedded baseComponent so package helpers can reach state outside of the Component interface.
+func (c *baseComponent) base() *baseComponent {
+	return c
+}
+
+// constrain clamps the given size to the component's minimum and maximum size.
+func (c *baseComponent) constrain(width, height int32) (int32, int32) {
+	return clampSize(width, c.minSize.Width, c.maxSize.Width), clampSize(height, c.minSize.Height, c.maxSize.Height)
+}
+
+// invalidateLayout notifies the parent container that the component's content has changed.
+// The parent re-runs its layout so the component is re-measured and re-positioned.
+func (c *baseComponent) invalidateLayout() {
+	if c.parent != nil {
+		c.parent.InvalidateLayout()
+	}
 }
 
 // Draw should never be called from the base Component level and will panic if done so.
@@ -201,13 +647,125 @@ func drawComponent(c any, ctx *common.DrawCtx) {
 		if comp.Visible() {
 			drawLabel(ctx, comp)
 		}
+	case TextArea:
+		if comp.Visible() {
+			drawTextArea(ctx, comp)
+		}
 	case TextInput:
 		if comp.Visible() {
 			drawTextInput(ctx, comp)
 		}
 	case Selector:
 		drawSelector(ctx, comp)
+	case ScrollView:
+		if comp.Visible() {
+			drawScrollView(ctx, comp)
+		}
+	case Container:
+		if comp.Visible() {
+			for _, child := range comp.Children() {
+				child.Draw(ctx)
+			}
+		}
 	default:
 		fmt.Println("unsupported component type")
 	}
 }
+
+// HitTest reports whether the point lies on the visible part of the component.
+// Parts of the component scrolled out of the viewport of a surrounding ScrollView are not hit.
+//
+// Parameters:
+//   - c: The component to test.
+//   - x: The x-coordinate of the point in window coordinates.
+//   - y: The y-coordinate of the point in window coordinates.
+//
+// Returns:
+//   - bool: True if the point is on the visible part of the component, false otherwise.
+func HitTest(c Component, x, y int32) bool {
+	cx, cy := c.Position()
+	w, h := c.Size()
+	if !(common.Rect{X: cx, Y: cy, W: w, H: h}).Contains(x, y) {
+		return false
+	}
+	clip := clipOf(c)
+	return clip == nil || clip.Contains(x, y)
+}
+
+// HitPath returns the chain of visible components under the point, from c down to the innermost one.
+// Children added later are drawn on top and therefore win over earlier siblings.
+//
+// Parameters:
+//   - c: The component to start from.
+//   - x: The x-coordinate of the point in window coordinates.
+//   - y: The y-coordinate of the point in window coordinates.
+//
+// Returns:
+//   - []Component: The components under the point, outermost first, or nil if c is not under the point.
+func HitPath(c Component, x, y int32) []Component {
+	if !c.Visible() || !HitTest(c, x, y) {
+		return nil
+	}
+	path := []Component{c}
+	if container, ok := c.(Container); ok {
+		children := container.Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			if sub := HitPath(children[i], x, y); sub != nil {
+				return append(path, sub...)
+			}
+		}
+	}
+	return path
+}
+
+// clipOf returns the intersection of the viewports of every ScrollView the component is nested in.
+// It returns nil when the component is not inside a ScrollView.
+func clipOf(c Component) *common.Rect {
+	var clip *common.Rect
+	for p := c.Parent(); p != nil; p = p.Parent() {
+		sv, ok := p.(ScrollView)
+		if !ok {
+			continue
+		}
+		vp := sv.Viewport()
+		if clip != nil {
+			vp = vp.Intersect(*clip)
+		}
+		clip = &vp
+	}
+	return clip
+}
+
+// clippedBounds returns the bounds of the component limited to the viewports it is nested in.
+func clippedBounds(c Component) [4]int32 {
+	x, y := c.Position()
+	w, h := c.Size()
+	rect := common.Rect{X: x, Y: y, W: w, H: h}
+	if clip := clipOf(c); clip != nil {
+		rect = rect.Intersect(*clip)
+	}
+	return [4]int32{rect.X, rect.Y, rect.W, rect.H}
+}
+
+// Walk visits the component and, for containers, all of its descendants in drawing order.
+// Returning false from fn stops the walk.
+//
+// Parameters:
+//   - c: The component to start walking from.
+//   - fn: The function called for each visited component.
+//
+// Returns:
+//   - bool: False if the walk was stopped early, true otherwise.
+func Walk(c Component, fn func(Component) bool) bool {
+	if !fn(c) {
+		return false
+	}
+	if container, ok := c.(Container); ok {
+		for _, child := range container.Children() {
+			if !Walk(child, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
 package component
 
+import "github.com/Carmen-Shannon/gooey/common"
+
 type createComponentOptions struct {
 	ID   uintptr
 	Size struct {
@@ -12,10 +14,33 @@ type createComponentOptions struct {
 	}
 	Visible bool
 	Enabled bool
+	Anchor  Anchor
+	MinSize struct {
+		Width  int32
+		Height int32
+	}
+	MaxSize struct {
+		Width  int32
+		Height int32
+	}
+	// Focusable is nil when the component type decides whether it takes keyboard focus.
+	Focusable *bool
+	TabIndex  int
+	Tooltip   string
+	// Cursor is nil while the component uses the cursor of its type.
+	Cursor *common.Cursor
 }
 
 type CreateComponentOption func(*createComponentOptions)
 
+// focusable returns whether the component takes keyboard focus, falling back to the default of the component type.
+func (opts *createComponentOptions) focusable(def bool) bool {
+	if opts.Focusable != nil {
+		return *opts.Focusable
+	}
+	return def
+}
+
 func newCreateComponentOptions() *createComponentOptions {
 	return &createComponentOptions{
 		ID:       0,
@@ -23,6 +48,7 @@ func newCreateComponentOptions() *createComponentOptions {
 		Position: struct{ X, Y int32 }{X: 0, Y: 0},
 		Visible:  true,
 		Enabled:  true,
+		Anchor:   AnchorLeft | AnchorTop,
 	}
 }
 
@@ -99,3 +125,105 @@ func ComponentEnabledOpt(enabled bool) CreateComponentOption {
 		opts.Enabled = enabled
 	}
 }
+
+// ComponentAnchorOpt sets the edges of the window the component is anchored to.
+// It takes a combination of Anchor flags and returns a CreateComponentOption function.
+//
+// Parameters:
+//   - anchor: The anchor flags of the component, defaults to AnchorLeft | AnchorTop.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentAnchorOpt(anchor Anchor) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.Anchor = anchor
+	}
+}
+
+// ComponentMinSizeOpt sets the minimum size of the component.
+// It takes width and height as int32 values, 0 meaning unconstrained, and returns a CreateComponentOption function.
+//
+// Parameters:
+//   - width: The minimum width of the component.
+//   - height: The minimum height of the component.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentMinSizeOpt(width, height int32) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.MinSize.Width = width
+		opts.MinSize.Height = height
+	}
+}
+
+// ComponentMaxSizeOpt sets the maximum size of the component.
+// It takes width and height as int32 values, 0 meaning unconstrained, and returns a CreateComponentOption function.
+//
+// Parameters:
+//   - width: The maximum width of the component.
+//   - height: The maximum height of the component.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentMaxSizeOpt(width, height int32) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.MaxSize.Width = width
+		opts.MaxSize.Height = height
+	}
+}
+
+// ComponentFocusableOpt sets whether the component can take keyboard focus.
+// Buttons and text inputs are focusable by default, other components are not.
+//
+// Parameters:
+//   - focusable: True if the component can take keyboard focus, false otherwise.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentFocusableOpt(focusable bool) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.Focusable = &focusable
+	}
+}
+
+// ComponentTabIndexOpt sets the position of the component in the tab order of its window.
+// Components with a positive index are visited first in increasing order, followed by the components with index 0 in layout order.
+// A negative index leaves the component out of tab traversal while it can still be focused by clicking it or calling Focus.
+//
+// Parameters:
+//   - index: The tab index of the component.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentTabIndexOpt(index int) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.TabIndex = index
+	}
+}
+
+// ComponentTooltipOpt sets the tooltip of the component, a short text shown near the pointer after it rested over the component.
+//
+// Parameters:
+//   - text: The tooltip text, empty for no tooltip.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentTooltipOpt(text string) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.Tooltip = text
+	}
+}
+
+// ComponentCursorOpt sets the mouse pointer shown while the pointer is over the component,
+// for example common.CursorHand or a custom cursor created with common.ImageCursor.
+//
+// Parameters:
+//   - cursor: The cursor of the component.
+//
+// Returns:
+//   - CreateComponentOption: A function that takes a pointer to createComponentOptions
+func ComponentCursorOpt(cursor *common.Cursor) CreateComponentOption {
+	return func(opts *createComponentOptions) {
+		opts.Cursor = cursor
+	}
+}
@@ -0,0 +1,74 @@
+package component
+
+import "math"
+
+// Easing maps the linear progress of an animation between 0 and 1 to the progress of the animated value.
+// Curves may overshoot 0 and 1 in between, but must return 1 for 1.
+type Easing func(t float64) float64
+
+// Linear moves at a constant speed.
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad starts slow and accelerates.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad starts fast and decelerates.
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad accelerates through the first half and decelerates through the second.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// EaseInCubic starts slow and accelerates, more pronounced than EaseInQuad.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic starts fast and decelerates, more pronounced than EaseOutQuad.
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic accelerates through the first half and decelerates through the second, more pronounced than EaseInOutQuad.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// EaseOutBack overshoots the target slightly before settling on it.
+func EaseOutBack(t float64) float64 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	return 1 + c3*math.Pow(t-1, 3) + c1*math.Pow(t-1, 2)
+}
+
+// EaseOutBounce bounces off the target a few times before settling on it.
+func EaseOutBounce(t float64) float64 {
+	const n1 = 7.5625
+	const d1 = 2.75
+	switch {
+	case t < 1/d1:
+		return n1 * t * t
+	case t < 2/d1:
+		t -= 1.5 / d1
+		return n1*t*t + 0.75
+	case t < 2.5/d1:
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375
+	default:
+		t -= 2.625 / d1
+		return n1*t*t + 0.984375
+	}
+}
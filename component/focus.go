@@ -0,0 +1,291 @@
+package component
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// FocusManager tracks which component of a window has keyboard focus and moves it along the tab order.
+// Every window owns one manager; its top-level components are attached to it and nested components reach it through their parents.
+type FocusManager struct {
+	mu          sync.Mutex
+	hwnd        uintptr
+	roots       func() []Component
+	focused     Component
+	ringVisible bool
+}
+
+// focusTarget is implemented by the package's component types through baseComponent.
+// Text inputs override it because the backend owns their focus and publishes their focus events.
+type focusTarget interface {
+	setFocused(fm *FocusManager, focused bool)
+}
+
+// NewFocusManager creates the focus manager of a window.
+//
+// Parameters:
+//   - hwnd: The handle of the window the manager belongs to.
+//   - roots: A function returning the current top-level components of the window.
+//
+// Returns:
+//   - *FocusManager: A pointer to the newly created focus manager.
+func NewFocusManager(hwnd uintptr, roots func() []Component) *FocusManager {
+	return &FocusManager{
+		hwnd:  hwnd,
+		roots: roots,
+	}
+}
+
+// Attach connects a top-level component of the window to the manager so it and its descendants can call Focus and Blur.
+//
+// Parameters:
+//   - c: The top-level component added to the window.
+func (fm *FocusManager) Attach(c Component) {
+	if b := baseOf(c); b != nil {
+		treeMu.Lock()
+		b.focusManager = fm
+		treeMu.Unlock()
+	}
+}
+
+// Detach disconnects a top-level component removed from the window, blurring it or its focused descendant.
+//
+// Parameters:
+//   - c: The top-level component removed from the window.
+func (fm *FocusManager) Detach(c Component) {
+	if focused := fm.Focused(); focused != nil {
+		Walk(c, func(d Component) bool {
+			if d == focused {
+				fm.focus(nil, false)
+				return false
+			}
+			return true
+		})
+	}
+	if b := baseOf(c); b != nil {
+		treeMu.Lock()
+		b.focusManager = nil
+		treeMu.Unlock()
+	}
+}
+
+// Focused returns the component holding the keyboard focus.
+//
+// Returns:
+//   - Component: The focused component, or nil if no component has focus.
+func (fm *FocusManager) Focused() Component {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	return fm.focused
+}
+
+// Focus moves the keyboard focus to c and shows the focus ring around it.
+// It does nothing if c is not focusable, hidden or disabled.
+//
+// Parameters:
+//   - c: The component to focus.
+func (fm *FocusManager) Focus(c Component) {
+	if c != nil && CanFocus(c) {
+		fm.focus(c, true)
+	}
+}
+
+// FocusByClick moves the keyboard focus to a component the user clicked, without showing the focus ring.
+// Clicking a component that cannot take focus removes the focus from the window.
+//
+// Parameters:
+//   - c: The clicked component, or nil if no focusable component was clicked.
+func (fm *FocusManager) FocusByClick(c Component) {
+	if c != nil && !CanFocus(c) {
+		c = nil
+	}
+	fm.focus(c, false)
+}
+
+// Blur removes the keyboard focus from the window.
+func (fm *FocusManager) Blur() {
+	fm.focus(nil, false)
+}
+
+// Next moves the keyboard focus to the next component in the tab order, wrapping around at the end.
+//
+// Returns:
+//   - bool: True if a component received focus, false if the window has no component to tab to.
+func (fm *FocusManager) Next() bool {
+	return fm.step(1)
+}
+
+// Prev moves the keyboard focus to the previous component in the tab order, wrapping around at the start.
+//
+// Returns:
+//   - bool: True if a component received focus, false if the window has no component to tab to.
+func (fm *FocusManager) Prev() bool {
+	return fm.step(-1)
+}
+
+// TabOrder returns the components of the window visited by Tab, in order.
+// Components with a positive tab index come first sorted by index, followed by the components with index 0 in layout order.
+// Components which are hidden, disabled, not focusable or have a negative tab index are left out.
+//
+// Returns:
+//   - []Component: The components in tab order.
+func (fm *FocusManager) TabOrder() []Component {
+	var indexed, ordered []Component
+	for _, root := range fm.roots() {
+		Walk(root, func(c Component) bool {
+			if !c.Visible() {
+				return false
+			}
+			if !c.Focusable() || !c.Enabled() || c.TabIndex() < 0 {
+				return true
+			}
+			if c.TabIndex() > 0 {
+				indexed = append(indexed, c)
+			} else {
+				ordered = append(ordered, c)
+			}
+			return true
+		})
+	}
+	slices.SortStableFunc(indexed, func(a, b Component) int {
+		return a.TabIndex() - b.TabIndex()
+	})
+	return append(indexed, ordered...)
+}
+
+// DrawFocusRing draws the focus ring around the focused component if the focus was moved by the keyboard or by Focus.
+// Parts of the component scrolled out of a surrounding ScrollView are left out.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the ring.
+func (fm *FocusManager) DrawFocusRing(ctx *common.DrawCtx) {
+	fm.mu.Lock()
+	focused, visible := fm.focused, fm.ringVisible
+	fm.mu.Unlock()
+
+	if focused == nil || !visible || !CanFocus(focused) {
+		return
+	}
+	b := clippedBounds(focused)
+	if b[2] <= 0 || b[3] <= 0 {
+		return
+	}
+	drawFocusRing(ctx, common.Rect{X: b[0], Y: b[1], W: b[2], H: b[3]}, &focused.Theme().Palette.Primary)
+}
+
+// CanFocus reports whether the component can currently take keyboard focus.
+// It has to be focusable and enabled, and neither it nor any of its parents may be hidden.
+//
+// Parameters:
+//   - c: The component to check.
+//
+// Returns:
+//   - bool: True if the component can take keyboard focus, false otherwise.
+func CanFocus(c Component) bool {
+	if !c.Focusable() || !c.Enabled() {
+		return false
+	}
+	var p Component = c
+	for p != nil {
+		if !p.Visible() {
+			return false
+		}
+		parent := p.Parent()
+		if parent == nil {
+			break
+		}
+		p = parent
+	}
+	return true
+}
+
+// step moves the focus by dir places along the tab order.
+func (fm *FocusManager) step(dir int) bool {
+	order := fm.TabOrder()
+	if len(order) == 0 {
+		return false
+	}
+	i := slices.Index(order, fm.Focused())
+	switch {
+	case i < 0 && dir > 0:
+		i = 0
+	case i < 0:
+		i = len(order) - 1
+	default:
+		i = (i + dir + len(order)) % len(order)
+	}
+	fm.focus(order[i], true)
+	return true
+}
+
+// focus moves the focus to c, nil removing it, and notifies the previously and newly focused components.
+func (fm *FocusManager) focus(c Component, ring bool) {
+	fm.mu.Lock()
+	prev := fm.focused
+	fm.focused = c
+	fm.ringVisible = ring && c != nil
+	fm.mu.Unlock()
+
+	if prev == c {
+		return
+	}
+	if t, ok := prev.(focusTarget); ok {
+		t.setFocused(fm, false)
+	}
+	if t, ok := c.(focusTarget); ok {
+		t.setFocused(fm, true)
+	}
+}
+
+// focusID focuses the component with the given ID if it belongs to the window.
+func (fm *FocusManager) focusID(id uintptr) {
+	for _, root := range fm.roots() {
+		var found Component
+		Walk(root, func(c Component) bool {
+			if c.ID() == id {
+				found = c
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			fm.Focus(found)
+			return
+		}
+	}
+}
+
+// blurID removes the focus from the window if the component with the given ID holds it.
+func (fm *FocusManager) blurID(id uintptr) {
+	if focused := fm.Focused(); focused != nil && focused.ID() == id {
+		fm.focus(nil, false)
+	}
+}
+
+// setFocused records the focus state of the component and publishes a FocusEvent when it changed.
+func (c *baseComponent) setFocused(fm *FocusManager, focused bool) {
+	if c.focused == focused {
+		return
+	}
+	c.focused = focused
+	event.Publish(c.events, event.FocusEvent{Source: c.id, Focused: focused})
+}
+
+// treeMu guards the links tying components to their parents and windows, so animators can find the window of a
+// component from their ticker goroutine while the UI thread adds and removes components.
+var treeMu sync.RWMutex
+
+// rootFocusManager returns the focus manager of the window the component was added to, or nil.
+func (c *baseComponent) rootFocusManager() *FocusManager {
+	root := c
+	for p := c.parent; p != nil; p = p.Parent() {
+		if b := baseOf(p); b != nil {
+			root = b
+		}
+	}
+	return root.focusManager
+}
@@ -0,0 +1,27 @@
+//go:build linux
+// +build linux
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/linux"
+)
+
+// drawFocusRing draws a two pixel wide ring just inside the bounds of the focused component.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the ring.
+//   - r: The visible bounds of the focused component.
+//   - color: The color of the ring.
+func drawFocusRing(ctx *common.DrawCtx, r common.Rect, color *common.Color) {
+	display := linux.GetDisplay(ctx.Hwnd)
+	if display == nil {
+		return
+	}
+	drawable := linux.C_Drawable(ctx.Hdc)
+	linux.XDrawRect(display, drawable, int(r.X), int(r.Y), int(r.W), int(r.H), color)
+	if r.W > 2 && r.H > 2 {
+		linux.XDrawRect(display, drawable, int(r.X+1), int(r.Y+1), int(r.W-2), int(r.H-2), color)
+	}
+}
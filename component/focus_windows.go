@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+// drawFocusRing draws a two pixel wide ring just inside the bounds of the focused component.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the ring.
+//   - r: The visible bounds of the focused component.
+//   - color: The color of the ring.
+func drawFocusRing(ctx *common.DrawCtx, r common.Rect, color *common.Color) {
+	const width = 2
+	fillRect(ctx.Hdc, common.Rect{X: r.X, Y: r.Y, W: r.W, H: min(width, r.H)}, color)
+	fillRect(ctx.Hdc, common.Rect{X: r.X, Y: r.Y + r.H - min(width, r.H), W: r.W, H: min(width, r.H)}, color)
+	fillRect(ctx.Hdc, common.Rect{X: r.X, Y: r.Y, W: min(width, r.W), H: r.H}, color)
+	fillRect(ctx.Hdc, common.Rect{X: r.X + r.W - min(width, r.W), Y: r.Y, W: min(width, r.W), H: r.H}, color)
+}
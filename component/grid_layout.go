@@ -0,0 +1,244 @@
+package component
+
+import "slices"
+
+type gridLayout struct {
+	baseLayout
+	rowStretch map[int32]int32
+	colStretch map[int32]int32
+}
+
+type GridLayout interface {
+	Layout
+
+	// AddChild adds a component to the grid at the given cell.
+	// Use LayoutSpanOpt to make the child occupy more than one row or column.
+	//
+	// Parameters:
+	//  - c: The component to add.
+	//  - row: The zero-based row of the cell.
+	//  - col: The zero-based column of the cell.
+	//  - options: A variadic list of LayoutChildOption functions describing how the child is placed.
+	AddChild(c Component, row, col int32, options ...LayoutChildOption)
+
+	// RowStretch returns the stretch factor of a row.
+	//
+	// Parameters:
+	//  - row: The zero-based row index.
+	//
+	// Returns:
+	//  - int32: The stretch factor of the row.
+	RowStretch(row int32) int32
+
+	// SetRowStretch sets the share of the extra vertical space a row receives.
+	//
+	// Parameters:
+	//  - row: The zero-based row index.
+	//  - stretch: The stretch factor of the row, 0 keeps the row at its preferred height.
+	SetRowStretch(row, stretch int32)
+
+	// ColumnStretch returns the stretch factor of a column.
+	//
+	// Parameters:
+	//  - col: The zero-based column index.
+	//
+	// Returns:
+	//  - int32: The stretch factor of the column.
+	ColumnStretch(col int32) int32
+
+	// SetColumnStretch sets the share of the extra horizontal space a column receives.
+	//
+	// Parameters:
+	//  - col: The zero-based column index.
+	//  - stretch: The stretch factor of the column, 0 keeps the column at its preferred width.
+	SetColumnStretch(col, stretch int32)
+}
+
+var _ GridLayout = (*gridLayout)(nil)
+
+// NewGrid creates a new layout that places its children in rows and columns.
+// It accepts a variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Parameters:
+//   - options: A variadic list of CreateLayoutOption functions to customize the layout's properties.
+//
+// Returns:
+//   - GridLayout: A pointer to the newly created grid layout.
+func NewGrid(options ...CreateLayoutOption) GridLayout {
+	opts := newCreateLayoutOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	g := &gridLayout{
+		baseLayout: newBaseLayout(opts),
+		rowStretch: make(map[int32]int32),
+		colStretch: make(map[int32]int32),
+	}
+	g.self = g
+	return g
+}
+
+func (g *gridLayout) AddChild(c Component, row, col int32, options ...LayoutChildOption) {
+	params := newLayoutParams()
+	for _, opt := range options {
+		opt(&params)
+	}
+	params.Row = max(0, row)
+	params.Col = max(0, col)
+	params.RowSpan = max(1, params.RowSpan)
+	params.ColSpan = max(1, params.ColSpan)
+	g.addChild(c, params)
+}
+
+func (g *gridLayout) RowStretch(row int32) int32 {
+	return g.rowStretch[row]
+}
+
+func (g *gridLayout) SetRowStretch(row, stretch int32) {
+	g.rowStretch[row] = max(0, stretch)
+	g.InvalidateLayout()
+}
+
+func (g *gridLayout) ColumnStretch(col int32) int32 {
+	return g.colStretch[col]
+}
+
+func (g *gridLayout) SetColumnStretch(col, stretch int32) {
+	g.colStretch[col] = max(0, stretch)
+	g.InvalidateLayout()
+}
+
+// measure computes the preferred width of every column and height of every row.
+// Spanning children first fill the span with the single-cell sizes, then share any remaining deficit evenly.
+//
+// Returns:
+//   - []int32: The preferred width of each column.
+//   - []int32: The preferred height of each row.
+func (g *gridLayout) measure() ([]int32, []int32) {
+	spacing := g.Spacing()
+	children, params := g.visibleChildren()
+	var rows, cols int32
+	for _, p := range params {
+		rows = max(rows, p.Row+p.RowSpan)
+		cols = max(cols, p.Col+p.ColSpan)
+	}
+	colW := make([]int32, cols)
+	rowH := make([]int32, rows)
+	prefs := make([][2]int32, len(children))
+	for i, c := range children {
+		w, h := preferredSize(c)
+		prefs[i] = [2]int32{w, h}
+		if params[i].ColSpan == 1 {
+			colW[params[i].Col] = max(colW[params[i].Col], w)
+		}
+		if params[i].RowSpan == 1 {
+			rowH[params[i].Row] = max(rowH[params[i].Row], h)
+		}
+	}
+	for i := range children {
+		p := params[i]
+		if p.ColSpan > 1 {
+			growSpan(colW[p.Col:p.Col+p.ColSpan], prefs[i][0], spacing)
+		}
+		if p.RowSpan > 1 {
+			growSpan(rowH[p.Row:p.Row+p.RowSpan], prefs[i][1], spacing)
+		}
+	}
+	return colW, rowH
+}
+
+// growSpan widens the tracks of a span so their total, including spacing, fits the preferred size.
+func growSpan(tracks []int32, pref, spacing int32) {
+	have := spanSize(tracks, spacing)
+	if pref <= have {
+		return
+	}
+	deficit := pref - have
+	n := int32(len(tracks))
+	for i := range tracks {
+		tracks[i] += deficit / n
+	}
+	tracks[len(tracks)-1] += deficit % n
+}
+
+// spanSize returns the total size of the tracks including the spacing between them.
+func spanSize(tracks []int32, spacing int32) int32 {
+	var total int32
+	for _, t := range tracks {
+		total += t
+	}
+	if len(tracks) > 1 {
+		total += spacing * int32(len(tracks)-1)
+	}
+	return total
+}
+
+func (g *gridLayout) PreferredSize() (int32, int32) {
+	colW, rowH := g.measure()
+	spacing := g.Spacing()
+	return spanSize(colW, spacing) + g.padding.Left + g.padding.Right,
+		spanSize(rowH, spacing) + g.padding.Top + g.padding.Bottom
+}
+
+func (g *gridLayout) Relayout() {
+	g.fitToContent()
+	children, params := g.visibleChildren()
+	if len(children) == 0 {
+		return
+	}
+	inner := g.innerRect()
+	colW, rowH := g.measure()
+	spacing := g.Spacing()
+
+	colStretch := make([]int32, len(colW))
+	for i := range colStretch {
+		colStretch[i] = g.colStretch[int32(i)]
+	}
+	rowStretch := make([]int32, len(rowH))
+	for i := range rowStretch {
+		rowStretch[i] = g.rowStretch[int32(i)]
+	}
+	distribute(colW, colStretch, inner.W-spanSize(colW, spacing))
+	distribute(rowH, rowStretch, inner.H-spanSize(rowH, spacing))
+
+	for i, c := range children {
+		p := params[i]
+		cellX := inner.X + spanSize(colW[:p.Col], spacing)
+		cellY := inner.Y + spanSize(rowH[:p.Row], spacing)
+		if p.Col > 0 {
+			cellX += spacing
+		}
+		if p.Row > 0 {
+			cellY += spacing
+		}
+		cellW := spanSize(colW[p.Col:p.Col+p.ColSpan], spacing)
+		cellH := spanSize(rowH[p.Row:p.Row+p.RowSpan], spacing)
+
+		prefW, prefH := preferredSize(c)
+		w, x := alignSpan(p.HAlign, prefW, cellW)
+		h, y := alignSpan(p.VAlign, prefH, cellH)
+		c.SetPosition(cellX+x, cellY+y)
+		c.SetSize(w, h)
+	}
+}
+
+// Children returns the children of the grid ordered by row and then by column.
+func (g *gridLayout) Children() []Component {
+	order := make([]int, len(g.children))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		pa, pb := g.params[a], g.params[b]
+		if pa.Row != pb.Row {
+			return int(pa.Row - pb.Row)
+		}
+		return int(pa.Col - pb.Col)
+	})
+	children := make([]Component, len(order))
+	for i, idx := range order {
+		children[i] = g.children[idx]
+	}
+	return children
+}
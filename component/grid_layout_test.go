@@ -0,0 +1,37 @@
+package component
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrowSpan(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracks  []int32
+		pref    int32
+		spacing int32
+		want    []int32
+	}{
+		{name: "already fits", tracks: []int32{10, 10}, pref: 20, want: []int32{10, 10}},
+		{name: "smaller", tracks: []int32{10, 10}, pref: 5, want: []int32{10, 10}},
+		{name: "single track", tracks: []int32{10}, pref: 25, want: []int32{25}},
+		{name: "even deficit", tracks: []int32{10, 10}, pref: 30, want: []int32{15, 15}},
+		{name: "remainder to last", tracks: []int32{0, 0, 0}, pref: 11, want: []int32{3, 3, 5}},
+		{name: "spacing counts", tracks: []int32{10, 10}, pref: 30, spacing: 4, want: []int32{13, 13}},
+		{name: "spacing fits", tracks: []int32{10, 10}, pref: 24, spacing: 4, want: []int32{10, 10}},
+		{name: "spacing with remainder", tracks: []int32{5, 5, 5}, pref: 30, spacing: 2, want: []int32{8, 8, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracks := slices.Clone(tt.tracks)
+			growSpan(tracks, tt.pref, tt.spacing)
+			if !slices.Equal(tracks, tt.want) {
+				t.Errorf("growSpan(%v, %d, %d) = %v, want %v", tt.tracks, tt.pref, tt.spacing, tracks, tt.want)
+			}
+			if got := spanSize(tracks, tt.spacing); got < tt.pref {
+				t.Errorf("spanSize after growSpan = %d, want at least %d", got, tt.pref)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package component
 
-import "github.com/Carmen-Shannon/gooey/common"
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
 
 type label struct {
 	baseComponent
@@ -32,9 +35,17 @@ func NewLabel(options ...CreateLabelOption) Label {
 
 	l := &label{
 		baseComponent: baseComponent{
-			id:      cOpts.ID,
-			visible: cOpts.Visible,
-			enabled: cOpts.Enabled,
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(false),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
 			size: struct {
 				Width  int32
 				Height int32
@@ -59,6 +70,8 @@ func NewLabel(options ...CreateLabelOption) Label {
 	return l
 }
 
+// Label is a component displaying text.
+// The font, text size and color come from the label's theme until they are set explicitly.
 type Label interface {
 	Component
 
@@ -147,18 +160,26 @@ func (l *label) Text() string {
 
 func (l *label) SetText(text string) {
 	l.text = text
+	l.invalidateLayout()
 }
 
 func (l *label) Font() string {
-	return l.font
+	if l.font != "" {
+		return l.font
+	}
+	return l.Theme().Label.Font.Family
 }
 
 func (l *label) SetFont(font string) {
 	l.font = font
+	l.invalidateLayout()
 }
 
 func (l *label) Color() *common.Color {
-	return l.color
+	if l.color != nil {
+		return l.color
+	}
+	return l.Theme().Label.Resolve(l.themeState()).Foreground
 }
 
 func (l *label) SetColor(color *common.Color) {
@@ -166,11 +187,15 @@ func (l *label) SetColor(color *common.Color) {
 }
 
 func (l *label) TextSize() int32 {
-	return l.textSize
+	if l.textSize > 0 {
+		return l.textSize
+	}
+	return l.Theme().Label.Font.Size
 }
 
 func (l *label) SetTextSize(size int32) {
 	l.textSize = size
+	l.invalidateLayout()
 }
 
 func (l *label) TextAlignment() TextAlignment {
@@ -187,4 +212,11 @@ func (l *label) WordWrap() bool {
 
 func (l *label) SetWordWrap(wordWrap bool) {
 	l.wordWrap = wordWrap
+	l.invalidateLayout()
+}
+
+// PreferredSize returns the size of the label's text plus a small padding on each side.
+func (l *label) PreferredSize() (int32, int32) {
+	w, h := measureText(l.Font(), l.TextSize(), l.text)
+	return w + 8, h + 8
 }
@@ -17,9 +17,9 @@ type CreateLabelOption func(*createLabelOptions)
 func newCreateLabelOptions() *createLabelOptions {
 	return &createLabelOptions{
 		Text:          "Label",
-		Font:          "Arial",
-		Color:         &common.Color{Red: 0, Green: 0, Blue: 0},
-		TextSize:      12,
+		Font:          "",
+		Color:         nil,
+		TextSize:      0,
 		TextAlignment: CenterAlign,
 		WordWrap:      false,
 	}
@@ -17,7 +17,7 @@ func drawLabel(ctx *common.DrawCtx, l Label) {
 	fontName := l.Font()
 	fontSize := l.TextSize()
 	text := l.Text()
-	color := l.Color()
+	color := fade(ctx, l, l.Color())
 
 	display := linux.GetDisplay(ctx.Hwnd)
 	if display == nil {
@@ -43,7 +43,7 @@ func drawLabel(ctx *common.DrawCtx, l Label) {
 		}()
 	}
 
-	wdws.SetTextColor(ctx.Hdc, l.Color())
+	wdws.SetTextColor(ctx.Hdc, fade(ctx, l, l.Color()))
 	wdws.SetBkMode(ctx.Hdc, wdws.BK_TRANSPARENT)
 
 	var alignment uint32
@@ -0,0 +1,337 @@
+package component
+
+import (
+	"slices"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// LayoutAlignment describes how a child is placed inside the space a layout gives it.
+type LayoutAlignment int
+
+const (
+	LayoutAlignFill LayoutAlignment = iota
+	LayoutAlignStart
+	LayoutAlignCenter
+	LayoutAlignEnd
+)
+
+// Orientation describes the main axis of a box layout.
+type Orientation int
+
+const (
+	OrientationVertical Orientation = iota
+	OrientationHorizontal
+)
+
+// layoutParams holds the per-child settings a layout uses when positioning a child.
+type layoutParams struct {
+	Stretch int32
+	HAlign  LayoutAlignment
+	VAlign  LayoutAlignment
+	Row     int32
+	Col     int32
+	RowSpan int32
+	ColSpan int32
+}
+
+func newLayoutParams() layoutParams {
+	return layoutParams{
+		Stretch: 0,
+		HAlign:  LayoutAlignFill,
+		VAlign:  LayoutAlignFill,
+		Row:     0,
+		Col:     0,
+		RowSpan: 1,
+		ColSpan: 1,
+	}
+}
+
+type Container interface {
+	Component
+
+	// Children returns the components managed by the container in drawing order.
+	//
+	// Returns:
+	//  - []Component: The child components of the container.
+	Children() []Component
+
+	// RemoveChild removes the first child with the given ID from the container.
+	//
+	// Parameters:
+	//  - id: The ID of the child to remove.
+	RemoveChild(id uintptr)
+
+	// InvalidateLayout signals that a child's content changed and the layout needs to be re-computed.
+	// The request is forwarded to the outermost container so the whole tree is re-measured.
+	InvalidateLayout()
+}
+
+type Layout interface {
+	Container
+
+	// Spacing returns the space between adjacent children, the spacing of the theme until it was set explicitly.
+	//
+	// Returns:
+	//  - int32: The spacing between children in pixels.
+	Spacing() int32
+
+	// SetSpacing sets the space between adjacent children.
+	//
+	// Parameters:
+	//  - spacing: The spacing between children in pixels.
+	SetSpacing(spacing int32)
+
+	// Padding returns the space between the layout's edges and its children.
+	//
+	// Returns:
+	//  - common.Insets: The padding of the layout.
+	Padding() common.Insets
+
+	// SetPadding sets the space between the layout's edges and its children.
+	//
+	// Parameters:
+	//  - padding: The padding to set for the layout.
+	SetPadding(padding common.Insets)
+
+	// Relayout computes and applies the positions and sizes of all children.
+	Relayout()
+}
+
+// baseLayout holds the state shared by every layout manager.
+// The concrete layout is stored in self so the shared methods can trigger its Relayout.
+type baseLayout struct {
+	baseComponent
+	self       Layout
+	children   []Component
+	params     []layoutParams
+	spacing    *int32
+	padding    common.Insets
+	alignment  LayoutAlignment
+	fitContent bool
+}
+
+func newBaseLayout(opts *createLayoutOptions) baseLayout {
+	cOpts := newCreateComponentOptions()
+	for _, opt := range opts.ComponentOptions {
+		opt(cOpts)
+	}
+
+	return baseLayout{
+		baseComponent: baseComponent{
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(false),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
+			size: struct {
+				Width  int32
+				Height int32
+			}{
+				Width:  cOpts.Size.Width,
+				Height: cOpts.Size.Height,
+			},
+			position: struct {
+				X int32
+				Y int32
+			}{
+				X: cOpts.Position.X,
+				Y: cOpts.Position.Y,
+			},
+		},
+		spacing:    opts.Spacing,
+		padding:    opts.Padding,
+		alignment:  opts.Alignment,
+		fitContent: opts.FitContent,
+	}
+}
+
+// addChild appends the child with its layout parameters and re-runs the layout.
+func (l *baseLayout) addChild(c Component, params layoutParams) {
+	if old := c.Parent(); old != nil {
+		old.RemoveChild(c.ID())
+	}
+	l.children = append(l.children, c)
+	l.params = append(l.params, params)
+	c.SetParent(l.self)
+	l.InvalidateLayout()
+}
+
+// visibleChildren returns the visible children alongside their layout parameters.
+func (l *baseLayout) visibleChildren() ([]Component, []layoutParams) {
+	children := make([]Component, 0, len(l.children))
+	params := make([]layoutParams, 0, len(l.params))
+	for i, c := range l.children {
+		if c.Visible() {
+			children = append(children, c)
+			params = append(params, l.params[i])
+		}
+	}
+	return children, params
+}
+
+// innerRect returns the area available to children after the padding is applied.
+func (l *baseLayout) innerRect() common.Rect {
+	return common.Rect{
+		X: l.position.X + l.padding.Left,
+		Y: l.position.Y + l.padding.Top,
+		W: max(0, l.size.Width-l.padding.Left-l.padding.Right),
+		H: max(0, l.size.Height-l.padding.Top-l.padding.Bottom),
+	}
+}
+
+// fitToContent resizes a top-level layout to its preferred size when fitContent is enabled.
+func (l *baseLayout) fitToContent() {
+	if l.fitContent && l.parent == nil {
+		l.size.Width, l.size.Height = l.constrain(l.self.PreferredSize())
+	}
+}
+
+func (l *baseLayout) Draw(ctx *common.DrawCtx) {
+	drawComponent(l.self, ctx)
+}
+
+func (l *baseLayout) Children() []Component {
+	return slices.Clone(l.children)
+}
+
+func (l *baseLayout) RemoveChild(id uintptr) {
+	for i, c := range l.children {
+		if c.ID() == id {
+			c.SetParent(nil)
+			l.children = slices.Delete(l.children, i, i+1)
+			l.params = slices.Delete(l.params, i, i+1)
+			l.InvalidateLayout()
+			return
+		}
+	}
+}
+
+func (l *baseLayout) InvalidateLayout() {
+	if l.parent != nil {
+		l.parent.InvalidateLayout()
+		return
+	}
+	l.self.Relayout()
+}
+
+func (l *baseLayout) SetSize(width, height int32) {
+	l.baseComponent.SetSize(width, height)
+	l.self.Relayout()
+}
+
+func (l *baseLayout) SetPosition(x, y int32) {
+	l.baseComponent.SetPosition(x, y)
+	l.self.Relayout()
+}
+
+func (l *baseLayout) Spacing() int32 {
+	if l.spacing != nil {
+		return *l.spacing
+	}
+	return l.Theme().Spacing
+}
+
+func (l *baseLayout) SetSpacing(spacing int32) {
+	l.spacing = &spacing
+	l.InvalidateLayout()
+}
+
+func (l *baseLayout) Padding() common.Insets {
+	return l.padding
+}
+
+func (l *baseLayout) SetPadding(padding common.Insets) {
+	l.padding = padding
+	l.InvalidateLayout()
+}
+
+// alignSpan places a child of the preferred size inside the available span.
+//
+// Parameters:
+//   - align: The alignment of the child within the span.
+//   - pref: The preferred size of the child along the axis.
+//   - avail: The size of the span along the axis.
+//
+// Returns:
+//   - int32: The size the child should occupy.
+//   - int32: The offset of the child from the start of the span.
+func alignSpan(align LayoutAlignment, pref, avail int32) (int32, int32) {
+	size := min(pref, avail)
+	switch align {
+	case LayoutAlignStart:
+		return size, 0
+	case LayoutAlignCenter:
+		return size, (avail - size) / 2
+	case LayoutAlignEnd:
+		return size, avail - size
+	default:
+		return avail, 0
+	}
+}
+
+// distribute grows or shrinks the sizes so they fill the available space.
+// Extra space is shared according to the stretch factors, while missing space is taken proportionally to each size.
+// Whatever rounding leaves over goes to, or is taken from, the last sizes, so the sizes add up to the available space
+// unless there's no stretchable size or the space is too small for anything.
+//
+// Parameters:
+//   - sizes: The preferred sizes, modified in place.
+//   - stretch: The stretch factor for each size.
+//   - extra: The difference between the available space and the sum of the sizes.
+//
+// Returns:
+//   - bool: True if the extra space was consumed by stretching, false otherwise.
+func distribute(sizes, stretch []int32, extra int32) bool {
+	if extra > 0 {
+		var total, last int32
+		for i, s := range stretch {
+			if s > 0 {
+				total += s
+				last = int32(i)
+			}
+		}
+		if total == 0 {
+			return false
+		}
+		var given int32
+		for i, s := range stretch {
+			if s > 0 {
+				share := extra * s / total
+				sizes[i] += share
+				given += share
+			}
+		}
+		sizes[last] += extra - given
+		return true
+	}
+	if extra < 0 {
+		var total int32
+		for _, s := range sizes {
+			total += s
+		}
+		if total == 0 {
+			return false
+		}
+		taken := int32(0)
+		for i, s := range sizes {
+			cut := min(s, -extra*s/total)
+			sizes[i] -= cut
+			taken += cut
+		}
+		// the rounded down cuts leave part of the missing space, take it from the last sizes
+		for i := len(sizes) - 1; i >= 0 && taken < -extra; i-- {
+			cut := min(sizes[i], -extra-taken)
+			sizes[i] -= cut
+			taken += cut
+		}
+	}
+	return false
+}
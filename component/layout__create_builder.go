@@ -0,0 +1,136 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+type createLayoutOptions struct {
+	// Spacing is nil while the layout uses the spacing of its theme.
+	Spacing          *int32
+	Padding          common.Insets
+	Alignment        LayoutAlignment
+	FitContent       bool
+	ComponentOptions []CreateComponentOption
+}
+
+type CreateLayoutOption func(*createLayoutOptions)
+
+// LayoutChildOption customizes how a single child is placed by its layout.
+type LayoutChildOption func(*layoutParams)
+
+func newCreateLayoutOptions() *createLayoutOptions {
+	return &createLayoutOptions{
+		Spacing:    nil,
+		Padding:    common.UniformInsets(0),
+		Alignment:  LayoutAlignStart,
+		FitContent: false,
+	}
+}
+
+// LayoutSpacingOpt sets the space between adjacent children of the layout.
+//
+// Parameters:
+//   - spacing: The spacing between children in pixels.
+//
+// Returns:
+//   - CreateLayoutOption: A function that takes a pointer to createLayoutOptions and sets its Spacing field.
+func LayoutSpacingOpt(spacing int32) CreateLayoutOption {
+	return func(opts *createLayoutOptions) {
+		spacing = max(0, spacing)
+		opts.Spacing = &spacing
+	}
+}
+
+// LayoutPaddingOpt sets the space between the layout's edges and its children.
+//
+// Parameters:
+//   - padding: The padding to set for the layout.
+//
+// Returns:
+//   - CreateLayoutOption: A function that takes a pointer to createLayoutOptions and sets its Padding field.
+func LayoutPaddingOpt(padding common.Insets) CreateLayoutOption {
+	return func(opts *createLayoutOptions) {
+		opts.Padding = padding
+	}
+}
+
+// LayoutAlignmentOpt sets where the children of a box layout are packed along the main axis when no child stretches.
+//
+// Parameters:
+//   - alignment: LayoutAlignStart, LayoutAlignCenter or LayoutAlignEnd.
+//
+// Returns:
+//   - CreateLayoutOption: A function that takes a pointer to createLayoutOptions and sets its Alignment field.
+func LayoutAlignmentOpt(alignment LayoutAlignment) CreateLayoutOption {
+	return func(opts *createLayoutOptions) {
+		opts.Alignment = alignment
+	}
+}
+
+// LayoutFitContentOpt makes a top-level layout resize itself to its preferred size whenever it re-runs.
+//
+// Parameters:
+//   - fit: true to size the layout from its content, false to keep the size it was given.
+//
+// Returns:
+//   - CreateLayoutOption: A function that takes a pointer to createLayoutOptions and sets its FitContent field.
+func LayoutFitContentOpt(fit bool) CreateLayoutOption {
+	return func(opts *createLayoutOptions) {
+		opts.FitContent = fit
+	}
+}
+
+// LayoutComponentOptionsOpt sets the component options of the layout.
+//
+// Parameters:
+//   - options: The component options to set for the layout.
+//
+// Returns:
+//   - CreateLayoutOption: A function that takes a pointer to createLayoutOptions and sets its ComponentOptions field.
+func LayoutComponentOptionsOpt(options ...CreateComponentOption) CreateLayoutOption {
+	return func(opts *createLayoutOptions) {
+		opts.ComponentOptions = options
+	}
+}
+
+// LayoutStretchOpt sets the share of the layout's extra space the child receives along the main axis.
+//
+// Parameters:
+//   - stretch: The stretch factor, 0 keeps the child at its preferred size.
+//
+// Returns:
+//   - LayoutChildOption: A function that sets the child's stretch factor.
+func LayoutStretchOpt(stretch int32) LayoutChildOption {
+	return func(params *layoutParams) {
+		params.Stretch = max(0, stretch)
+	}
+}
+
+// LayoutAlignOpt sets how the child is aligned inside the space the layout gives it.
+//
+// Parameters:
+//   - horizontal: The horizontal alignment of the child.
+//   - vertical: The vertical alignment of the child.
+//
+// Returns:
+//   - LayoutChildOption: A function that sets the child's alignment.
+func LayoutAlignOpt(horizontal, vertical LayoutAlignment) LayoutChildOption {
+	return func(params *layoutParams) {
+		params.HAlign = horizontal
+		params.VAlign = vertical
+	}
+}
+
+// LayoutSpanOpt sets the number of grid rows and columns the child occupies.
+// It is ignored by box layouts.
+//
+// Parameters:
+//   - rowSpan: The number of rows the child spans, at least 1.
+//   - colSpan: The number of columns the child spans, at least 1.
+//
+// Returns:
+//   - LayoutChildOption: A function that sets the child's row and column span.
+func LayoutSpanOpt(rowSpan, colSpan int32) LayoutChildOption {
+	return func(params *layoutParams) {
+		params.RowSpan = max(1, rowSpan)
+		params.ColSpan = max(1, colSpan)
+	}
+}
@@ -0,0 +1,67 @@
+package component
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		sizes   []int32
+		stretch []int32
+		extra   int32
+		want    []int32
+		wantOK  bool
+	}{
+		{name: "no extra", sizes: []int32{10, 20}, stretch: []int32{1, 1}, want: []int32{10, 20}},
+		{name: "even stretch", sizes: []int32{10, 20}, stretch: []int32{1, 1}, extra: 10, want: []int32{15, 25}, wantOK: true},
+		{name: "weighted stretch", sizes: []int32{10, 10}, stretch: []int32{1, 3}, extra: 20, want: []int32{15, 25}, wantOK: true},
+		{name: "remainder to last stretchable", sizes: []int32{0, 0, 0}, stretch: []int32{1, 1, 1}, extra: 10, want: []int32{3, 3, 4}, wantOK: true},
+		{name: "remainder skips unstretched", sizes: []int32{0, 0, 0}, stretch: []int32{1, 1, 0}, extra: 5, want: []int32{2, 3, 0}, wantOK: true},
+		{name: "only stretchable grows", sizes: []int32{10, 10}, stretch: []int32{0, 2}, extra: 7, want: []int32{10, 17}, wantOK: true},
+		{name: "nothing stretches", sizes: []int32{10, 10}, stretch: []int32{0, 0}, extra: 7, want: []int32{10, 10}},
+		{name: "shrink proportionally", sizes: []int32{10, 30}, stretch: []int32{0, 0}, extra: -20, want: []int32{5, 15}},
+		{name: "shrink remainder from last", sizes: []int32{10, 10, 10}, stretch: []int32{1, 1, 1}, extra: -10, want: []int32{7, 7, 6}},
+		{name: "shrink remainder spills over", sizes: []int32{10, 10, 1}, stretch: []int32{0, 0, 0}, extra: -20, want: []int32{1, 0, 0}},
+		{name: "shrink below zero", sizes: []int32{10, 20}, stretch: []int32{1, 1}, extra: -50, want: []int32{0, 0}},
+		{name: "shrink empty sizes", sizes: []int32{0, 0}, stretch: []int32{1, 1}, extra: -5, want: []int32{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizes := slices.Clone(tt.sizes)
+			ok := distribute(sizes, tt.stretch, tt.extra)
+			if ok != tt.wantOK || !slices.Equal(sizes, tt.want) {
+				t.Errorf("distribute(%v, %v, %d) = %v, %v, want %v, %v", tt.sizes, tt.stretch, tt.extra, sizes, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAlignSpan(t *testing.T) {
+	tests := []struct {
+		name       string
+		align      LayoutAlignment
+		pref       int32
+		avail      int32
+		wantSize   int32
+		wantOffset int32
+	}{
+		{name: "fill", align: LayoutAlignFill, pref: 10, avail: 30, wantSize: 30},
+		{name: "start", align: LayoutAlignStart, pref: 10, avail: 30, wantSize: 10},
+		{name: "center", align: LayoutAlignCenter, pref: 10, avail: 30, wantSize: 10, wantOffset: 10},
+		{name: "center rounds down", align: LayoutAlignCenter, pref: 10, avail: 21, wantSize: 10, wantOffset: 5},
+		{name: "end", align: LayoutAlignEnd, pref: 10, avail: 30, wantSize: 10, wantOffset: 20},
+		{name: "start clamps", align: LayoutAlignStart, pref: 40, avail: 30, wantSize: 30},
+		{name: "center clamps", align: LayoutAlignCenter, pref: 40, avail: 30, wantSize: 30},
+		{name: "end clamps", align: LayoutAlignEnd, pref: 40, avail: 30, wantSize: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, offset := alignSpan(tt.align, tt.pref, tt.avail)
+			if size != tt.wantSize || offset != tt.wantOffset {
+				t.Errorf("alignSpan(%v, %d, %d) = %d, %d, want %d, %d", tt.align, tt.pref, tt.avail, size, offset, tt.wantSize, tt.wantOffset)
+			}
+		})
+	}
+}
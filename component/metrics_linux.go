@@ -0,0 +1,21 @@
+//go:build linux
+// +build linux
+
+package component
+
+import "github.com/Carmen-Shannon/gooey/internal/linux"
+
+// measureText measures the rendered size of the text on the linux platform.
+//
+// Parameters:
+//   - font: The name of the font used to render the text.
+//   - size: The size of the font.
+//   - text: The text to measure.
+//
+// Returns:
+//   - int32: The width of the text in pixels.
+//   - int32: The height of the text in pixels.
+func measureText(font string, size int32, text string) (int32, int32) {
+	w, h := linux.MeasureText(font, int(size), text)
+	return int32(w), int32(h)
+}
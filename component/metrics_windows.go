@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package component
+
+import wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+
+// measureText measures the rendered size of the text on the windows platform.
+//
+// Parameters:
+//   - font: The name of the font used to render the text.
+//   - size: The size of the font.
+//   - text: The text to measure.
+//
+// Returns:
+//   - int32: The width of the text in pixels.
+//   - int32: The height of the text in pixels.
+func measureText(font string, size int32, text string) (int32, int32) {
+	return wdws.MeasureString(font, size, text)
+}
@@ -0,0 +1,47 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+// effectiveOpacity returns the opacity a component is drawn with, its own opacity multiplied by that of its parents.
+//
+// Parameters:
+//   - c: The component to draw.
+//
+// Returns:
+//   - float32: The opacity between 0 and 1.
+func effectiveOpacity(c Component) float32 {
+	opacity := c.Opacity()
+	for p := c.Parent(); p != nil && opacity > 0; p = p.Parent() {
+		opacity *= p.Opacity()
+	}
+	return opacity
+}
+
+// fade blends a color of a component with the window's background according to the component's opacity.
+// Opaque components get the color back unchanged.
+//
+// Parameters:
+//   - ctx: The drawing context of the window.
+//   - c: The component the color belongs to.
+//   - color: The color to blend, nil is passed through.
+//
+// Returns:
+//   - *common.Color: The color to draw with.
+func fade(ctx *common.DrawCtx, c Component, color *common.Color) *common.Color {
+	opacity := effectiveOpacity(c)
+	if color == nil || opacity >= 1 {
+		return color
+	}
+	bg := windowBackground(ctx.Hwnd)
+	if bg == nil {
+		return color
+	}
+	mix := func(fg, bg uint8) uint8 {
+		return uint8(float32(fg)*opacity + float32(bg)*(1-opacity) + 0.5)
+	}
+	return &common.Color{
+		Red:   mix(color.Red, bg.Red),
+		Green: mix(color.Green, bg.Green),
+		Blue:  mix(color.Blue, bg.Blue),
+	}
+}
@@ -0,0 +1,28 @@
+package component
+
+// Register hands what the backend tracks for the component and its descendants to the backend again, such as the
+// bounds and events of buttons and the state of text inputs. Components register when they are created, windows call
+// Register when a component is added, as removing a component from a window drops its registration.
+//
+// Parameters:
+//   - c: The component to register.
+func Register(c Component) {
+	Walk(c, func(c Component) bool {
+		if r, ok := c.(interface{ register() }); ok {
+			r.register()
+		}
+		return true
+	})
+}
+
+// Unregister makes the backend forget the component and its descendants, so they can no longer be clicked or focused
+// where they were last drawn. Windows call it for removed components, Register hands the component back.
+//
+// Parameters:
+//   - c: The component to unregister.
+func Unregister(c Component) {
+	Walk(c, func(c Component) bool {
+		unregisterComponent(c.ID())
+		return true
+	})
+}
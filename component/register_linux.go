@@ -0,0 +1,14 @@
+//go:build linux
+// +build linux
+
+package component
+
+import "github.com/Carmen-Shannon/gooey/internal/linux"
+
+// unregisterComponent drops what the backend tracks for a component removed from its window.
+//
+// Parameters:
+//   - id: The ID of the removed component.
+func unregisterComponent(id uintptr) {
+	linux.UnregisterComponent(id)
+}
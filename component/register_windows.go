@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package component
+
+import wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+
+// unregisterComponent drops what the backend tracks for a component removed from its window.
+//
+// Parameters:
+//   - id: The ID of the removed component.
+func unregisterComponent(id uintptr) {
+	wdws.UnregisterComponent(id)
+}
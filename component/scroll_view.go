@@ -0,0 +1,605 @@
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/theme"
+)
+
+// ScrollbarPolicy describes when a scroll view shows one of its scrollbars.
+type ScrollbarPolicy int
+
+const (
+	ScrollbarAuto ScrollbarPolicy = iota
+	ScrollbarAlways
+	ScrollbarNever
+)
+
+// minThumbLength keeps the scrollbar thumbs grabbable when the content is much larger than the viewport.
+const minThumbLength = 16
+
+type scrollView struct {
+	baseComponent
+	content          Component
+	offset           struct{ X, Y int32 }
+	contentSize      struct{ Width, Height int32 }
+	hPolicy          ScrollbarPolicy
+	vPolicy          ScrollbarPolicy
+	hBar             bool
+	vBar             bool
+	scrollbarSize    int32
+	scrollStep       int32
+	backgroundColor  *common.Color
+	scrollbarColor   *common.Color
+	thumbColor       *common.Color
+	onScroll         func(x, y int32)
+	dragOrientation  Orientation
+	dragging         bool
+	dragStart        int32
+	dragStartOffset  int32
+	state            *common.ScrollViewState
+	relayoutInFlight bool
+}
+
+// NewScrollView creates a new ScrollView component with the specified options.
+// It accepts a variadic list of CreateScrollViewOption functions to customize the scroll view's properties.
+//
+// Parameters:
+//   - options: A variadic list of CreateScrollViewOption functions to customize the scroll view's properties.
+//
+// Returns:
+//   - ScrollView: The newly created scroll view.
+func NewScrollView(options ...CreateScrollViewOption) ScrollView {
+	opts := newCreateScrollViewOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+	cOpts := newCreateComponentOptions()
+	for _, opt := range opts.ComponentOptions {
+		opt(cOpts)
+	}
+
+	s := &scrollView{
+		baseComponent: baseComponent{
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(false),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
+			size: struct {
+				Width  int32
+				Height int32
+			}{
+				Width:  cOpts.Size.Width,
+				Height: cOpts.Size.Height,
+			},
+			position: struct {
+				X int32
+				Y int32
+			}{
+				X: cOpts.Position.X,
+				Y: cOpts.Position.Y,
+			},
+		},
+		hPolicy:         opts.HorizontalPolicy,
+		vPolicy:         opts.VerticalPolicy,
+		scrollbarSize:   opts.ScrollbarSize,
+		scrollStep:      opts.ScrollStep,
+		backgroundColor: opts.BackgroundColor,
+		scrollbarColor:  opts.ScrollbarColor,
+		thumbColor:      opts.ThumbColor,
+		onScroll:        opts.OnScroll,
+		state: &common.ScrollViewState{
+			ID: cOpts.ID,
+		},
+	}
+
+	// the scrolling math lives here, the window delivers the mouse input and the backend the scrolling keys
+	s.state.Events = s.events
+	event.Subscribe(s.events, func(e event.WheelEvent) {
+		s.handleWheel(e.DeltaX, e.DeltaY)
+	})
+	event.Subscribe(s.events, func(e event.MouseDownEvent) {
+		if e.Button == event.MouseButtonLeft {
+			s.handlePress(e.WindowX, e.WindowY)
+		}
+	})
+	event.Subscribe(s.events, func(e event.MouseMoveEvent) {
+		s.handleDrag(e.WindowX, e.WindowY)
+	})
+	event.Subscribe(s.events, func(e event.MouseUpEvent) {
+		if e.Button == event.MouseButtonLeft {
+			s.dragging = false
+		}
+	})
+	event.Subscribe(s.events, func(e event.KeyDownEvent) {
+		if s.enabled {
+			s.handleKey(e.Key)
+		}
+	})
+	event.Subscribe(s.events, func(e event.ScrollEvent) {
+		if s.onScroll != nil {
+			s.onScroll(e.X, e.Y)
+		}
+	})
+
+	if opts.Content != nil {
+		s.SetContent(opts.Content)
+	} else {
+		s.relayout()
+	}
+	registerScrollView(s)
+	return s
+}
+
+// register hands the scroll view's state to the backend, see Register.
+func (s *scrollView) register() {
+	registerScrollView(s)
+}
+
+// ScrollView presents a single content component that can be larger than the view and scrolled.
+// The scrollbar and thumb colors come from the scroll view's theme until they are set explicitly.
+type ScrollView interface {
+	Container
+
+	// Content returns the component presented inside the scroll view.
+	//
+	// Returns:
+	//  - Component: The scrolled component, or nil if none is set.
+	Content() Component
+
+	// SetContent sets the component presented inside the scroll view and resets the scroll offset.
+	// Containers such as layouts are stretched to at least the size of the viewport, other components keep their own size.
+	//
+	// Parameters:
+	//  - content: The component to scroll.
+	SetContent(content Component)
+
+	// ScrollOffset returns how far the content is scrolled.
+	//
+	// Returns:
+	//  - int32: The horizontal scroll offset in pixels.
+	//  - int32: The vertical scroll offset in pixels.
+	ScrollOffset() (int32, int32)
+
+	// SetScrollOffset scrolls the content to the given offset, clamped to the scrollable range.
+	//
+	// Parameters:
+	//  - x: The horizontal scroll offset in pixels.
+	//  - y: The vertical scroll offset in pixels.
+	SetScrollOffset(x, y int32)
+
+	// ScrollBy scrolls the content relative to the current offset, clamped to the scrollable range.
+	//
+	// Parameters:
+	//  - dx: The horizontal distance to scroll in pixels.
+	//  - dy: The vertical distance to scroll in pixels.
+	ScrollBy(dx, dy int32)
+
+	// MaxScrollOffset returns the largest offset the content can be scrolled to.
+	//
+	// Returns:
+	//  - int32: The largest horizontal scroll offset.
+	//  - int32: The largest vertical scroll offset.
+	MaxScrollOffset() (int32, int32)
+
+	// ContentSize returns the size of the scrolled content.
+	//
+	// Returns:
+	//  - int32: The width of the content.
+	//  - int32: The height of the content.
+	ContentSize() (int32, int32)
+
+	// Viewport returns the area of the window the content is visible in, excluding the scrollbars.
+	//
+	// Returns:
+	//  - common.Rect: The viewport of the scroll view.
+	Viewport() common.Rect
+
+	// ScrollbarPolicy returns when the horizontal and vertical scrollbars are shown.
+	//
+	// Returns:
+	//  - ScrollbarPolicy: The policy of the horizontal scrollbar.
+	//  - ScrollbarPolicy: The policy of the vertical scrollbar.
+	ScrollbarPolicy() (ScrollbarPolicy, ScrollbarPolicy)
+
+	// SetScrollbarPolicy sets when the horizontal and vertical scrollbars are shown.
+	//
+	// Parameters:
+	//  - horizontal: The policy of the horizontal scrollbar.
+	//  - vertical: The policy of the vertical scrollbar.
+	SetScrollbarPolicy(horizontal, vertical ScrollbarPolicy)
+
+	// BackgroundColor returns the color painted behind the content.
+	//
+	// Returns:
+	//  - *common.Color: The background color, or nil if the viewport is transparent.
+	BackgroundColor() *common.Color
+
+	// SetBackgroundColor sets the color painted behind the content.
+	//
+	// Parameters:
+	//  - color: The background color, or nil to leave the viewport transparent.
+	SetBackgroundColor(color *common.Color)
+
+	// ScrollbarColor returns the color of the scrollbar tracks.
+	//
+	// Returns:
+	//  - *common.Color: The color of the scrollbar tracks.
+	ScrollbarColor() *common.Color
+
+	// SetScrollbarColor sets the color of the scrollbar tracks.
+	//
+	// Parameters:
+	//  - color: The color of the scrollbar tracks.
+	SetScrollbarColor(color *common.Color)
+
+	// ThumbColor returns the color of the scrollbar thumbs.
+	//
+	// Returns:
+	//  - *common.Color: The color of the scrollbar thumbs.
+	ThumbColor() *common.Color
+
+	// SetThumbColor sets the color of the scrollbar thumbs.
+	//
+	// Parameters:
+	//  - color: The color of the scrollbar thumbs.
+	SetThumbColor(color *common.Color)
+
+	// OnScroll returns the function called whenever the scroll offset changes.
+	//
+	// Returns:
+	//  - func(x, y int32): The function called with the new offset.
+	OnScroll() func(x, y int32)
+
+	// SetOnScroll sets the function called whenever the scroll offset changes.
+	//
+	// Parameters:
+	//  - onScroll: The function to call with the new offset.
+	SetOnScroll(onScroll func(x, y int32))
+}
+
+var _ ScrollView = (*scrollView)(nil)
+
+func (s *scrollView) Draw(ctx *common.DrawCtx) {
+	drawComponent(s, ctx)
+}
+
+func (s *scrollView) Children() []Component {
+	if s.content == nil {
+		return nil
+	}
+	return []Component{s.content}
+}
+
+func (s *scrollView) RemoveChild(id uintptr) {
+	if s.content != nil && s.content.ID() == id {
+		s.content.SetParent(nil)
+		s.content = nil
+		s.offset.X, s.offset.Y = 0, 0
+		s.relayout()
+	}
+}
+
+// InvalidateLayout re-measures the content. The scroll view keeps its own size when the content changes,
+// so the request stops here instead of being forwarded to the parent.
+func (s *scrollView) InvalidateLayout() {
+	s.relayout()
+}
+
+func (s *scrollView) SetSize(width, height int32) {
+	s.baseComponent.SetSize(width, height)
+	s.relayout()
+}
+
+func (s *scrollView) SetPosition(x, y int32) {
+	s.baseComponent.SetPosition(x, y)
+	s.relayout()
+}
+
+func (s *scrollView) SetVisible(visible bool) {
+	s.baseComponent.SetVisible(visible)
+	s.relayout()
+}
+
+func (s *scrollView) Content() Component {
+	return s.content
+}
+
+func (s *scrollView) SetContent(content Component) {
+	if s.content != nil {
+		s.content.SetParent(nil)
+	}
+	if content != nil {
+		if old := content.Parent(); old != nil {
+			old.RemoveChild(content.ID())
+		}
+		content.SetParent(s)
+	}
+	s.content = content
+	s.offset.X, s.offset.Y = 0, 0
+	s.relayout()
+}
+
+func (s *scrollView) ScrollOffset() (int32, int32) {
+	return s.offset.X, s.offset.Y
+}
+
+func (s *scrollView) SetScrollOffset(x, y int32) {
+	maxX, maxY := s.MaxScrollOffset()
+	x = max(0, min(x, maxX))
+	y = max(0, min(y, maxY))
+	if x == s.offset.X && y == s.offset.Y {
+		return
+	}
+	s.offset.X, s.offset.Y = x, y
+	s.positionContent()
+	event.Publish(s.events, event.ScrollEvent{Source: s.id, X: x, Y: y})
+}
+
+func (s *scrollView) ScrollBy(dx, dy int32) {
+	s.SetScrollOffset(s.offset.X+dx, s.offset.Y+dy)
+}
+
+func (s *scrollView) MaxScrollOffset() (int32, int32) {
+	vp := s.Viewport()
+	return max(0, s.contentSize.Width-vp.W), max(0, s.contentSize.Height-vp.H)
+}
+
+func (s *scrollView) ContentSize() (int32, int32) {
+	return s.contentSize.Width, s.contentSize.Height
+}
+
+func (s *scrollView) Viewport() common.Rect {
+	vp := common.Rect{X: s.position.X, Y: s.position.Y, W: s.size.Width, H: s.size.Height}
+	if s.vBar {
+		vp.W = max(0, vp.W-s.scrollbarSize)
+	}
+	if s.hBar {
+		vp.H = max(0, vp.H-s.scrollbarSize)
+	}
+	return vp
+}
+
+func (s *scrollView) ScrollbarPolicy() (ScrollbarPolicy, ScrollbarPolicy) {
+	return s.hPolicy, s.vPolicy
+}
+
+func (s *scrollView) SetScrollbarPolicy(horizontal, vertical ScrollbarPolicy) {
+	s.hPolicy = horizontal
+	s.vPolicy = vertical
+	s.relayout()
+}
+
+func (s *scrollView) BackgroundColor() *common.Color {
+	return s.backgroundColor
+}
+
+func (s *scrollView) SetBackgroundColor(color *common.Color) {
+	s.backgroundColor = color
+}
+
+func (s *scrollView) ScrollbarColor() *common.Color {
+	if s.scrollbarColor != nil {
+		return s.scrollbarColor
+	}
+	return s.Theme().ScrollView.Resolve(theme.StateNormal).Background
+}
+
+func (s *scrollView) SetScrollbarColor(color *common.Color) {
+	s.scrollbarColor = color
+}
+
+func (s *scrollView) ThumbColor() *common.Color {
+	if s.thumbColor != nil {
+		return s.thumbColor
+	}
+	return s.Theme().ScrollView.Resolve(theme.StateNormal).Foreground
+}
+
+func (s *scrollView) SetThumbColor(color *common.Color) {
+	s.thumbColor = color
+}
+
+func (s *scrollView) OnScroll() func(x, y int32) {
+	return s.onScroll
+}
+
+func (s *scrollView) SetOnScroll(onScroll func(x, y int32)) {
+	s.onScroll = onScroll
+}
+
+// relayout decides which scrollbars are needed, measures the content and moves it to the current offset.
+// Showing one scrollbar shrinks the viewport and may require the other, so the measurement runs twice.
+func (s *scrollView) relayout() {
+	if s.relayoutInFlight {
+		return
+	}
+	s.relayoutInFlight = true
+	defer func() { s.relayoutInFlight = false }()
+
+	s.hBar = s.hPolicy == ScrollbarAlways
+	s.vBar = s.vPolicy == ScrollbarAlways
+	for range 2 {
+		vp := s.Viewport()
+		w, h := s.measureContent(vp.W, vp.H)
+		s.hBar = s.hPolicy == ScrollbarAlways || (s.hPolicy == ScrollbarAuto && w > vp.W)
+		s.vBar = s.vPolicy == ScrollbarAlways || (s.vPolicy == ScrollbarAuto && h > vp.H)
+	}
+	vp := s.Viewport()
+	s.contentSize.Width, s.contentSize.Height = s.measureContent(vp.W, vp.H)
+
+	maxX, maxY := s.MaxScrollOffset()
+	s.offset.X = max(0, min(s.offset.X, maxX))
+	s.offset.Y = max(0, min(s.offset.Y, maxY))
+
+	s.state.ID = s.id
+	s.state.Bounds = common.Rect{X: s.position.X, Y: s.position.Y, W: s.size.Width, H: s.size.Height}
+	if !s.visible {
+		s.state.Bounds = common.Rect{}
+	}
+	s.state.Clip = clipOf(s)
+
+	if s.content != nil {
+		if _, ok := s.content.(Container); ok {
+			s.content.SetSize(s.contentSize.Width, s.contentSize.Height)
+		}
+	}
+	s.positionContent()
+}
+
+// measureContent returns the size of the content for the given viewport.
+// Containers fill the viewport along axes that do not scroll and grow to their preferred size along the others.
+func (s *scrollView) measureContent(viewW, viewH int32) (int32, int32) {
+	if s.content == nil {
+		return 0, 0
+	}
+	if _, ok := s.content.(Container); !ok {
+		return s.content.Size()
+	}
+	w, h := preferredSize(s.content)
+	if s.hPolicy == ScrollbarNever {
+		w = viewW
+	} else {
+		w = max(w, viewW)
+	}
+	if s.vPolicy == ScrollbarNever {
+		h = viewH
+	} else {
+		h = max(h, viewH)
+	}
+	return w, h
+}
+
+// positionContent moves the content so the part at the scroll offset sits at the top-left of the viewport.
+func (s *scrollView) positionContent() {
+	if s.content == nil {
+		return
+	}
+	vp := s.Viewport()
+	s.content.SetPosition(vp.X-s.offset.X, vp.Y-s.offset.Y)
+}
+
+// scrollbarRects returns the track and thumb rectangles of the vertical and horizontal scrollbars.
+// Rectangles of hidden scrollbars are empty.
+func (s *scrollView) scrollbarRects() (vTrack, vThumb, hTrack, hThumb common.Rect) {
+	vp := s.Viewport()
+	if s.vBar {
+		vTrack = common.Rect{X: vp.X + vp.W, Y: vp.Y, W: s.scrollbarSize, H: vp.H}
+		pos, length := thumbSpan(vTrack.H, vp.H, s.contentSize.Height, s.offset.Y)
+		vThumb = common.Rect{X: vTrack.X + 2, Y: vTrack.Y + pos, W: max(0, vTrack.W-4), H: length}
+	}
+	if s.hBar {
+		hTrack = common.Rect{X: vp.X, Y: vp.Y + vp.H, W: vp.W, H: s.scrollbarSize}
+		pos, length := thumbSpan(hTrack.W, vp.W, s.contentSize.Width, s.offset.X)
+		hThumb = common.Rect{X: hTrack.X + pos, Y: hTrack.Y + 2, W: length, H: max(0, hTrack.H-4)}
+	}
+	return vTrack, vThumb, hTrack, hThumb
+}
+
+// thumbSpan computes the offset and length of a scrollbar thumb along its track.
+//
+// Parameters:
+//   - track: The length of the scrollbar track.
+//   - view: The length of the viewport along the same axis.
+//   - content: The length of the content along the same axis.
+//   - offset: The current scroll offset along the same axis.
+//
+// Returns:
+//   - int32: The offset of the thumb from the start of the track.
+//   - int32: The length of the thumb.
+func thumbSpan(track, view, content, offset int32) (int32, int32) {
+	if content <= view || content <= 0 {
+		return 0, track
+	}
+	length := min(track, max(minThumbLength, int32(int64(track)*int64(view)/int64(content))))
+	travel := track - length
+	if travel <= 0 {
+		return 0, length
+	}
+	return int32(int64(travel) * int64(offset) / int64(content-view)), length
+}
+
+// handleWheel scrolls by three steps per wheel notch.
+// A vertical wheel scrolls horizontally when the content can only scroll sideways.
+func (s *scrollView) handleWheel(dx, dy int32) {
+	if !s.vBar && s.hBar && dx == 0 {
+		dx, dy = dy, 0
+	}
+	step := s.scrollStep * 3
+	s.ScrollBy(dx*step/event.WheelDelta, dy*step/event.WheelDelta)
+}
+
+// handlePress starts dragging a thumb, or pages towards the pointer when the track outside the thumb is pressed.
+func (s *scrollView) handlePress(x, y int32) {
+	vTrack, vThumb, hTrack, hThumb := s.scrollbarRects()
+	vp := s.Viewport()
+	switch {
+	case vThumb.Contains(x, y):
+		s.dragging, s.dragOrientation, s.dragStart, s.dragStartOffset = true, OrientationVertical, y, s.offset.Y
+	case hThumb.Contains(x, y):
+		s.dragging, s.dragOrientation, s.dragStart, s.dragStartOffset = true, OrientationHorizontal, x, s.offset.X
+	case vTrack.Contains(x, y):
+		if y < vThumb.Y {
+			s.ScrollBy(0, -vp.H)
+		} else {
+			s.ScrollBy(0, vp.H)
+		}
+	case hTrack.Contains(x, y):
+		if x < hThumb.X {
+			s.ScrollBy(-vp.W, 0)
+		} else {
+			s.ScrollBy(vp.W, 0)
+		}
+	}
+}
+
+// handleDrag converts the pointer travel since the press into a scroll offset.
+func (s *scrollView) handleDrag(x, y int32) {
+	if !s.dragging {
+		return
+	}
+	vTrack, vThumb, hTrack, hThumb := s.scrollbarRects()
+	maxX, maxY := s.MaxScrollOffset()
+	if s.dragOrientation == OrientationVertical {
+		travel := vTrack.H - vThumb.H
+		if travel > 0 {
+			s.SetScrollOffset(s.offset.X, s.dragStartOffset+int32(int64(y-s.dragStart)*int64(maxY)/int64(travel)))
+		}
+		return
+	}
+	travel := hTrack.W - hThumb.W
+	if travel > 0 {
+		s.SetScrollOffset(s.dragStartOffset+int32(int64(x-s.dragStart)*int64(maxX)/int64(travel)), s.offset.Y)
+	}
+}
+
+// handleKey scrolls by a step for the arrow keys, by a viewport for the paging keys and to either end for Home and End.
+func (s *scrollView) handleKey(key event.Key) {
+	vp := s.Viewport()
+	_, maxY := s.MaxScrollOffset()
+	switch key {
+	case event.KeyUp:
+		s.ScrollBy(0, -s.scrollStep)
+	case event.KeyDown:
+		s.ScrollBy(0, s.scrollStep)
+	case event.KeyLeft:
+		s.ScrollBy(-s.scrollStep, 0)
+	case event.KeyRight:
+		s.ScrollBy(s.scrollStep, 0)
+	case event.KeyPageUp:
+		s.ScrollBy(0, -vp.H)
+	case event.KeyPageDown:
+		s.ScrollBy(0, vp.H)
+	case event.KeyHome:
+		s.SetScrollOffset(s.offset.X, 0)
+	case event.KeyEnd:
+		s.SetScrollOffset(s.offset.X, maxY)
+	}
+}
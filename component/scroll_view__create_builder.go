@@ -0,0 +1,159 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+type createScrollViewOptions struct {
+	Content          Component
+	HorizontalPolicy ScrollbarPolicy
+	VerticalPolicy   ScrollbarPolicy
+	ScrollbarSize    int32
+	ScrollStep       int32
+	BackgroundColor  *common.Color
+	ScrollbarColor   *common.Color
+	ThumbColor       *common.Color
+	OnScroll         func(x, y int32)
+	ComponentOptions []CreateComponentOption
+}
+
+type CreateScrollViewOption func(*createScrollViewOptions)
+
+func newCreateScrollViewOptions() *createScrollViewOptions {
+	return &createScrollViewOptions{
+		HorizontalPolicy: ScrollbarAuto,
+		VerticalPolicy:   ScrollbarAuto,
+		ScrollbarSize:    12,
+		ScrollStep:       16,
+		BackgroundColor:  nil,
+		ScrollbarColor:   nil,
+		ThumbColor:       nil,
+	}
+}
+
+// ScrollViewContentOpt sets the component presented inside the scroll view.
+// It takes a Component and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - content: The component to scroll, usually a layout holding the actual children.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewContentOpt(content Component) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.Content = content
+	}
+}
+
+// ScrollViewScrollbarPolicyOpt sets when the horizontal and vertical scrollbars are shown.
+// It takes two ScrollbarPolicy values and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - horizontal: The policy of the horizontal scrollbar, defaults to ScrollbarAuto.
+//   - vertical: The policy of the vertical scrollbar, defaults to ScrollbarAuto.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewScrollbarPolicyOpt(horizontal, vertical ScrollbarPolicy) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.HorizontalPolicy = horizontal
+		opts.VerticalPolicy = vertical
+	}
+}
+
+// ScrollViewScrollbarSizeOpt sets the thickness of the scrollbars.
+// It takes an int32 and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - size: The thickness of the scrollbars in pixels, defaults to 12.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewScrollbarSizeOpt(size int32) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.ScrollbarSize = size
+	}
+}
+
+// ScrollViewScrollStepOpt sets the distance scrolled by a single arrow key press.
+// A notch of the mouse wheel scrolls three steps.
+// It takes an int32 and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - step: The distance in pixels, defaults to 16.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewScrollStepOpt(step int32) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.ScrollStep = step
+	}
+}
+
+// ScrollViewBackgroundColorOpt sets the color painted behind the content of the scroll view.
+// It takes a pointer to a common.Color, nil leaving the viewport transparent, and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - color: The background color of the viewport.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewBackgroundColorOpt(color *common.Color) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.BackgroundColor = color
+	}
+}
+
+// ScrollViewScrollbarColorOpt sets the color of the scrollbar tracks.
+// It takes a pointer to a common.Color and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - color: The color of the scrollbar tracks.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewScrollbarColorOpt(color *common.Color) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.ScrollbarColor = color
+	}
+}
+
+// ScrollViewThumbColorOpt sets the color of the draggable scrollbar thumbs.
+// It takes a pointer to a common.Color and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - color: The color of the scrollbar thumbs.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewThumbColorOpt(color *common.Color) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.ThumbColor = color
+	}
+}
+
+// ScrollViewOnScrollOpt sets the function called whenever the scroll offset changes.
+// It takes a function receiving the new offset and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - onScroll: The function to call with the new horizontal and vertical offset.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewOnScrollOpt(onScroll func(x, y int32)) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.OnScroll = onScroll
+	}
+}
+
+// ScrollViewComponentOptionsOpt sets the component options for the scroll view.
+// It takes a variadic number of CreateComponentOption and returns a CreateScrollViewOption function.
+//
+// Parameters:
+//   - options: A variadic number of CreateComponentOption to apply to the scroll view.
+//
+// Returns:
+//   - CreateScrollViewOption: A function that takes a pointer to createScrollViewOptions
+func ScrollViewComponentOptionsOpt(options ...CreateComponentOption) CreateScrollViewOption {
+	return func(opts *createScrollViewOptions) {
+		opts.ComponentOptions = options
+	}
+}
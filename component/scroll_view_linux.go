@@ -0,0 +1,58 @@
+//go:build linux
+// +build linux
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/linux"
+)
+
+// drawScrollView draws the background, the clipped content and the scrollbars of the scroll view.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the scroll view.
+//   - s: The ScrollView component to be drawn.
+func drawScrollView(ctx *common.DrawCtx, s ScrollView) {
+	sv := s.(*scrollView)
+	display := linux.GetDisplay(ctx.Hwnd)
+	if display == nil {
+		return
+	}
+	drawable := linux.C_Drawable(ctx.Hdc)
+	vp := sv.Viewport()
+
+	linux.PushClipRect(drawable, vp.X, vp.Y, vp.W, vp.H)
+	if bg := fade(ctx, s, sv.BackgroundColor()); bg != nil {
+		linux.XFillRect(display, drawable, int(vp.X), int(vp.Y), int(vp.W), int(vp.H), bg)
+	}
+	if content := sv.Content(); content != nil {
+		content.Draw(ctx)
+	}
+	linux.PopClipRect(drawable)
+
+	vTrack, vThumb, hTrack, hThumb := sv.scrollbarRects()
+	for _, r := range []common.Rect{vTrack, hTrack} {
+		if !r.Empty() {
+			linux.XFillRect(display, drawable, int(r.X), int(r.Y), int(r.W), int(r.H), fade(ctx, s, sv.ScrollbarColor()))
+		}
+	}
+	if !vTrack.Empty() && !hTrack.Empty() {
+		linux.XFillRect(display, drawable, int(vTrack.X), int(hTrack.Y), int(vTrack.W), int(hTrack.H), fade(ctx, s, sv.ScrollbarColor()))
+	}
+	for _, r := range []common.Rect{vThumb, hThumb} {
+		if r.Empty() {
+			continue
+		}
+		radius := min(r.W, r.H) / 2
+		linux.XFillRoundedRect(display, drawable, int(r.X), int(r.Y), int(r.W), int(r.H), int(radius), fade(ctx, s, sv.ThumbColor()))
+	}
+}
+
+// registerScrollView registers the scroll view state with the linux package so it receives wheel, pointer and keyboard input.
+//
+// Parameters:
+//   - s: The ScrollView component to be registered.
+func registerScrollView(s ScrollView) {
+	linux.RegisterScrollViewState(s.ID(), s.(*scrollView).state)
+}
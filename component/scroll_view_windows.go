@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+)
+
+// drawScrollView draws the background, the clipped content and the scrollbars of the scroll view.
+// The content is clipped to the viewport by intersecting the clip region of the device context.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the scroll view.
+//   - s: The ScrollView component to be drawn.
+func drawScrollView(ctx *common.DrawCtx, s ScrollView) {
+	sv := s.(*scrollView)
+	vp := sv.Viewport()
+
+	wdws.PushClipRect(ctx.Hdc, vp.X, vp.Y, vp.W, vp.H)
+	if bg := fade(ctx, s, sv.BackgroundColor()); bg != nil {
+		fillRect(ctx.Hdc, vp, bg)
+	}
+	if content := sv.Content(); content != nil {
+		content.Draw(ctx)
+	}
+	wdws.PopClipRect(ctx.Hdc)
+
+	vTrack, vThumb, hTrack, hThumb := sv.scrollbarRects()
+	for _, r := range []common.Rect{vTrack, hTrack} {
+		if !r.Empty() {
+			fillRect(ctx.Hdc, r, fade(ctx, s, sv.ScrollbarColor()))
+		}
+	}
+	if !vTrack.Empty() && !hTrack.Empty() {
+		fillRect(ctx.Hdc, common.Rect{X: vTrack.X, Y: hTrack.Y, W: vTrack.W, H: hTrack.H}, fade(ctx, s, sv.ScrollbarColor()))
+	}
+	for _, r := range []common.Rect{vThumb, hThumb} {
+		if r.Empty() {
+			continue
+		}
+		brush := wdws.CreateSolidBrush(fade(ctx, s, sv.ThumbColor()))
+		pen := wdws.CreatePen(wdws.PS_SOLID, 1, fade(ctx, s, sv.ThumbColor()))
+		oldBrush := wdws.SelectObject(ctx.Hdc, brush)
+		oldPen := wdws.SelectObject(ctx.Hdc, pen)
+		_ = wdws.DrawRectangle(ctx.Hdc, r.X, r.Y, r.X+r.W, r.Y+r.H, min(r.W, r.H))
+		wdws.SelectObject(ctx.Hdc, oldPen)
+		wdws.SelectObject(ctx.Hdc, oldBrush)
+		wdws.DeleteObject(pen)
+		wdws.DeleteObject(brush)
+	}
+}
+
+// fillRect fills the rectangle with a solid color.
+//
+// Parameters:
+//   - hdc: Handle to the device context to draw on.
+//   - r: The rectangle to fill.
+//   - color: The color to fill the rectangle with.
+func fillRect(hdc uintptr, r common.Rect, color *common.Color) {
+	brush := wdws.CreateSolidBrush(color)
+	defer wdws.DeleteObject(brush)
+	wdws.FillRect(hdc, [4]int32{r.X, r.Y, r.X + r.W, r.Y + r.H}, uintptr(brush))
+}
+
+// registerScrollView registers the scroll view state with the windows package so it receives wheel, pointer and keyboard input.
+//
+// Parameters:
+//   - s: The ScrollView component to be registered.
+func registerScrollView(s ScrollView) {
+	wdws.RegisterScrollViewState(s.ID(), s.(*scrollView).state)
+}
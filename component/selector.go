@@ -1,6 +1,9 @@
 package component
 
-import "github.com/Carmen-Shannon/gooey/common"
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
 
 type selector struct {
 	baseComponent
@@ -75,9 +78,17 @@ func NewSelector(options ...CreateSelectorOption) Selector {
 
 	s := &selector{
 		baseComponent: baseComponent{
-			id:      cOpts.ID,
-			visible: cOpts.Visible,
-			enabled: cOpts.Enabled,
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(false),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
 			size: struct {
 				Width  int32
 				Height int32
@@ -97,8 +108,9 @@ func NewSelector(options ...CreateSelectorOption) Selector {
 		opacity: opts.Opacity,
 		drawing: opts.Drawing,
 		state: &common.SelectorState{
-			ID:      0,
-			Drawing: opts.Drawing,
+			ID:          0,
+			ComponentID: cOpts.ID,
+			Drawing:     opts.Drawing,
 			Bounds: common.Rect{
 				X: cOpts.Position.X,
 				Y: cOpts.Position.Y,
@@ -109,41 +121,33 @@ func NewSelector(options ...CreateSelectorOption) Selector {
 			Opacity:  opts.Opacity,
 			Visible:  cOpts.Visible,
 			Blocking: false,
-			CbMap:    make(map[string]func(any)),
 		},
 	}
 
-	cbMap := make(map[string]func(any))
-	cbMap["drawing"] = func(drawing any) {
-		if drawingBool, ok := drawing.(bool); ok {
-			s.drawing = drawingBool
-		}
-	}
-	cbMap["blocking"] = func(blocking any) {
-		if blockingBool, ok := blocking.(bool); ok {
-			s.state.Blocking = blockingBool
-		}
-	}
-	cbMap["bounds"] = func(bounds any) {
-		if boundsRect, ok := bounds.(common.Rect); ok {
-			s.position.X = boundsRect.X
-			s.position.Y = boundsRect.Y
-			s.size.Width = boundsRect.W
-			s.size.Height = boundsRect.H
-		}
-	}
-	cbMap["visible"] = func(visible any) {
-		if visibleBool, ok := visible.(bool); ok {
-			s.visible = visibleBool
-		}
-	}
-	s.state.CbMap = cbMap
+	s.state.Events = s.events
+	event.Subscribe(s.events, func(e event.DrawingChangedEvent) {
+		s.drawing = e.Drawing
+	})
+	event.Subscribe(s.events, func(e event.BoundsChangedEvent) {
+		s.position.X = e.X
+		s.position.Y = e.Y
+		s.size.Width = e.Width
+		s.size.Height = e.Height
+	})
+	event.Subscribe(s.events, func(e event.VisibilityChangedEvent) {
+		s.visible = e.Visible
+	})
 
 	registerSelector(cOpts.ID, s)
 
 	return s
 }
 
+// register hands the selector's state to the backend, see Register.
+func (s *selector) register() {
+	registerSelector(s.ID(), s)
+}
+
 func (s *selector) Draw(ctx *common.DrawCtx) {
 	drawComponent(s, ctx)
 }
@@ -154,7 +158,7 @@ func (s *selector) Color() *common.Color {
 
 func (s *selector) SetColor(color *common.Color) {
 	s.color = color
-	s.state.Color = color
+	updateSelectorState(s, common.UpdateSelectorColor(color))
 }
 
 func (s *selector) Opacity() float32 {
@@ -163,7 +167,7 @@ func (s *selector) Opacity() float32 {
 
 func (s *selector) SetOpacity(opacity float32) {
 	s.opacity = opacity
-	s.state.Opacity = opacity
+	updateSelectorState(s, common.UpdateSelectorOpacity(opacity))
 }
 
 func (s *selector) Drawing() bool {
@@ -172,12 +176,12 @@ func (s *selector) Drawing() bool {
 
 func (s *selector) SetDrawing(drawing bool) {
 	s.drawing = drawing
-	s.state.Drawing = drawing
+	updateSelectorState(s, common.UpdateSelectorDrawing(drawing))
 }
 
 func (s *selector) SetVisible(visible bool) {
 	s.baseComponent.SetVisible(visible)
-	s.state.Visible = visible
+	updateSelectorState(s, common.UpdateSelectorVisible(visible))
 }
 
 func (s *selector) StartCapture() {
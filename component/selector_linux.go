@@ -8,12 +8,16 @@ import (
 	"github.com/Carmen-Shannon/gooey/internal/linux"
 )
 
-func drawSelector(_ *common.DrawCtx, s Selector) {
-	state := s.(*selector).state
+func drawSelector(ctx *common.DrawCtx, s Selector) {
+	// the overlay thread updates the state concurrently, so it is only read through a snapshot
+	state, ok := linux.SelectorStateSnapshot(s.ID())
+	if !ok {
+		return
+	}
 
 	// Launch overlay if needed
 	if state.Visible && !linux.SelectorOverlayActive() {
-		linux.LaunchSelectorOverlayOnThread(s.ID())
+		linux.LaunchSelectorOverlayOnThread(ctx.Hwnd, s.ID())
 	}
 
 	// If selector is visible and drawing, force overlay redraw on every update
@@ -34,3 +38,12 @@ func drawSelector(_ *common.DrawCtx, s Selector) {
 func registerSelector(componentID uintptr, s Selector) {
 	linux.RegisterSelectorState(componentID, s.(*selector).state)
 }
+
+// updateSelectorState applies updates to the selector state under the lock shared with the overlay thread.
+//
+// Parameters:
+//   - s: The Selector component to update.
+//   - updates: A variadic number of update functions to modify the state.
+func updateSelectorState(s Selector, updates ...common.UpdateSelectorState) {
+	linux.UpdateSelectorState(s.ID(), updates...)
+}
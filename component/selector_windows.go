@@ -17,7 +17,11 @@ import (
 //   - ctx: The drawing context for the selector.
 //   - s: The Selector component to be drawn.
 func drawSelector(_ *common.DrawCtx, s Selector) {
-	state := wdws.GetSelectorState(s.ID())
+	// the overlay thread updates the state concurrently, so it is only read through a snapshot
+	state, ok := wdws.SelectorStateSnapshot(s.ID())
+	if !ok {
+		return
+	}
 
 	if state.Visible {
 		if state.ID == 0 {
@@ -48,3 +52,12 @@ func drawSelector(_ *common.DrawCtx, s Selector) {
 func registerSelector(componentID uintptr, s Selector) {
 	wdws.RegisterSelectorState(componentID, s.(*selector).state)
 }
+
+// updateSelectorState applies updates to the selector state under the lock shared with the overlay thread.
+//
+// Parameters:
+//   - s: The Selector component to update.
+//   - updates: A variadic number of update functions to modify the state.
+func updateSelectorState(s Selector, updates ...common.UpdateSelectorState) {
+	wdws.UpdateSelectorState(s.ID(), updates...)
+}
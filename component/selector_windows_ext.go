@@ -5,7 +5,6 @@ package component
 
 import (
 	"fmt"
-	"runtime"
 	"unsafe"
 
 	"github.com/Carmen-Shannon/gooey/common"
@@ -36,7 +35,10 @@ var (
 // Returns:
 //   - uintptr: The handle of the created overlay window.
 func createSelectorOverlayWindow(s Selector) uintptr {
-	state := s.(*selector).state
+	state, ok := wdws.SelectorStateSnapshot(s.ID())
+	if !ok {
+		return 0
+	}
 	className := "GooeySelectorOverlay"
 	wndProc := windows.NewCallback(selectorOverlayProc)
 	clsName, _ := windows.UTF16PtrFromString(className)
@@ -68,7 +70,7 @@ func createSelectorOverlayWindow(s Selector) uintptr {
 	}
 
 	wdws.ShowWindow(hwnd, wdws.SW_SHOWNORMAL)
-	updateSelectorOverlay(hwnd, state)
+	updateSelectorOverlay(hwnd, &state)
 	return uintptr(hwnd)
 }
 
@@ -221,9 +223,8 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 		return 1
 	case wdws.WM_PAINT:
 		id := wdws.GetWindowLongPtr(hwnd, wdws.GWLP_USERDATA)
-		state := wdws.GetSelectorState(id)
-		if state != nil {
-			updateSelectorOverlay(hwnd, state)
+		if state, ok := wdws.SelectorStateSnapshot(id); ok {
+			updateSelectorOverlay(hwnd, &state)
 		}
 		return wdws.DefWindowProc(hwnd, msg, wParam, lParam)
 	case wdws.WM_NCCREATE:
@@ -231,8 +232,8 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 		wdws.SetWindowLongPtr(hwnd, wdws.GWLP_USERDATA, cs.LpCreateParams)
 	case wdws.WM_LBUTTONDOWN:
 		id := wdws.GetWindowLongPtr(hwnd, wdws.GWLP_USERDATA)
-		state := wdws.GetSelectorState(id)
-		if state != nil && state.Drawing && state.Blocking {
+		state, ok := wdws.SelectorStateSnapshot(id)
+		if ok && state.Drawing && state.Blocking {
 			// Start capturing bounds
 			captureBounds = true
 			startX = int32(lParam & 0xFFFF)
@@ -244,8 +245,7 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 	case wdws.WM_MOUSEMOVE:
 		if captureBounds {
 			id := wdws.GetWindowLongPtr(hwnd, wdws.GWLP_USERDATA)
-			state := wdws.GetSelectorState(id)
-			if state != nil {
+			if _, ok := wdws.SelectorStateSnapshot(id); ok {
 				curX := int32(lParam & 0xFFFF)
 				curY := int32((lParam >> 16) & 0xFFFF)
 				x, y := startX, startY
@@ -257,7 +257,7 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 				if h < 0 {
 					y, h = curY, -h
 				}
-				wdws.UpdateSelectorState(id, common.UpdateSelectorBounds(common.Rect{
+				wdws.UpdateSelectorStateFrom(uintptr(parentHwnd), id, common.UpdateSelectorBounds(common.Rect{
 					X: x, Y: y, W: w, H: h,
 				}))
 				// Trigger redraw
@@ -269,8 +269,7 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 	case wdws.WM_LBUTTONUP:
 		if captureBounds {
 			id := wdws.GetWindowLongPtr(hwnd, wdws.GWLP_USERDATA)
-			state := wdws.GetSelectorState(id)
-			if state != nil {
+			if _, ok := wdws.SelectorStateSnapshot(id); ok {
 				curX := int32(lParam & 0xFFFF)
 				curY := int32((lParam >> 16) & 0xFFFF)
 				x, y := startX, startY
@@ -281,7 +280,7 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 				if h < 0 {
 					y, h = curY, -h
 				}
-				wdws.UpdateSelectorState(id,
+				wdws.UpdateSelectorStateFrom(uintptr(parentHwnd), id,
 					common.UpdateSelectorBounds(common.Rect{X: x, Y: y, W: w, H: h}),
 					common.UpdateSelectorBlocking(false),
 					common.UpdateSelectorDrawing(false),
@@ -310,35 +309,14 @@ func selectorOverlayProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr
 	return wdws.DefWindowProc(hwnd, msg, wParam, lParam)
 }
 
-// LaunchSelectorOverlayOnThread creates and launches the selector overlay window on a separate thread.
-// It ensures that the window is created on the same thread as the GUI.
-// The function waits for the window to be created before returning the handle.
-// It handles the message loop for the overlay window and processes messages until the window is closed.
+// LaunchSelectorOverlayOnThread creates and launches the selector overlay window on a separate thread, see wdws.LaunchPopupOnThread.
 // It returns the handle of the created overlay window.
 // If the window creation fails, it returns 0.
 //
 // Parameters:
 //   - s: The selector component to be launched.
 func LaunchSelectorOverlayOnThread(s Selector) windows.Handle {
-	var hwnd windows.Handle
-	done := make(chan struct{})
-	go func() {
-		runtime.LockOSThread()
-		hwnd = windows.Handle(createSelectorOverlayWindow(s))
-		close(done)
-		if hwnd == 0 {
-			return // failed to create window
-		}
-		msg := new(wdws.Msg)
-		for {
-			ret, err := wdws.GetMessage(wdws.MessageOpt(msg), wdws.WindowHandleOpt(hwnd))
-			if ret == 0 || err != nil {
-				break // WM_QUIT or error
-			}
-			_ = wdws.TranslateMessage(msg)
-			_ = wdws.DispatchMessage(msg)
-		}
-	}()
-	<-done // Wait for window creation
-	return hwnd
+	return wdws.LaunchPopupOnThread(func() windows.Handle {
+		return windows.Handle(createSelectorOverlayWindow(s))
+	})
 }
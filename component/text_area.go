@@ -0,0 +1,314 @@
+package component
+
+import (
+	"strings"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
+)
+
+type textArea struct {
+	textInput
+	// layout is the visual lines of the value, rebuilt when layoutKey no longer matches.
+	layout     *textedit.Layout
+	layoutKey  textAreaLayoutKey
+	lineHeight int32
+	// reveal scrolls the caret into view on the next draw, it is set whenever the caret moves or the value changes.
+	reveal bool
+}
+
+// textAreaLayoutKey is everything the layout of a text area depends on.
+type textAreaLayoutKey struct {
+	value string
+	rect  common.Rect
+	font  common.Font
+	wrap  bool
+}
+
+// textAreaLine is one visual line of a text area as it is drawn, in window coordinates.
+type textAreaLine struct {
+	text string
+	x    int32
+	y    int32
+	// selection is the highlighted part of the line, empty when none of it is selected.
+	selection common.Rect
+}
+
+// NewTextArea creates a new TextArea component with the specified options.
+// It accepts a variadic list of CreateTextAreaOption functions to customize the text area's properties.
+//
+// Parameters:
+//   - options: A variadic list of CreateTextAreaOption functions to customize the text area's properties.
+func NewTextArea(options ...CreateTextAreaOption) TextArea {
+	opts := newCreateTextAreaOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+	cOpts := newCreateComponentOptions()
+	for _, opt := range opts.ComponentOptions {
+		opt(cOpts)
+	}
+
+	ta := &textArea{reveal: true}
+	initTextInput(&ta.textInput, opts.textInputOptions(), cOpts)
+	ta.state.Multiline = true
+	ta.state.MaxLines = opts.MaxLines
+	ta.state.Wrap = opts.Wrap
+
+	// the scrolling lives here, the backend maps clicks through the scroll offset kept in the state
+	event.Subscribe(ta.events, func(e event.CaretMovedEvent) {
+		ta.reveal = true
+	})
+	event.Subscribe(ta.events, func(e event.ValueChangedEvent[string]) {
+		ta.reveal = true
+	})
+	event.Subscribe(ta.events, func(e event.WheelEvent) {
+		ta.handleWheel(e.DeltaX, e.DeltaY, e.Modifiers)
+	})
+	return ta
+}
+
+// TextArea is a multi-line text field.
+// Long lines wrap at its width or, with wrapping turned off, scroll horizontally, and lines beyond its height scroll
+// vertically with the caret and the mouse wheel. Enter breaks the line, Up and Down move the caret between lines while
+// keeping its column, Page Up and Page Down move it by a page and Ctrl+Home and Ctrl+End to the start and end of the text.
+// Text areas are always left aligned.
+type TextArea interface {
+	TextInput
+
+	// MaxLines returns the maximum number of lines of the text area.
+	//
+	// Returns:
+	//  - int32: The maximum number of lines, 0 for no limit.
+	MaxLines() int32
+
+	// SetMaxLines sets the maximum number of lines of the text area.
+	// Lines are counted by their line breaks, wrapping does not add to them. The current value is not cut down.
+	//
+	// Parameters:
+	//  - maxLines: The maximum number of lines, 0 for no limit.
+	SetMaxLines(maxLines int32)
+
+	// Wrap returns whether the text area wraps lines wider than itself.
+	//
+	// Returns:
+	//  - bool: true if long lines wrap, false if they scroll horizontally.
+	Wrap() bool
+
+	// SetWrap sets whether the text area wraps lines wider than itself.
+	//
+	// Parameters:
+	//  - wrap: true to wrap long lines, false to scroll them horizontally.
+	SetWrap(wrap bool)
+
+	// LineCount returns the number of lines of the value, counted by its line breaks.
+	//
+	// Returns:
+	//  - int32: The number of lines, at least 1.
+	LineCount() int32
+
+	// CaretLine returns the line and column of the caret, both counting from 0.
+	// Lines are counted by the line breaks of the value and columns in characters, regardless of wrapping.
+	//
+	// Returns:
+	//  - int32: The line of the caret.
+	//  - int32: The column of the caret.
+	CaretLine() (int32, int32)
+
+	// ScrollOffset returns how far the text of the text area is scrolled.
+	//
+	// Returns:
+	//  - int32: The horizontal offset in pixels.
+	//  - int32: The vertical offset in pixels.
+	ScrollOffset() (int32, int32)
+
+	// SetScrollOffset scrolls the text of the text area, clamping the offset to its content.
+	// Publishes a ScrollEvent when the offset changes.
+	//
+	// Parameters:
+	//  - x: The horizontal offset in pixels.
+	//  - y: The vertical offset in pixels.
+	SetScrollOffset(x, y int32)
+}
+
+var _ TextArea = (*textArea)(nil)
+var _ KeyConsumer = (*textArea)(nil)
+
+func (ta *textArea) Draw(ctx *common.DrawCtx) {
+	ta.syncStateFont()
+	drawComponent(ta, ctx)
+}
+
+// PreferredSize returns a size fitting four lines of 30 characters.
+func (ta *textArea) PreferredSize() (int32, int32) {
+	w, h := measureText(ta.Font(), ta.TextSize(), strings.Repeat("0", 30))
+	return w + 2*common.TextPadding, 4*h + 2*common.TextPadding
+}
+
+func (ta *textArea) MaxLines() int32 {
+	return ta.state.MaxLines
+}
+
+func (ta *textArea) SetMaxLines(maxLines int32) {
+	ta.state.MaxLines = maxLines
+}
+
+func (ta *textArea) Wrap() bool {
+	return ta.state.Wrap
+}
+
+func (ta *textArea) SetWrap(wrap bool) {
+	ta.state.Wrap = wrap
+	ta.reveal = true
+}
+
+func (ta *textArea) LineCount() int32 {
+	return int32(strings.Count(ta.value, "\n")) + 1
+}
+
+func (ta *textArea) CaretLine() (int32, int32) {
+	runes := []rune(ta.value)
+	caret := min(max(ta.caretPos, 0), int32(len(runes)))
+	line, start := int32(0), int32(0)
+	for i, ch := range runes[:caret] {
+		if ch == '\n' {
+			line++
+			start = int32(i) + 1
+		}
+	}
+	return line, caret - start
+}
+
+func (ta *textArea) ScrollOffset() (int32, int32) {
+	return ta.state.Scroll.X, ta.state.Scroll.Y
+}
+
+func (ta *textArea) SetScrollOffset(x, y int32) {
+	maxX, maxY := ta.maxScroll()
+	x, y = min(max(x, 0), maxX), min(max(y, 0), maxY)
+	if x == ta.state.Scroll.X && y == ta.state.Scroll.Y {
+		return
+	}
+	ta.state.Scroll.X, ta.state.Scroll.Y = x, y
+	event.Publish(ta.events, event.ScrollEvent{Source: ta.id, X: x, Y: y})
+}
+
+// ConsumesKey adds Enter to the keys of a text input, which breaks the line instead of reaching the window.
+func (ta *textArea) ConsumesKey(key event.Key, mods event.Modifiers) bool {
+	if key == event.KeyEnter && !mods.Has(event.ModCtrl) && !mods.Has(event.ModSuper) && !mods.Has(event.ModAlt) {
+		return true
+	}
+	return ta.textInput.ConsumesKey(key, mods)
+}
+
+// handleWheel scrolls by three lines per notch of the wheel, turning it sideways with Shift when lines do not wrap.
+func (ta *textArea) handleWheel(dx, dy int32, mods event.Modifiers) {
+	ta.textLayout()
+	if !ta.state.Wrap && dx == 0 && mods.Has(event.ModShift) {
+		dx, dy = dy, 0
+	}
+	step := 3 * ta.lineHeight
+	ta.SetScrollOffset(ta.state.Scroll.X+dx*step/event.WheelDelta, ta.state.Scroll.Y+dy*step/event.WheelDelta)
+}
+
+// textLayout returns the visual lines of the value, laying them out again only when the value, size, font or wrapping changed.
+// It also keeps the line height the backend maps clicks with up to date.
+func (ta *textArea) textLayout() *textedit.Layout {
+	key := textAreaLayoutKey{value: ta.state.Value, rect: ta.state.TextRect(), font: ta.state.Font, wrap: ta.state.Wrap}
+	if ta.layout != nil && key == ta.layoutKey {
+		return ta.layout
+	}
+	_, ta.lineHeight = measureText(key.font.Name, key.font.Size, "Ag")
+	ta.lineHeight = max(ta.lineHeight, 1)
+	ta.state.LineHeight = ta.lineHeight
+	ta.layout = ta.state.Layout(func(text string) int32 {
+		w, _ := measureText(key.font.Name, key.font.Size, text)
+		return w
+	})
+	ta.layoutKey = key
+	return ta.layout
+}
+
+// maxScroll returns the largest scroll offsets that still keep the text rectangle filled.
+func (ta *textArea) maxScroll() (int32, int32) {
+	layout := ta.textLayout()
+	rect := ta.state.TextRect()
+	maxY := max(int32(len(layout.Lines))*ta.lineHeight-rect.H, 0)
+	if ta.state.Wrap {
+		return 0, maxY
+	}
+	// leave room for the caret after the longest line
+	return max(layout.Width()+2-rect.W, 0), maxY
+}
+
+// revealCaret scrolls the least distance that brings the caret's line, and without wrapping its column, into view.
+func (ta *textArea) revealCaret() {
+	layout := ta.textLayout()
+	rect := ta.state.TextRect()
+	x, y := ta.state.Scroll.X, ta.state.Scroll.Y
+
+	top := int32(layout.LineOf(ta.caretPos)) * ta.lineHeight
+	switch {
+	case top < y:
+		y = top
+	case top+ta.lineHeight > y+rect.H:
+		y = top + ta.lineHeight - rect.H
+	}
+	if !ta.state.Wrap {
+		left := layout.X(ta.caretPos)
+		switch {
+		case left < x:
+			x = left
+		case left+2 > x+rect.W:
+			x = left + 2 - rect.W
+		}
+	}
+	ta.SetScrollOffset(x, y)
+}
+
+// visibleLines returns the lines of the text area overlapping its text rectangle, and where the caret is drawn.
+// Selecting across a line break highlights a little space after the line, so selected empty lines show up.
+//
+// Returns:
+//   - []textAreaLine: The lines to draw from top to bottom.
+//   - common.Rect: The caret, 2 pixels wide and one line high.
+func (ta *textArea) visibleLines() ([]textAreaLine, common.Rect) {
+	layout := ta.textLayout()
+	if ta.reveal {
+		ta.reveal = false
+		ta.revealCaret()
+	} else {
+		// the content may have shrunk since the offset was set
+		ta.SetScrollOffset(ta.state.Scroll.X, ta.state.Scroll.Y)
+	}
+	rect := ta.state.TextRect()
+	originX, originY := rect.X-ta.state.Scroll.X, rect.Y-ta.state.Scroll.Y
+	selStart, selEnd := min(ta.selectionStart, ta.selectionEnd), max(ta.selectionStart, ta.selectionEnd)
+
+	first := max(int(ta.state.Scroll.Y/ta.lineHeight), 0)
+	var lines []textAreaLine
+	for i := first; i < len(layout.Lines); i++ {
+		y := originY + int32(i)*ta.lineHeight
+		if y >= rect.Y+rect.H {
+			break
+		}
+		ln := layout.Lines[i]
+		line := textAreaLine{text: layout.Text(i), x: originX, y: y}
+		if start, end := max(selStart, ln.Start), min(selEnd, ln.End); start < end || (!ln.Wrapped && start == ln.End && selEnd > ln.End) {
+			from, to := layout.LineX(i, start), layout.LineX(i, end)
+			if selEnd > ln.End && !ln.Wrapped {
+				to += common.TextPadding
+			}
+			line.selection = common.Rect{X: originX + from, Y: y, W: to - from, H: ta.lineHeight}
+		}
+		lines = append(lines, line)
+	}
+	caret := common.Rect{
+		X: originX + layout.X(ta.caretPos),
+		Y: originY + int32(layout.LineOf(ta.caretPos))*ta.lineHeight,
+		W: 2,
+		H: ta.lineHeight,
+	}
+	return lines, caret
+}
@@ -0,0 +1,213 @@
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
+)
+
+type createTextAreaOptions struct {
+	Value            string
+	MaxLength        int32
+	MaxLines         int32
+	Wrap             bool
+	Font             string
+	Color            *common.Color
+	TextColor        *common.Color
+	TextSize         int32
+	UndoDepth        int
+	Placeholder      placeholderOptions
+	ComponentOptions []CreateComponentOption
+}
+
+type CreateTextAreaOption func(*createTextAreaOptions)
+
+func newCreateTextAreaOptions() *createTextAreaOptions {
+	return &createTextAreaOptions{
+		Value:     "",
+		MaxLength: 0,
+		MaxLines:  0,
+		Wrap:      true,
+		Font:      "",
+		Color:     nil,
+		TextColor: nil,
+		TextSize:  0,
+		UndoDepth: textedit.DefaultHistoryDepth,
+	}
+}
+
+// textInputOptions returns the options of the text input a text area is built on.
+func (opts *createTextAreaOptions) textInputOptions() *createTextInputOptions {
+	return &createTextInputOptions{
+		Value:            opts.Value,
+		MaxLength:        opts.MaxLength,
+		Font:             opts.Font,
+		Color:            opts.Color,
+		TextColor:        opts.TextColor,
+		TextSize:         opts.TextSize,
+		TextAlignment:    LeftAlign,
+		UndoDepth:        opts.UndoDepth,
+		Placeholder:      opts.Placeholder,
+		ComponentOptions: opts.ComponentOptions,
+	}
+}
+
+// TextAreaValueOpt sets the value of the text area component, which may hold line breaks.
+// It takes a string as the value and returns a CreateTextAreaOption function.
+//
+// Parameters:
+//   - value: The string value to set for the text area component.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaValueOpt(value string) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Value = value
+	}
+}
+
+// TextAreaMaxLengthOpt sets the maximum length of the text area component, line breaks included.
+// It takes an int32 as the maximum length and returns a CreateTextAreaOption function.
+//
+// Parameters:
+//   - maxLength: The maximum number of characters allowed in the text area component, 0 for no limit.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaMaxLengthOpt(maxLength int32) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.MaxLength = maxLength
+	}
+}
+
+// TextAreaMaxLinesOpt sets the maximum number of lines of the text area component.
+// Lines are counted by their line breaks, wrapping does not add to them.
+//
+// Parameters:
+//   - maxLines: The maximum number of lines, 0 for no limit.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaMaxLinesOpt(maxLines int32) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.MaxLines = maxLines
+	}
+}
+
+// TextAreaWrapOpt sets whether the text area component wraps lines wider than itself.
+// Without wrapping, long lines scroll horizontally. Wrapping is on by default.
+//
+// Parameters:
+//   - wrap: True to wrap long lines.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaWrapOpt(wrap bool) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Wrap = wrap
+	}
+}
+
+// TextAreaFontOpt sets the font of the text area component.
+//
+// Parameters:
+//   - font: The name of the font.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaFontOpt(font string) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Font = font
+	}
+}
+
+// TextAreaColorOpt sets the background color of the text area component.
+//
+// Parameters:
+//   - color: The background color to set for the text area component.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaColorOpt(color *common.Color) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Color = color
+	}
+}
+
+// TextAreaTextColorOpt sets the text color of the text area component.
+//
+// Parameters:
+//   - textColor: The text color to set for the text area component.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaTextColorOpt(textColor *common.Color) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.TextColor = textColor
+	}
+}
+
+// TextAreaTextSizeOpt sets the text size of the text area component.
+//
+// Parameters:
+//   - textSize: The size of the text in points.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaTextSizeOpt(textSize int32) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.TextSize = textSize
+	}
+}
+
+// TextAreaUndoDepthOpt sets how many edits of the text area component can be undone. The default is 100.
+//
+// Parameters:
+//   - depth: The maximum number of undo steps kept, 0 disables undo.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaUndoDepthOpt(depth int) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.UndoDepth = depth
+	}
+}
+
+// TextAreaPlaceholderOpt sets the hint text the text area component shows while its value is empty.
+//
+// Parameters:
+//   - placeholder: The hint text.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaPlaceholderOpt(placeholder string) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Placeholder.Text = placeholder
+	}
+}
+
+// TextAreaPlaceholderColorOpt sets the color of the placeholder of the text area component.
+// Without it the placeholder uses the theme's disabled text color.
+//
+// Parameters:
+//   - color: The color of the placeholder.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaPlaceholderColorOpt(color *common.Color) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.Placeholder.Color = color
+	}
+}
+
+// TextAreaComponentOptionsOpt sets additional component options for the text area component.
+//
+// Parameters:
+//   - componentOptions: Additional options to apply to the text area component.
+//
+// Returns:
+//   - CreateTextAreaOption: A function that takes a pointer to createTextAreaOptions
+func TextAreaComponentOptionsOpt(componentOptions ...CreateComponentOption) CreateTextAreaOption {
+	return func(opts *createTextAreaOptions) {
+		opts.ComponentOptions = componentOptions
+	}
+}
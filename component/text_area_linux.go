@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/linux"
+)
+
+// drawTextArea draws the text area line by line, clipped to its text rectangle and shifted by its scroll offset.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the text area.
+//   - ta: The TextArea component to be drawn.
+func drawTextArea(ctx *common.DrawCtx, ta TextArea) {
+	x, y := ta.Position()
+	w, h := ta.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	display := linux.GetDisplay(ctx.Hwnd)
+	if display == nil {
+		return
+	}
+	drawable := linux.C_Drawable(ctx.Hdc)
+	area := ta.(*textArea)
+
+	borderColor, highlightColor, caretColor := area.themeColors()
+	borderColor, highlightColor, caretColor = fade(ctx, ta, borderColor), fade(ctx, ta, highlightColor), fade(ctx, ta, caretColor)
+
+	linux.XFillRect(display, drawable, int(x), int(y), int(w), int(h), fade(ctx, ta, ta.Color()))
+	linux.XDrawRect(display, drawable, int(x), int(y), int(w), int(h), borderColor)
+
+	lines, caret := area.visibleLines()
+	rect := area.state.TextRect()
+	fontName := ta.Font()
+	fontSize := int(ta.TextSize())
+	textColor := fade(ctx, ta, ta.TextColor())
+
+	linux.PushClipRect(drawable, rect.X, rect.Y, rect.W, rect.H)
+	defer linux.PopClipRect(drawable)
+	for _, line := range lines {
+		if sel := line.selection; !sel.Empty() {
+			linux.XFillRect(display, drawable, int(sel.X), int(sel.Y), int(sel.W), int(sel.H), highlightColor)
+		}
+		if line.text != "" {
+			linux.XDrawTextRect(display, drawable, int(line.x), int(line.y), int(rect.X+rect.W-line.x), int(area.lineHeight), fontName, fontSize, line.text, textColor, linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+		}
+	}
+
+	if area.placeholderShown() && len(lines) > 0 {
+		first := lines[0]
+		linux.XDrawTextRect(display, drawable, int(first.x), int(first.y), int(rect.X+rect.W-first.x), int(area.lineHeight), fontName, fontSize, ta.Placeholder(), fade(ctx, ta, ta.PlaceholderColor()), linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+	}
+
+	preedit, preeditCaret := area.preedit()
+	if preedit != "" {
+		caret.X = drawPreedit(ctx, caret, fontName, int32(fontSize), preedit, preeditCaret, textColor, fade(ctx, ta, ta.Color()))
+	}
+
+	start, end := ta.Selection()
+	if linux.CT.IsVisible() && ta.Focused() && (start == end || preedit != "") {
+		linux.XFillRect(display, drawable, int(caret.X), int(caret.Y), int(caret.W), int(caret.H), caretColor)
+	}
+}
@@ -0,0 +1,68 @@
+//go:build windows
+// +build windows
+
+package component
+
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+)
+
+// drawTextArea draws the text area line by line, clipped to its text rectangle and shifted by its scroll offset.
+//
+// Parameters:
+//   - ctx: The context to use for drawing the text area.
+//   - ta: The TextArea component to be drawn.
+func drawTextArea(ctx *common.DrawCtx, ta TextArea) {
+	x, y := ta.Position()
+	w, h := ta.Size()
+	area := ta.(*textArea)
+
+	borderColor, highlightColor, caretColor := area.themeColors()
+	borderColor, highlightColor, caretColor = fade(ctx, ta, borderColor), fade(ctx, ta, highlightColor), fade(ctx, ta, caretColor)
+
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y, W: w, H: h}, fade(ctx, ta, ta.Color()))
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y, W: w, H: 1}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y + h - 1, W: w, H: 1}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y, W: 1, H: h}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x + w - 1, Y: y, W: 1, H: h}, borderColor)
+
+	lines, caret := area.visibleLines()
+	rect := area.state.TextRect()
+
+	font := wdws.CreateFont(-ta.TextSize(), ta.Font())
+	oldFont := wdws.SelectObject(ctx.Hdc, font)
+	defer wdws.SelectObject(ctx.Hdc, oldFont)
+	wdws.SetBkMode(ctx.Hdc, wdws.BK_TRANSPARENT)
+	wdws.SetTextColor(ctx.Hdc, fade(ctx, ta, ta.TextColor()))
+
+	wdws.PushClipRect(ctx.Hdc, rect.X, rect.Y, rect.W, rect.H)
+	defer wdws.PopClipRect(ctx.Hdc)
+	for _, line := range lines {
+		if !line.selection.Empty() {
+			fillRect(ctx.Hdc, line.selection, highlightColor)
+		}
+		if line.text != "" {
+			lineRect := [4]int32{line.x, line.y, rect.X + rect.W, line.y + area.lineHeight}
+			wdws.DrawText(ctx.Hdc, line.text, &lineRect, wdws.DT_LEFT|wdws.DT_VCENTER|wdws.DT_SINGLELINE|wdws.DT_NOCLIP|wdws.DT_NOPREFIX)
+		}
+	}
+
+	if area.placeholderShown() && len(lines) > 0 {
+		first := lines[0]
+		lineRect := [4]int32{first.x, first.y, rect.X + rect.W, first.y + area.lineHeight}
+		wdws.SetTextColor(ctx.Hdc, fade(ctx, ta, ta.PlaceholderColor()))
+		wdws.DrawText(ctx.Hdc, ta.Placeholder(), &lineRect, wdws.DT_LEFT|wdws.DT_VCENTER|wdws.DT_SINGLELINE|wdws.DT_NOCLIP|wdws.DT_NOPREFIX)
+	}
+
+	preedit, preeditCaret := area.preedit()
+	if preedit != "" {
+		wdws.SetTextColor(ctx.Hdc, fade(ctx, ta, ta.TextColor()))
+		caret.X = drawPreedit(ctx, caret, font, preedit, preeditCaret, fade(ctx, ta, ta.TextColor()), fade(ctx, ta, ta.Color()))
+	}
+
+	start, end := ta.Selection()
+	if ta.Focused() && wdws.CT.IsVisible() && (start == end || preedit != "") {
+		fillRect(ctx.Hdc, caret, caretColor)
+	}
+}
@@ -1,6 +1,13 @@
 package component
 
-import "github.com/Carmen-Shannon/gooey/common"
+import (
+	"strings"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
+	"github.com/Carmen-Shannon/gooey/theme"
+)
 
 type textInput struct {
 	baseComponent
@@ -15,9 +22,19 @@ type textInput struct {
 	caretPos       int32
 	selectionStart int32
 	selectionEnd   int32
+	passwordMask   rune
+	revealed       bool
+	placeholder    placeholderOptions
+	validators     []func(string) error
+	errorMessage   string
+	errorColor     *common.Color
 	state          *common.TextInputState
 }
 
+// DefaultPasswordMask is the character a password field draws for each character of its value unless told otherwise.
+// The core X11 fonts only cover Latin-1, which rules out the usual bullet.
+const DefaultPasswordMask = '*'
+
 // NewTextInput creates a new TextInput component with the specified options.
 // It accepts a variadic list of CreateTextInputOption functions to customize the text input's properties.
 //
@@ -33,11 +50,32 @@ func NewTextInput(options ...CreateTextInputOption) TextInput {
 		opt(cOpts)
 	}
 
-	ti := &textInput{
+	ti := &textInput{}
+	initTextInput(ti, opts, cOpts)
+	return ti
+}
+
+// initTextInput sets up a text input in place, subscribes it to the events of its state and registers the state with
+// the backend. Text areas share it, which is why it works on a pointer to the embedded text input.
+//
+// Parameters:
+//   - ti: The text input to set up.
+//   - opts: The text input options.
+//   - cOpts: The component options.
+func initTextInput(ti *textInput, opts *createTextInputOptions, cOpts *createComponentOptions) {
+	*ti = textInput{
 		baseComponent: baseComponent{
-			id:      cOpts.ID,
-			visible: cOpts.Visible,
-			enabled: cOpts.Enabled,
+			id:        cOpts.ID,
+			events:    event.NewDispatcher(),
+			visible:   cOpts.Visible,
+			enabled:   cOpts.Enabled,
+			anchor:    cOpts.Anchor,
+			minSize:   cOpts.MinSize,
+			maxSize:   cOpts.MaxSize,
+			focusable: cOpts.focusable(true),
+			tabIndex:  cOpts.TabIndex,
+			tooltip:   cOpts.Tooltip,
+			cursor:    cOpts.Cursor,
 			size: struct {
 				Width  int32
 				Height int32
@@ -64,7 +102,12 @@ func NewTextInput(options ...CreateTextInputOption) TextInput {
 		caretPos:       0,
 		selectionStart: 0,
 		selectionEnd:   0,
+		passwordMask:   opts.PasswordMask,
+		placeholder:    opts.Placeholder,
+		validators:     opts.Validators,
+		errorColor:     opts.ErrorColor,
 		state: &common.TextInputState{
+			ID:             cOpts.ID,
 			Value:          opts.Value,
 			MaxLength:      opts.MaxLength,
 			Font:           common.Font{Name: opts.Font, Size: opts.TextSize},
@@ -72,6 +115,9 @@ func NewTextInput(options ...CreateTextInputOption) TextInput {
 			SelectionEnd:   0,
 			CaretPos:       0,
 			Focused:        false,
+			Mask:           opts.PasswordMask,
+			Filter:         opts.Filter,
+			History:        textedit.NewHistory(opts.UndoDepth),
 			Bounds: struct {
 				X      int32
 				Y      int32
@@ -83,60 +129,46 @@ func NewTextInput(options ...CreateTextInputOption) TextInput {
 				Width:  cOpts.Size.Width,
 				Height: cOpts.Size.Height,
 			},
-			CbMap: make(map[string]func(any)),
 		},
 	}
 
-	cbMap := make(map[string]func(any))
-	cbMap["value"] = func(newVal any) {
-		if newValStr, ok := newVal.(string); ok && newValStr != ti.value && len(newValStr) <= int(ti.maxLength) {
-			ti.value = newValStr
-		}
-	}
-	cbMap["focused"] = func(focused any) {
-		if focusedBool, ok := focused.(bool); ok && focusedBool != ti.focused {
-			ti.focused = focusedBool
-		}
-	}
-	cbMap["caretPos"] = func(caretPos any) {
-		if caretPosInt, ok := caretPos.(int32); ok && caretPosInt != ti.caretPos {
-			ti.caretPos = caretPosInt
+	// the backend edits the shared state and publishes what changed, keep the component's fields in sync with it
+	ti.state.Events = ti.events
+	event.Subscribe(ti.events, func(e event.ValueChangedEvent[string]) {
+		ti.value = e.New
+		ti.Validate()
+	})
+	event.Subscribe(ti.events, func(e event.FocusEvent) {
+		ti.focused = e.Focused
+		if !e.Focused {
+			ti.Validate()
 		}
-	}
-	cbMap["selectionStart"] = func(selectionStart any) {
-		if selectionStartInt, ok := selectionStart.(int32); ok {
-			ti.selectionStart = selectionStartInt
-		}
-	}
-	cbMap["selectionEnd"] = func(selectionEnd any) {
-		if selectionEndInt, ok := selectionEnd.(int32); ok {
-			ti.selectionEnd = selectionEndInt
-		}
-	}
-	cbMap["maxLength"] = func(maxLength any) {
-		if maxLengthInt, ok := maxLength.(int32); ok && maxLengthInt != ti.maxLength {
-			ti.maxLength = maxLengthInt
-		}
-	}
-	cbMap["bounds"] = func(bounds any) {
-		if boundsStruct, ok := bounds.(struct {
-			X      int32
-			Y      int32
-			Width  int32
-			Height int32
-		}); ok {
-			ti.position.X = boundsStruct.X
-			ti.position.Y = boundsStruct.Y
-			ti.size.Width = boundsStruct.Width
-			ti.size.Height = boundsStruct.Height
-		}
-	}
-	ti.state.CbMap = cbMap
+	})
+	event.Subscribe(ti.events, func(e event.CaretMovedEvent) {
+		ti.caretPos = e.Pos
+	})
+	event.Subscribe(ti.events, func(e event.SelectionChangedEvent) {
+		ti.selectionStart = e.Start
+		ti.selectionEnd = e.End
+	})
+	event.Subscribe(ti.events, func(e event.BoundsChangedEvent) {
+		ti.position.X = e.X
+		ti.position.Y = e.Y
+		ti.size.Width = e.Width
+		ti.size.Height = e.Height
+	})
 
 	registerTextInput(ti)
-	return ti
 }
 
+// register hands the text input's state to the backend, see Register.
+func (ti *textInput) register() {
+	registerTextInput(ti)
+}
+
+// TextInput is a single line text field.
+// The font, text size and colors come from the text input's theme until they are set explicitly,
+// the border, selection and caret colors always do.
 type TextInput interface {
 	Component
 
@@ -243,21 +275,159 @@ type TextInput interface {
 	//  - int32: The start position of the selection.
 	//  - int32: The end position of the selection.
 	Selection() (int32, int32)
+
+	// Undo reverts the latest edit, restoring the value, caret and selection from before it, as Ctrl+Z does.
+	// Consecutive typing is undone at once.
+	//
+	// Returns:
+	//  - bool: true if there was an edit to undo, false otherwise.
+	Undo() bool
+
+	// Redo reapplies the latest undone edit, as Ctrl+Y and Ctrl+Shift+Z do.
+	//
+	// Returns:
+	//  - bool: true if there was an edit to redo, false otherwise.
+	Redo() bool
+
+	// ClearHistory forgets all edits, so neither Undo nor Redo have anything to do until the value is edited again.
+	ClearHistory()
+
+	// PasswordMask returns the character drawn in place of each character of a password field's value.
+	//
+	// Returns:
+	//  - rune: The mask character, 0 if the text input is not a password field.
+	PasswordMask() rune
+
+	// SetPasswordMask turns the text input into a password field drawing mask in place of each character of its value.
+	// While masked, the value cannot be copied or cut and double-clicking selects all of it.
+	//
+	// Parameters:
+	//  - mask: The mask character, 0 to show the value as it is.
+	SetPasswordMask(mask rune)
+
+	// Revealed returns whether a password field currently shows its value.
+	//
+	// Returns:
+	//  - bool: true if the value is shown despite the password mask, false otherwise.
+	Revealed() bool
+
+	// SetRevealed shows or masks the value of a password field again, as a "show password" toggle does.
+	// A revealed value can be copied and cut like the value of any other text input.
+	//
+	// Parameters:
+	//  - revealed: true to show the value, false to mask it.
+	SetRevealed(revealed bool)
+
+	// Placeholder returns the hint text shown while the value of the text input is empty.
+	//
+	// Returns:
+	//  - string: The placeholder, empty if there is none.
+	Placeholder() string
+
+	// SetPlaceholder sets the hint text shown while the value of the text input is empty.
+	// The placeholder is only drawn, Value never returns it and it is never copied.
+	//
+	// Parameters:
+	//  - placeholder: The hint text, empty for none.
+	SetPlaceholder(placeholder string)
+
+	// PlaceholderColor returns the color of the placeholder.
+	//
+	// Returns:
+	//  - *common.Color: The color of the placeholder.
+	PlaceholderColor() *common.Color
+
+	// SetPlaceholderColor sets the color of the placeholder.
+	//
+	// Parameters:
+	//  - color: The color of the placeholder, nil for the theme's disabled text color.
+	SetPlaceholderColor(color *common.Color)
+
+	// Filter returns the filter limiting what typing and pasting insert.
+	//
+	// Returns:
+	//  - *common.TextFilter: The filter, nil if any text is accepted.
+	Filter() *common.TextFilter
+
+	// SetFilter sets the filter limiting what typing and pasting insert. The current value is left as it is.
+	//
+	// Parameters:
+	//  - filter: The filter, nil to accept any text.
+	SetFilter(filter *common.TextFilter)
+
+	// AddValidator adds a validator checking the whole value whenever it changes and when the text input loses focus.
+	//
+	// Parameters:
+	//  - validate: The function returning an error for values that are not acceptable.
+	AddValidator(validate func(value string) error)
+
+	// Validate runs the validators on the current value now and updates the error state from the first error.
+	// Publishes a ValidationEvent when the error message changes.
+	//
+	// Returns:
+	//  - error: The first error of the validators, nil if the value is valid.
+	Validate() error
+
+	// Error returns the message of the error state, which the border shows in the error color and the tooltip spells out.
+	//
+	// Returns:
+	//  - string: The error message, empty if the value is valid.
+	Error() string
+
+	// SetError puts the text input into the error state with a message, for errors only known elsewhere, such as a
+	// name that is already taken. The next validation replaces it when the text input has validators.
+	// Publishes a ValidationEvent when the error message changes.
+	//
+	// Parameters:
+	//  - message: The error message, empty to clear the error state.
+	SetError(message string)
 }
 
 var _ TextInput = (*textInput)(nil)
+var _ KeyConsumer = (*textInput)(nil)
 
 func (ti *textInput) Draw(ctx *common.DrawCtx) {
+	ti.syncStateFont()
 	drawComponent(ti, ctx)
 }
 
+// SetSize sets the size of the text input and keeps the bounds used for hit-testing in sync.
+func (ti *textInput) SetSize(width, height int32) {
+	ti.baseComponent.SetSize(width, height)
+	ti.state.Bounds.Width, ti.state.Bounds.Height = ti.Size()
+	ti.state.Clip = clipOf(ti)
+}
+
+// SetPosition sets the position of the text input and keeps the bounds used for hit-testing in sync.
+func (ti *textInput) SetPosition(x, y int32) {
+	ti.baseComponent.SetPosition(x, y)
+	ti.state.Bounds.X = x
+	ti.state.Bounds.Y = y
+	ti.state.Clip = clipOf(ti)
+}
+
+// PreferredSize returns a size wide enough for the maximum length of the input, capped at 20 characters.
+func (ti *textInput) PreferredSize() (int32, int32) {
+	chars := int32(20)
+	if ti.maxLength > 0 {
+		chars = min(ti.maxLength, chars)
+	}
+	w, h := measureText(ti.Font(), ti.TextSize(), strings.Repeat("0", int(chars)))
+	return w + 12, h + 12
+}
+
 func (ti *textInput) Value() string {
 	return ti.value
 }
 
+// SetValue sets the value of the text input and publishes a ValueChangedEvent when it differs from the current one.
 func (ti *textInput) SetValue(value string) {
+	old := ti.value
 	ti.value = value
 	ti.state.Value = value
+	if old != value {
+		event.Publish(ti.events, event.ValueChangedEvent[string]{Source: ti.id, Old: old, New: value})
+	}
 }
 
 func (ti *textInput) MaxLength() int32 {
@@ -267,19 +437,27 @@ func (ti *textInput) MaxLength() int32 {
 func (ti *textInput) SetMaxLength(maxLength int32) {
 	ti.maxLength = maxLength
 	ti.state.MaxLength = maxLength
+	ti.invalidateLayout()
 }
 
 func (ti *textInput) Font() string {
-	return ti.font
+	if ti.font != "" {
+		return ti.font
+	}
+	return ti.Theme().TextInput.Font.Family
 }
 
 func (ti *textInput) SetFont(font string) {
 	ti.font = font
-	ti.state.Font.Name = font
+	ti.state.Font.Name = ti.Font()
+	ti.invalidateLayout()
 }
 
 func (ti *textInput) Color() *common.Color {
-	return ti.color
+	if ti.color != nil {
+		return ti.color
+	}
+	return ti.Theme().TextInput.Resolve(ti.themeState()).Background
 }
 
 func (ti *textInput) SetColor(color *common.Color) {
@@ -287,7 +465,10 @@ func (ti *textInput) SetColor(color *common.Color) {
 }
 
 func (ti *textInput) TextColor() *common.Color {
-	return ti.textColor
+	if ti.textColor != nil {
+		return ti.textColor
+	}
+	return ti.Theme().TextInput.Resolve(ti.themeState()).Foreground
 }
 
 func (ti *textInput) SetTextColor(textColor *common.Color) {
@@ -295,11 +476,37 @@ func (ti *textInput) SetTextColor(textColor *common.Color) {
 }
 
 func (ti *textInput) TextSize() int32 {
-	return ti.textSize
+	if ti.textSize > 0 {
+		return ti.textSize
+	}
+	return ti.Theme().TextInput.Font.Size
 }
 
 func (ti *textInput) SetTextSize(textSize int32) {
 	ti.textSize = textSize
+	ti.state.Font.Size = ti.TextSize()
+	ti.invalidateLayout()
+}
+
+// themeColors returns the colors of the text input that only come from its theme.
+//
+// Returns:
+//   - *common.Color: The color of the border.
+//   - *common.Color: The background color of selected text.
+//   - *common.Color: The color of the caret.
+func (ti *textInput) themeColors() (*common.Color, *common.Color, *common.Color) {
+	t := ti.Theme()
+	border := t.TextInput.Resolve(ti.themeState()).Border
+	if ti.errorColor != nil && ti.themeState() == theme.StateError {
+		border = ti.errorColor
+	}
+	return border, &t.Palette.Selection, &t.Palette.Caret
+}
+
+// syncStateFont keeps the font the backend measures the text with in line with the drawn font,
+// which changes with the theme when the text input was not given one.
+func (ti *textInput) syncStateFont() {
+	ti.state.Font = common.Font{Name: ti.Font(), Size: ti.TextSize()}
 }
 
 func (ti *textInput) TextAlignment() TextAlignment {
@@ -310,10 +517,43 @@ func (ti *textInput) SetTextAlignment(textAlignment TextAlignment) {
 	ti.textAlignment = textAlignment
 }
 
+// Cursor returns the I-beam over enabled text inputs unless a cursor was set explicitly.
+func (ti *textInput) Cursor() *common.Cursor {
+	if ti.cursor == nil && ti.enabled {
+		return common.CursorIBeam
+	}
+	return ti.cursor
+}
+
 func (ti *textInput) Focused() bool {
 	return ti.focused
 }
 
+func (ti *textInput) ConsumesKey(key event.Key, mods event.Modifiers) bool {
+	if mods.Has(event.ModCtrl) || mods.Has(event.ModSuper) {
+		// clipboard, select all and undo shortcuts are handled by the text input itself
+		switch key {
+		case event.KeyA, event.KeyC, event.KeyV, event.KeyX, event.KeyZ, event.KeyY,
+			event.KeyLeft, event.KeyRight, event.KeyHome, event.KeyEnd, event.KeyBackspace, event.KeyDelete:
+			return true
+		}
+		return false
+	}
+	if mods.Has(event.ModAlt) {
+		return false
+	}
+	switch key {
+	case event.KeyEscape, event.KeyTab, event.KeyEnter, event.KeyUnknown:
+		return false
+	}
+	return key < event.KeyF1 || key > event.KeyF12
+}
+
+// setFocused hands focus changes made by the window's focus manager to the backend, which owns the text input's focus.
+func (ti *textInput) setFocused(fm *FocusManager, focused bool) {
+	focusTextInput(fm.hwnd, ti, focused)
+}
+
 func (ti *textInput) Caret() int32 {
 	return ti.caretPos
 }
@@ -321,3 +561,172 @@ func (ti *textInput) Caret() int32 {
 func (ti *textInput) Selection() (int32, int32) {
 	return ti.selectionStart, ti.selectionEnd
 }
+
+func (ti *textInput) Undo() bool {
+	return undoTextInput(ti)
+}
+
+func (ti *textInput) Redo() bool {
+	return redoTextInput(ti)
+}
+
+func (ti *textInput) ClearHistory() {
+	ti.state.History.Clear()
+}
+
+func (ti *textInput) PasswordMask() rune {
+	return ti.passwordMask
+}
+
+func (ti *textInput) SetPasswordMask(mask rune) {
+	ti.passwordMask = mask
+	ti.syncMask()
+}
+
+func (ti *textInput) Revealed() bool {
+	return ti.revealed
+}
+
+func (ti *textInput) SetRevealed(revealed bool) {
+	ti.revealed = revealed
+	ti.syncMask()
+}
+
+func (ti *textInput) Placeholder() string {
+	return ti.placeholder.Text
+}
+
+func (ti *textInput) SetPlaceholder(placeholder string) {
+	ti.placeholder.Text = placeholder
+}
+
+func (ti *textInput) PlaceholderColor() *common.Color {
+	if ti.placeholder.Color != nil {
+		return ti.placeholder.Color
+	}
+	return &ti.Theme().Palette.TextDisabled
+}
+
+func (ti *textInput) SetPlaceholderColor(color *common.Color) {
+	ti.placeholder.Color = color
+}
+
+func (ti *textInput) Filter() *common.TextFilter {
+	return ti.state.Filter
+}
+
+func (ti *textInput) SetFilter(filter *common.TextFilter) {
+	ti.state.Filter = filter
+}
+
+func (ti *textInput) AddValidator(validate func(value string) error) {
+	ti.validators = append(ti.validators, validate)
+}
+
+func (ti *textInput) Validate() error {
+	var err error
+	for _, validate := range ti.validators {
+		if err = validate(ti.value); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		ti.SetError(err.Error())
+	} else if len(ti.validators) > 0 {
+		ti.SetError("")
+	}
+	return err
+}
+
+func (ti *textInput) Error() string {
+	return ti.errorMessage
+}
+
+func (ti *textInput) SetError(message string) {
+	if message == ti.errorMessage {
+		return
+	}
+	ti.errorMessage = message
+	event.Publish(ti.events, event.ValidationEvent{Source: ti.id, Message: message})
+}
+
+// Tooltip returns the error message while the value is invalid, and the tooltip that was set otherwise.
+func (ti *textInput) Tooltip() string {
+	if ti.errorMessage != "" {
+		return ti.errorMessage
+	}
+	return ti.tooltip
+}
+
+// themeState puts an enabled text input with an invalid value into the error state.
+func (ti *textInput) themeState() theme.State {
+	if ti.enabled && ti.errorMessage != "" {
+		return theme.StateError
+	}
+	return ti.baseComponent.themeState()
+}
+
+// scrollToCaret updates the horizontal scroll offset of a value wider than the text rectangle, scrolling just enough
+// to keep the caret and what an input method composes at it inside of it, and no further than the end of the value
+// needs. The backend maps clicks through it.
+//
+// Returns:
+//   - int32: The scroll offset in pixels.
+func (ti *textInput) scrollToCaret() int32 {
+	measure := func(text []rune) int32 {
+		w, _ := measureText(ti.Font(), ti.TextSize(), string(text))
+		return w
+	}
+	text := []rune(ti.state.DisplayText())
+	width := ti.state.TextRect().W
+	caretX := measure(text[:min(max(ti.caretPos, 0), int32(len(text)))])
+	preedit, _ := ti.preedit()
+	preeditW := measure([]rune(preedit))
+
+	// leave room for the 2 pixel wide caret after the last character
+	scroll := ti.state.Scroll.X
+	if caretX+preeditW+2 > scroll+width {
+		scroll = caretX + preeditW + 2 - width
+	}
+	scroll = min(scroll, caretX, max(measure(text)+preeditW+2-width, 0))
+	ti.state.Scroll.X = max(scroll, 0)
+	return ti.state.Scroll.X
+}
+
+// placeholderShown reports whether the placeholder is drawn in place of the empty value.
+func (ti *textInput) placeholderShown() bool {
+	return ti.placeholder.Text != "" && ti.value == "" && ti.state.Preedit == "" && (!ti.focused || ti.placeholder.WhileFocused)
+}
+
+// placeholderFont returns the font and text size the placeholder is drawn with.
+func (ti *textInput) placeholderFont() (string, int32) {
+	font, size := ti.placeholder.Font, ti.placeholder.TextSize
+	if font == "" {
+		font = ti.Font()
+	}
+	if size <= 0 {
+		size = ti.TextSize()
+	}
+	return font, size
+}
+
+// preedit returns what an input method is composing at the caret while the text input has the focus, masked like the
+// value, and the position of the input method's cursor inside of it.
+func (ti *textInput) preedit() (string, int32) {
+	preedit := ti.state.Preedit
+	if preedit == "" || !ti.focused {
+		return "", 0
+	}
+	if ti.state.Mask != 0 {
+		preedit = strings.Repeat(string(ti.state.Mask), len([]rune(preedit)))
+	}
+	return preedit, ti.state.PreeditCaret
+}
+
+// syncMask hands the mask in effect to the backend, which measures, copies and selects by it.
+func (ti *textInput) syncMask() {
+	ti.state.Mask = ti.passwordMask
+	if ti.revealed {
+		ti.state.Mask = 0
+	}
+}
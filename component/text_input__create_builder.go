@@ -1,6 +1,9 @@
 package component
 
-import "github.com/Carmen-Shannon/gooey/common"
+import (
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
+)
 
 type createTextInputOptions struct {
 	Value            string
@@ -10,20 +13,37 @@ type createTextInputOptions struct {
 	TextColor        *common.Color
 	TextSize         int32
 	TextAlignment    TextAlignment
+	UndoDepth        int
+	PasswordMask     rune
+	Placeholder      placeholderOptions
+	Filter           *common.TextFilter
+	Validators       []func(string) error
+	ErrorColor       *common.Color
 	ComponentOptions []CreateComponentOption
 }
 
+// placeholderOptions holds the hint text shown by an empty text input and its look.
+type placeholderOptions struct {
+	Text     string
+	Color    *common.Color
+	Font     string
+	TextSize int32
+	// WhileFocused keeps the placeholder up while the focused text input is still empty.
+	WhileFocused bool
+}
+
 type CreateTextInputOption func(*createTextInputOptions)
 
 func newCreateTextInputOptions() *createTextInputOptions {
 	return &createTextInputOptions{
 		Value:         "...",
 		MaxLength:     3,
-		Font:          "Arial",
-		Color:         &common.Color{Red: 255, Green: 255, Blue: 255},
-		TextColor:     &common.Color{Red: 0, Green: 0, Blue: 0},
-		TextSize:      12,
+		Font:          "",
+		Color:         nil,
+		TextColor:     nil,
+		TextSize:      0,
 		TextAlignment: CenterAlign,
+		UndoDepth:     textedit.DefaultHistoryDepth,
 	}
 }
 
@@ -111,6 +131,138 @@ func TextInputTextAlignmentOpt(textAlignment TextAlignment) CreateTextInputOptio
 	}
 }
 
+// TextInputUndoDepthOpt sets how many edits of the text input component can be undone.
+// Consecutive typing counts as a single edit, paste, cut and deletions count one each. The default is 100.
+//
+// Parameters:
+//   - depth: The maximum number of undo steps kept, 0 disables undo.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputUndoDepthOpt(depth int) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.UndoDepth = depth
+	}
+}
+
+// TextInputPasswordOpt turns the text input component into a password field, which draws maskRune in place of each
+// character of its value. A masked value cannot be copied or cut, SetRevealed toggles showing it.
+//
+// Parameters:
+//   - maskRune: The character to draw, 0 for DefaultPasswordMask.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputPasswordOpt(maskRune rune) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		if maskRune == 0 {
+			maskRune = DefaultPasswordMask
+		}
+		opts.PasswordMask = maskRune
+	}
+}
+
+// TextInputPlaceholderOpt sets the hint text the text input component shows while its value is empty.
+// The placeholder is only drawn, it is not part of the value and never copied.
+//
+// Parameters:
+//   - placeholder: The hint text.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputPlaceholderOpt(placeholder string) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Placeholder.Text = placeholder
+	}
+}
+
+// TextInputPlaceholderColorOpt sets the color of the placeholder of the text input component.
+// Without it the placeholder uses the theme's disabled text color.
+//
+// Parameters:
+//   - color: The color of the placeholder.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputPlaceholderColorOpt(color *common.Color) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Placeholder.Color = color
+	}
+}
+
+// TextInputPlaceholderFontOpt sets the font of the placeholder of the text input component.
+// Without it the placeholder is drawn in the font of the value.
+//
+// Parameters:
+//   - font: The name of the font, empty for the font of the value.
+//   - textSize: The size of the text in points, 0 for the size of the value.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputPlaceholderFontOpt(font string, textSize int32) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Placeholder.Font = font
+		opts.Placeholder.TextSize = textSize
+	}
+}
+
+// TextInputPlaceholderWhileFocusedOpt sets whether the placeholder of the text input component stays up while the
+// text input is focused, until the first character is typed. By default it disappears with the focus.
+//
+// Parameters:
+//   - whileFocused: True to keep showing the placeholder while focused.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputPlaceholderWhileFocusedOpt(whileFocused bool) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Placeholder.WhileFocused = whileFocused
+	}
+}
+
+// TextInputFilterOpt limits what typing and pasting may insert into the text input component, see common.FilterDigits,
+// common.FilterDecimal, common.FilterHex, common.RegexpFilter and common.RuneFilter.
+//
+// Parameters:
+//   - filter: The filter, nil to accept any text.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputFilterOpt(filter *common.TextFilter) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Filter = filter
+	}
+}
+
+// TextInputValidatorOpt adds a validator checking the whole value of the text input component whenever it changes and
+// when the text input loses focus. The first error puts the text input into its error state with the error's message.
+// Validators run in the order they were added, the validators of the binding package fit as well.
+//
+// Parameters:
+//   - validate: The function returning an error for values that are not acceptable.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputValidatorOpt(validate func(value string) error) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.Validators = append(opts.Validators, validate)
+	}
+}
+
+// TextInputErrorColorOpt sets the border color of the text input component while its value is invalid.
+// Without it the border uses the theme's error color.
+//
+// Parameters:
+//   - color: The color of the border in the error state.
+//
+// Returns:
+//   - CreateTextInputOption: A function that takes a pointer to createTextInputOptions
+func TextInputErrorColorOpt(color *common.Color) CreateTextInputOption {
+	return func(opts *createTextInputOptions) {
+		opts.ErrorColor = color
+	}
+}
+
 // TextInputComponentOptionsOpt sets additional component options for the text input component.
 // It takes a variadic list of CreateComponentOption and returns a CreateTextInputOption function.
 //
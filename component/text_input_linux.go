@@ -21,19 +21,30 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 	}
 	drawable := linux.C_Drawable(ctx.Hdc)
 
+	borderColor, highlightColor, caretColor := ti.(*textInput).themeColors()
+	borderColor, highlightColor, caretColor = fade(ctx, ti, borderColor), fade(ctx, ti, highlightColor), fade(ctx, ti, caretColor)
+
 	// Draw background
-	bg := ti.Color()
+	bg := fade(ctx, ti, ti.Color())
 	linux.XFillRect(display, drawable, int(x), int(y), int(w), int(h), bg)
 
-	// Draw border (simple sunken effect)
-	borderColor := &common.Color{Red: 180, Green: 180, Blue: 180}
+	// Draw border
 	linux.XDrawRect(display, drawable, int(x), int(y), int(w), int(h), borderColor)
 
 	// Draw selection highlight if any
-	text := ti.Value()
+	text := ti.(*textInput).state.DisplayText()
 	fontName := ti.Font()
 	fontSize := int(ti.TextSize())
-	textColor := ti.TextColor()
+	textColor := fade(ctx, ti, ti.TextColor())
+
+	// the text scrolls so the caret stays visible, everything past the padding is clipped
+	textRectX := int(x) + 4
+	textRectY := int(y) + 2
+	textRectW := int(w) - 8
+	textRectH := int(h) - 4
+	originX := textRectX - int(ti.(*textInput).scrollToCaret())
+	linux.PushClipRect(drawable, int32(textRectX), int32(textRectY), int32(textRectW), int32(textRectH))
+	defer linux.PopClipRect(drawable)
 
 	selStart, selEnd := ti.Selection()
 	if selStart > selEnd {
@@ -49,39 +60,69 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 		prefixWidth := linux.XTextWidth(display, drawable, fontName, fontSize, prefixStr)
 		highlightWidth := linux.XTextWidth(display, drawable, fontName, fontSize, highlightStr)
 
-		highlightColor := &common.Color{Red: 120, Green: 160, Blue: 240}
-		linux.XFillRect(display, drawable, int(x)+4+prefixWidth, int(y)+2, highlightWidth, int(h)-4, highlightColor)
+		linux.XFillRect(display, drawable, originX+prefixWidth, textRectY, highlightWidth, textRectH, highlightColor)
 	}
 
 	// Draw text
-	textRectX := int(x) + 4
-	textRectY := int(y) + 2
-	textRectW := int(w) - 8
-	textRectH := int(h) - 4
-	linux.XDrawTextRect(display, drawable, textRectX, textRectY, textRectW, textRectH, fontName, fontSize, text, textColor, linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+	linux.XDrawTextRect(display, drawable, originX, textRectY, textRectX+textRectW-originX, textRectH, fontName, fontSize, text, textColor, linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+
+	// Draw the placeholder in place of an empty value
+	if ti.(*textInput).placeholderShown() {
+		phFont, phSize := ti.(*textInput).placeholderFont()
+		linux.XDrawTextRect(display, drawable, textRectX, textRectY, textRectW, textRectH, phFont, int(phSize), ti.Placeholder(), fade(ctx, ti, ti.PlaceholderColor()), linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+	}
+
+	// Draw what an input method is composing over the text at the caret, the caret moves to the input method's cursor
+	runes := []rune(text)
+	caretPos := min(max(int(ti.Caret()), 0), len(runes))
+	caretX := originX
+	if caretPos > 0 {
+		caretX += linux.XTextWidth(display, drawable, fontName, fontSize, string(runes[:caretPos]))
+	}
+	preedit, preeditCaret := ti.(*textInput).preedit()
+	if preedit != "" {
+		line := common.Rect{X: int32(caretX), Y: int32(textRectY), H: int32(textRectH)}
+		caretX = int(drawPreedit(ctx, line, fontName, int32(fontSize), preedit, preeditCaret, textColor, bg))
+	}
 
 	// Draw caret if focused and no selection
-	if linux.CT.Visible && ti.Focused() && selStart == selEnd {
-		caretPos := int(ti.Caret())
-		runes := []rune(text)
-		if caretPos < 0 {
-			caretPos = 0
-		}
-		if caretPos > len(runes) {
-			caretPos = len(runes)
-		}
-		caretX := int(x) + 4
-		if caretPos > 0 {
-			caretWidth := linux.XTextWidth(display, drawable, fontName, fontSize, string(runes[:caretPos]))
-			caretX += caretWidth
-		}
+	if linux.CT.IsVisible() && ti.Focused() && (selStart == selEnd || preedit != "") {
 		caretHeight := int(float32(h) * 0.5)
 		caretY := int(y) + (int(h)-caretHeight)/2
-		caretColor := &common.Color{Red: 0, Green: 0, Blue: 0}
 		linux.XFillRect(display, drawable, caretX, caretY, 2, caretHeight, caretColor)
 	}
 }
 
+// drawPreedit draws what an input method is composing over the text at the caret, on the background color and underlined.
+//
+// Parameters:
+//   - ctx: The draw context of the window.
+//   - line: The line the composition is drawn on, starting at the caret. Its width is ignored.
+//   - fontName: The font of the text.
+//   - fontSize: The size of the text.
+//   - preedit: The composed text.
+//   - cursor: The position of the input method's cursor inside preedit, in characters.
+//   - textColor: The color of the text and its underline.
+//   - bg: The color covering the text underneath.
+//
+// Returns:
+//   - int32: The x-coordinate of the input method's cursor.
+func drawPreedit(ctx *common.DrawCtx, line common.Rect, fontName string, fontSize int32, preedit string, cursor int32, textColor, bg *common.Color) int32 {
+	display := linux.GetDisplay(ctx.Hwnd)
+	drawable := linux.C_Drawable(ctx.Hdc)
+	runes := []rune(preedit)
+	w := linux.XTextWidth(display, drawable, fontName, int(fontSize), preedit)
+	_, textH := measureText(fontName, fontSize, preedit)
+
+	linux.XFillRect(display, drawable, int(line.X), int(line.Y), w, int(line.H), bg)
+	linux.XDrawTextRect(display, drawable, int(line.X), int(line.Y), w+int(fontSize), int(line.H), fontName, int(fontSize), preedit, textColor, linux.ALIGN_LEFT|linux.ALIGN_VCENTER|linux.ALIGN_SINGLELINE)
+	underlineY := min(line.Y+(line.H+textH)/2, line.Y+line.H-1)
+	linux.XFillRect(display, drawable, int(line.X), int(underlineY), w, 1, textColor)
+
+	cursor = min(max(cursor, 0), int32(len(runes)))
+	return line.X + int32(linux.XTextWidth(display, drawable, fontName, int(fontSize), string(runes[:cursor])))
+}
+
 // registerTextInput registers the text input component with the windows package so the state can be tracked and updated while rendering or responding to events.
 //
 // Parameters:
@@ -89,3 +130,36 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 func registerTextInput(ti TextInput) {
 	linux.RegisterTextInputState(ti.ID(), ti.(*textInput).state)
 }
+
+// focusTextInput moves the backend's keyboard focus to or away from the text input.
+// The backend updates the text input state, which publishes the FocusEvent.
+//
+// Parameters:
+//   - hwnd: The handle of the window containing the text input.
+//   - ti: The TextInput component to focus or blur.
+//   - focused: True to focus the text input, false to blur it.
+func focusTextInput(hwnd uintptr, ti TextInput, focused bool) {
+	linux.FocusTextInput(hwnd, ti.ID(), focused)
+}
+
+// undoTextInput reverts the latest edit of the text input in the backend, which publishes the resulting events.
+//
+// Parameters:
+//   - ti: The TextInput component to undo an edit of.
+//
+// Returns:
+//   - bool: True if there was an edit to undo.
+func undoTextInput(ti TextInput) bool {
+	return linux.UndoTextInput(ti.ID())
+}
+
+// redoTextInput reapplies the latest undone edit of the text input in the backend, which publishes the resulting events.
+//
+// Parameters:
+//   - ti: The TextInput component to redo an edit of.
+//
+// Returns:
+//   - bool: True if there was an edit to redo.
+func redoTextInput(ti TextInput) bool {
+	return linux.RedoTextInput(ti.ID())
+}
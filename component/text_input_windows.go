@@ -6,6 +6,8 @@ package component
 import (
 	"github.com/Carmen-Shannon/gooey/common"
 	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+
+	"golang.org/x/sys/windows"
 )
 
 // drawTextInput handles drawing the text input component on the windows platform.
@@ -19,19 +21,26 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 	x, y := ti.Position()
 	w, h := ti.Size()
 
+	borderColor, highlightColor, caretColor := ti.(*textInput).themeColors()
+	borderColor, highlightColor, caretColor = fade(ctx, ti, borderColor), fade(ctx, ti, highlightColor), fade(ctx, ti, caretColor)
+
 	// Draw background
-	bg := ti.Color()
+	bg := fade(ctx, ti, ti.Color())
 	brush := wdws.CreateSolidBrush(bg)
 	oldBrush := wdws.SelectObject(ctx.Hdc, brush)
 	wdws.DrawRectangle(ctx.Hdc, x, y, x+w, y+h, 4)
 	wdws.SelectObject(ctx.Hdc, oldBrush)
 	wdws.DeleteObject(brush)
 
-	// Draw border (optional)
-	wdws.DrawEdge(ctx.Hdc, &[4]int32{x, y, x + w, y + h}, wdws.BD_EDGE_SUNKEN, wdws.BF_RECT)
+	// Draw border
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y, W: w, H: 1}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y + h - 1, W: w, H: 1}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x, Y: y, W: 1, H: h}, borderColor)
+	fillRect(ctx.Hdc, common.Rect{X: x + w - 1, Y: y, W: 1, H: h}, borderColor)
 
 	// Draw selection highlight if any
-	text := ti.Value()
+	text := ti.(*textInput).state.DisplayText()
+	runes := []rune(text)
 	font := wdws.CreateFont(-ti.TextSize(), ti.Font())
 	oldFont := wdws.SelectObject(ctx.Hdc, font)
 	defer func() {
@@ -40,26 +49,31 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 	}()
 
 	wdws.SetBkMode(ctx.Hdc, wdws.BK_TRANSPARENT)
-	wdws.SetTextColor(ctx.Hdc, ti.TextColor())
+	wdws.SetTextColor(ctx.Hdc, fade(ctx, ti, ti.TextColor()))
+
+	// the text scrolls so the caret stays visible, everything past the padding is clipped
+	originX := x + 4 - ti.(*textInput).scrollToCaret()
+	wdws.PushClipRect(ctx.Hdc, x+4, y+2, w-8, h-4)
+	defer wdws.PopClipRect(ctx.Hdc)
 
 	selStart, selEnd := ti.Selection()
 	if selStart > selEnd {
 		selStart, selEnd = selEnd, selStart
 	}
-	if selStart != selEnd && selStart >= 0 && selEnd <= int32(len(text)) {
-		prefix := text[:selStart]
-		highlight := text[selStart:selEnd]
+	if selStart != selEnd && selStart >= 0 && selEnd <= int32(len(runes)) {
+		prefix := string(runes[:selStart])
+		highlight := string(runes[selStart:selEnd])
 
 		prefixWidth, _ := wdws.MeasureText(ctx.Hdc, font, prefix)
 		highlightWidth, _ := wdws.MeasureText(ctx.Hdc, font, highlight)
 
 		highlightRect := [4]int32{
-			x + 4 + prefixWidth,
+			originX + prefixWidth,
 			y + 2,
-			x + 4 + prefixWidth + highlightWidth,
+			originX + prefixWidth + highlightWidth,
 			y + h - 2,
 		}
-		highlightBrush := wdws.CreateSolidBrush(&common.Color{Red: 120, Green: 160, Blue: 240})
+		highlightBrush := wdws.CreateSolidBrush(highlightColor)
 		oldHighlightBrush := wdws.SelectObject(ctx.Hdc, highlightBrush)
 		wdws.FillRect(ctx.Hdc, highlightRect, uintptr(highlightBrush))
 		wdws.SelectObject(ctx.Hdc, oldHighlightBrush)
@@ -67,30 +81,70 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 	}
 
 	// Draw text
-	textRect := [4]int32{x + 4, y + 2, x + w - 4, y + h - 2}
+	textRect := [4]int32{originX, y + 2, x + w - 4, y + h - 2}
 	wdws.DrawText(ctx.Hdc, text, &textRect, wdws.DT_LEFT|wdws.DT_VCENTER|wdws.DT_SINGLELINE)
 
+	// Draw the placeholder in place of an empty value
+	if ti.(*textInput).placeholderShown() {
+		phFontName, phSize := ti.(*textInput).placeholderFont()
+		phFont := wdws.CreateFont(-phSize, phFontName)
+		prevFont := wdws.SelectObject(ctx.Hdc, phFont)
+		wdws.SetTextColor(ctx.Hdc, fade(ctx, ti, ti.PlaceholderColor()))
+		wdws.DrawText(ctx.Hdc, ti.Placeholder(), &textRect, wdws.DT_LEFT|wdws.DT_VCENTER|wdws.DT_SINGLELINE|wdws.DT_NOPREFIX)
+		wdws.SelectObject(ctx.Hdc, prevFont)
+	}
+
+	// Draw what an input method is composing over the text at the caret, the caret moves to the input method's cursor
+	caretPos := min(max(int(ti.Caret()), 0), len(runes))
+	caretX := originX
+	if caretPos > 0 {
+		caretWidth, _ := wdws.MeasureText(ctx.Hdc, font, string(runes[:caretPos]))
+		caretX += caretWidth
+	}
+	preedit, preeditCaret := ti.(*textInput).preedit()
+	if preedit != "" {
+		wdws.SetTextColor(ctx.Hdc, fade(ctx, ti, ti.TextColor()))
+		line := common.Rect{X: caretX, Y: y + 2, H: h - 4}
+		caretX = drawPreedit(ctx, line, font, preedit, preeditCaret, fade(ctx, ti, ti.TextColor()), bg)
+	}
+
 	// Draw caret if focused
-	if ti.Focused() && wdws.CT.Visible && selStart == selEnd {
-		caretPos := int(ti.Caret())
-		runes := []rune(text)
-		if caretPos < 0 {
-			caretPos = 0
-		}
-		if caretPos > len(runes) {
-			caretPos = len(runes)
-		}
-		caretX := x + 4
-		if caretPos > 0 {
-			caretWidth, _ := wdws.MeasureText(ctx.Hdc, font, string(runes[:caretPos]))
-			caretX += caretWidth
-		}
+	if ti.Focused() && wdws.CT.IsVisible() && (selStart == selEnd || preedit != "") {
 		caretHeight := int32(float32(h) * 0.5)
 		caretY := y + (h-caretHeight)/2
-		wdws.DrawRectangle(ctx.Hdc, caretX, caretY, caretX+2, caretY+caretHeight, 0)
+		fillRect(ctx.Hdc, common.Rect{X: caretX, Y: caretY, W: 2, H: caretHeight}, caretColor)
 	}
 }
 
+// drawPreedit draws what an input method is composing over the text at the caret, on the background color and underlined.
+// The text is drawn in the font and text color selected into the device context.
+//
+// Parameters:
+//   - ctx: The draw context of the window.
+//   - line: The line the composition is drawn on, starting at the caret. Its width is ignored.
+//   - font: The font selected into the device context.
+//   - preedit: The composed text.
+//   - cursor: The position of the input method's cursor inside preedit, in characters.
+//   - textColor: The color of the underline.
+//   - bg: The color covering the text underneath.
+//
+// Returns:
+//   - int32: The x-coordinate of the input method's cursor.
+func drawPreedit(ctx *common.DrawCtx, line common.Rect, font windows.Handle, preedit string, cursor int32, textColor, bg *common.Color) int32 {
+	runes := []rune(preedit)
+	w, textH := wdws.MeasureText(ctx.Hdc, font, preedit)
+
+	fillRect(ctx.Hdc, common.Rect{X: line.X, Y: line.Y, W: w, H: line.H}, bg)
+	textRect := [4]int32{line.X, line.Y, line.X + w, line.Y + line.H}
+	wdws.DrawText(ctx.Hdc, preedit, &textRect, wdws.DT_LEFT|wdws.DT_VCENTER|wdws.DT_SINGLELINE|wdws.DT_NOCLIP|wdws.DT_NOPREFIX)
+	underlineY := min(line.Y+(line.H+textH)/2, line.Y+line.H-1)
+	fillRect(ctx.Hdc, common.Rect{X: line.X, Y: underlineY, W: w, H: 1}, textColor)
+
+	cursor = min(max(cursor, 0), int32(len(runes)))
+	cursorX, _ := wdws.MeasureText(ctx.Hdc, font, string(runes[:cursor]))
+	return line.X + cursorX
+}
+
 // registerTextInput registers the text input component with the windows package so the state can be tracked and updated while rendering or responding to events.
 //
 // Parameters:
@@ -98,3 +152,36 @@ func drawTextInput(ctx *common.DrawCtx, ti TextInput) {
 func registerTextInput(ti TextInput) {
 	wdws.RegisterTextInputState(ti.ID(), ti.(*textInput).state)
 }
+
+// focusTextInput moves the backend's keyboard focus to or away from the text input.
+// The backend updates the text input state, which publishes the FocusEvent.
+//
+// Parameters:
+//   - hwnd: The handle of the window containing the text input.
+//   - ti: The TextInput component to focus or blur.
+//   - focused: True to focus the text input, false to blur it.
+func focusTextInput(hwnd uintptr, ti TextInput, focused bool) {
+	wdws.FocusTextInput(hwnd, ti.ID(), focused)
+}
+
+// undoTextInput reverts the latest edit of the text input in the backend, which publishes the resulting events.
+//
+// Parameters:
+//   - ti: The TextInput component to undo an edit of.
+//
+// Returns:
+//   - bool: True if there was an edit to undo.
+func undoTextInput(ti TextInput) bool {
+	return wdws.UndoTextInput(ti.ID())
+}
+
+// redoTextInput reapplies the latest undone edit of the text input in the backend, which publishes the resulting events.
+//
+// Parameters:
+//   - ti: The TextInput component to redo an edit of.
+//
+// Returns:
+//   - bool: True if there was an edit to redo.
+func redoTextInput(ti TextInput) bool {
+	return wdws.RedoTextInput(ti.ID())
+}
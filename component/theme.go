@@ -0,0 +1,49 @@
+package component
+
+import "github.com/Carmen-Shannon/gooey/theme"
+
+// defaultTheme is used by components that are not part of a window with a theme.
+var defaultTheme = theme.Light()
+
+// ApplyTheme sets the theme of a top-level component. Nested components use the theme of their top-level component.
+// Layouts are re-computed, as fonts and spacing taken from the theme change the sizes of their children.
+// Windows call it for their components, it only needs to be called directly for components drawn outside of a window.
+//
+// Parameters:
+//   - c: The top-level component.
+//   - t: The theme to use, or nil for the default light theme.
+func ApplyTheme(c Component, t *theme.Theme) {
+	if b := baseOf(c); b != nil {
+		b.theme = t
+	}
+	if l, ok := c.(Layout); ok {
+		l.Relayout()
+	}
+}
+
+func (c *baseComponent) Theme() *theme.Theme {
+	root := c
+	for p := c.parent; p != nil; p = p.Parent() {
+		if b := baseOf(p); b != nil {
+			root = b
+		}
+	}
+	if root.theme != nil {
+		return root.theme
+	}
+	return defaultTheme
+}
+
+// themeState returns the interaction state shared by every component type, disabled taking precedence over focused.
+//
+// Returns:
+//   - theme.State: The state to look colors up with.
+func (c *baseComponent) themeState() theme.State {
+	if !c.enabled {
+		return theme.StateDisabled
+	}
+	if c.focused {
+		return theme.StateFocused
+	}
+	return theme.StateNormal
+}
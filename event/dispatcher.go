@@ -0,0 +1,115 @@
+package event
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Dispatcher delivers typed events to the listeners subscribed for that event type.
+// Every component owns a Dispatcher, and applications may create their own with NewDispatcher.
+//
+// A Dispatcher is safe for concurrent use. Listeners are called synchronously by Publish in the order
+// they subscribed, and no lock is held while they run, so a listener may subscribe, unsubscribe or publish.
+// Listeners run on the goroutine that publishes. The toolkit publishes every event on the event loop of the window,
+// so listeners of component events may update components directly.
+type Dispatcher struct {
+	mu        sync.Mutex
+	nextID    uint64
+	listeners map[reflect.Type][]listener
+}
+
+type listener struct {
+	id uint64
+	fn any
+}
+
+// Handle identifies a single subscription and is used to remove it again.
+// The zero Handle is valid and unsubscribing it does nothing.
+type Handle struct {
+	d  *Dispatcher
+	t  reflect.Type
+	id uint64
+}
+
+// NewDispatcher creates an empty Dispatcher.
+//
+// Returns:
+//   - *Dispatcher: A dispatcher without any listeners.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		listeners: make(map[reflect.Type][]listener),
+	}
+}
+
+// Subscribe registers fn to be called for every event of type T published on the dispatcher.
+// Any number of listeners can be subscribed to the same event type.
+//
+// Parameters:
+//   - d: The dispatcher to subscribe to.
+//   - fn: The function called with each published event.
+//
+// Returns:
+//   - Handle: The handle used to unsubscribe the listener.
+func Subscribe[T any](d *Dispatcher, fn func(T)) Handle {
+	if d == nil || fn == nil {
+		return Handle{}
+	}
+	t := reflect.TypeFor[T]()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.listeners == nil {
+		d.listeners = make(map[reflect.Type][]listener)
+	}
+	d.nextID++
+	d.listeners[t] = append(d.listeners[t], listener{id: d.nextID, fn: fn})
+	return Handle{d: d, t: t, id: d.nextID}
+}
+
+// Publish delivers the event to every listener subscribed for its type.
+// Publishing on a nil dispatcher or without listeners does nothing.
+//
+// Parameters:
+//   - d: The dispatcher to publish on.
+//   - ev: The event to deliver.
+func Publish[T any](d *Dispatcher, ev T) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	ls := slices.Clone(d.listeners[reflect.TypeFor[T]()])
+	d.mu.Unlock()
+
+	for _, l := range ls {
+		l.fn.(func(T))(ev)
+	}
+}
+
+// HasListeners reports whether any listener is subscribed for events of type T.
+//
+// Parameters:
+//   - d: The dispatcher to inspect.
+//
+// Returns:
+//   - bool: True if at least one listener is subscribed, false otherwise.
+func HasListeners[T any](d *Dispatcher) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.listeners[reflect.TypeFor[T]()]) > 0
+}
+
+// Unsubscribe removes the listener of the handle. Calling it more than once is safe.
+func (h Handle) Unsubscribe() {
+	if h.d == nil {
+		return
+	}
+	h.d.mu.Lock()
+	defer h.d.mu.Unlock()
+	h.d.listeners[h.t] = slices.DeleteFunc(h.d.listeners[h.t], func(l listener) bool {
+		return l.id == h.id
+	})
+}
@@ -0,0 +1,186 @@
+package event
+
+// ClickEvent is published when a component is activated, for example a button pressed and released with the left mouse button.
+type ClickEvent struct {
+	Source uintptr
+}
+
+// PressedEvent is published when the pressed state of a button changes.
+type PressedEvent struct {
+	Source  uintptr
+	Pressed bool
+}
+
+// ValueChangedEvent is published when the value of a component changes, either from user input or from code.
+type ValueChangedEvent[T any] struct {
+	Source uintptr
+	Old    T
+	New    T
+}
+
+// FocusEvent is published when a component gains or loses keyboard focus.
+type FocusEvent struct {
+	Source  uintptr
+	Focused bool
+}
+
+// CaretMovedEvent is published when the caret of a text component moves.
+type CaretMovedEvent struct {
+	Source uintptr
+	Pos    int32
+}
+
+// SelectionChangedEvent is published when the selected range of a text component changes.
+// Start may be larger than End when the selection was made backwards.
+type SelectionChangedEvent struct {
+	Source uintptr
+	Start  int32
+	End    int32
+}
+
+// ValidationEvent is published when the validation error of a text input changes.
+// Message is empty once the value is valid again.
+type ValidationEvent struct {
+	Source  uintptr
+	Message string
+}
+
+// BoundsChangedEvent is published when the backend moves or resizes a component, for example a selector being dragged.
+type BoundsChangedEvent struct {
+	Source uintptr
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// VisibilityChangedEvent is published when the backend shows or hides a component.
+type VisibilityChangedEvent struct {
+	Source  uintptr
+	Visible bool
+}
+
+// DrawingChangedEvent is published when a selector starts or stops drawing its capture rectangle.
+type DrawingChangedEvent struct {
+	Source  uintptr
+	Drawing bool
+}
+
+// BlockingChangedEvent is published when a selector starts or stops blocking input to the rest of the screen.
+type BlockingChangedEvent struct {
+	Source   uintptr
+	Blocking bool
+}
+
+// ScrollEvent is published when the scroll offset of a scroll view or text area changes.
+type ScrollEvent struct {
+	Source uintptr
+	X      int32
+	Y      int32
+}
+
+// MouseDownEvent is published when a mouse button is pressed over a component.
+// X and Y are relative to the component's position, WindowX and WindowY to the window.
+// ClickCount is 1 for a single click, 2 for the second press of a double click and so on.
+type MouseDownEvent struct {
+	Source     uintptr
+	X          int32
+	Y          int32
+	WindowX    int32
+	WindowY    int32
+	Button     MouseButton
+	Modifiers  Modifiers
+	ClickCount int
+}
+
+// MouseUpEvent is published to the component that received the MouseDownEvent when the button is released,
+// even if the pointer left the component in the meantime.
+// X and Y are relative to the component's position, WindowX and WindowY to the window.
+type MouseUpEvent struct {
+	Source     uintptr
+	X          int32
+	Y          int32
+	WindowX    int32
+	WindowY    int32
+	Button     MouseButton
+	Modifiers  Modifiers
+	ClickCount int
+}
+
+// MouseMoveEvent is published when the pointer moves over a component, or anywhere while a button pressed over it is held.
+// Button is the held button in the latter case and MouseButtonNone otherwise.
+// X and Y are relative to the component's position, WindowX and WindowY to the window.
+type MouseMoveEvent struct {
+	Source    uintptr
+	X         int32
+	Y         int32
+	WindowX   int32
+	WindowY   int32
+	Button    MouseButton
+	Modifiers Modifiers
+}
+
+// MouseEnterEvent is published when the pointer moves onto a component.
+// A component nested in a container is entered together with the container.
+type MouseEnterEvent struct {
+	Source    uintptr
+	X         int32
+	Y         int32
+	WindowX   int32
+	WindowY   int32
+	Modifiers Modifiers
+}
+
+// MouseLeaveEvent is published when the pointer moves off a component or out of the window.
+type MouseLeaveEvent struct {
+	Source    uintptr
+	X         int32
+	Y         int32
+	WindowX   int32
+	WindowY   int32
+	Modifiers Modifiers
+}
+
+// DoubleClickEvent is published after the MouseDownEvent of the second press of a double click.
+type DoubleClickEvent struct {
+	Source    uintptr
+	X         int32
+	Y         int32
+	WindowX   int32
+	WindowY   int32
+	Button    MouseButton
+	Modifiers Modifiers
+}
+
+// WheelEvent is published when the mouse wheel is turned over a component.
+// DeltaX and DeltaY are measured in WheelDelta units per notch, positive values scroll right and down.
+// X and Y are relative to the component's position, WindowX and WindowY to the window.
+type WheelEvent struct {
+	Source    uintptr
+	X         int32
+	Y         int32
+	WindowX   int32
+	WindowY   int32
+	DeltaX    int32
+	DeltaY    int32
+	Modifiers Modifiers
+}
+
+// KeyDownEvent is published when a key is pressed while a component or window receives keyboard input.
+// Repeat is true for the events generated while the key is held down.
+type KeyDownEvent struct {
+	Source    uintptr
+	Key       Key
+	Modifiers Modifiers
+	Repeat    bool
+}
+
+// KeyUpEvent is published when a key is released while a component or window receives keyboard input.
+type KeyUpEvent struct {
+	Source    uintptr
+	Key       Key
+	Modifiers Modifiers
+}
+
+// WheelDelta is the wheel movement reported for a single notch of the mouse wheel.
+const WheelDelta = 120
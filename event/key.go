@@ -0,0 +1,166 @@
+package event
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Key identifies a key on the keyboard independently of the platform it was pressed on.
+// Keys name the physical key rather than the character it produces, so Shift+1 is reported as Key1 with ModShift.
+type Key int
+
+const (
+	KeyUnknown Key = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+
+	KeyA
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+
+	Key0
+	Key1
+	Key2
+	Key3
+	Key4
+	Key5
+	Key6
+	Key7
+	Key8
+	Key9
+
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+
+	KeyEscape
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyDelete
+	KeyInsert
+	KeySpace
+
+	KeyMinus
+	KeyEqual
+	KeyComma
+	KeyPeriod
+	KeySlash
+	KeySemicolon
+	KeyApostrophe
+	KeyLeftBracket
+	KeyRightBracket
+	KeyBackslash
+	KeyGrave
+
+	KeyShift
+	KeyCtrl
+	KeyAlt
+	KeySuper
+	KeyCapsLock
+)
+
+var keyNames = map[Key]string{
+	KeyUp: "Up", KeyDown: "Down", KeyLeft: "Left", KeyRight: "Right",
+	KeyPageUp: "PageUp", KeyPageDown: "PageDown", KeyHome: "Home", KeyEnd: "End",
+	KeyEscape: "Escape", KeyEnter: "Enter", KeyTab: "Tab", KeyBackspace: "Backspace",
+	KeyDelete: "Delete", KeyInsert: "Insert", KeySpace: "Space",
+	KeyMinus: "-", KeyEqual: "=", KeyComma: ",", KeyPeriod: ".", KeySlash: "/",
+	KeySemicolon: ";", KeyApostrophe: "'", KeyLeftBracket: "[", KeyRightBracket: "]",
+	KeyBackslash: "\\", KeyGrave: "`",
+	KeyShift: "Shift", KeyCtrl: "Ctrl", KeyAlt: "Alt", KeySuper: "Super", KeyCapsLock: "CapsLock",
+}
+
+// keyAliases holds the alternative spellings accepted by ParseKey.
+var keyAliases = map[string]Key{
+	"esc": KeyEscape, "return": KeyEnter, "del": KeyDelete, "ins": KeyInsert,
+	"pgup": KeyPageUp, "pgdn": KeyPageDown, "plus": KeyEqual,
+}
+
+// String returns the name of the key as accepted by ParseKey, for example "A", "F5" or "PageDown".
+func (k Key) String() string {
+	switch {
+	case k >= KeyA && k <= KeyZ:
+		return string(rune('A' + int(k-KeyA)))
+	case k >= Key0 && k <= Key9:
+		return string(rune('0' + int(k-Key0)))
+	case k >= KeyF1 && k <= KeyF12:
+		return "F" + strconv.Itoa(int(k-KeyF1)+1)
+	}
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// ParseKey returns the key with the given name, ignoring case.
+// It accepts the names returned by Key.String as well as a few common aliases such as "Esc" and "Return".
+//
+// Parameters:
+//   - name: The name of the key.
+//
+// Returns:
+//   - Key: The key, or KeyUnknown if the name is not recognized.
+func ParseKey(name string) Key {
+	lower := strings.ToLower(name)
+	if len(lower) == 1 {
+		switch c := lower[0]; {
+		case c >= 'a' && c <= 'z':
+			return KeyA + Key(c-'a')
+		case c >= '0' && c <= '9':
+			return Key0 + Key(c-'0')
+		}
+	}
+	if len(lower) > 1 && lower[0] == 'f' {
+		if n, err := strconv.Atoi(lower[1:]); err == nil && n >= 1 && n <= 12 {
+			return KeyF1 + Key(n-1)
+		}
+	}
+	if k, ok := keyAliases[lower]; ok {
+		return k
+	}
+	for k, n := range keyNames {
+		if strings.ToLower(n) == lower {
+			return k
+		}
+	}
+	return KeyUnknown
+}
@@ -0,0 +1,34 @@
+package event
+
+// MouseButton identifies a button of the mouse independently of the platform it was pressed on.
+type MouseButton int
+
+const (
+	MouseButtonNone MouseButton = iota
+	MouseButtonLeft
+	MouseButtonRight
+	MouseButtonMiddle
+	MouseButtonBack
+	MouseButtonForward
+)
+
+// Modifiers is a set of modifier keys held while an input event happened.
+type Modifiers int
+
+const (
+	ModShift Modifiers = 1 << iota
+	ModCtrl
+	ModAlt
+	ModSuper
+)
+
+// Has reports whether all of the given modifiers are held.
+//
+// Parameters:
+//   - mods: The modifiers to check for.
+//
+// Returns:
+//   - bool: True if every modifier in mods is held, false otherwise.
+func (m Modifiers) Has(mods Modifiers) bool {
+	return m&mods == mods
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shortcut is a key combined with the exact set of modifiers that must be held for it, for example Ctrl+Shift+P.
+type Shortcut struct {
+	Key       Key
+	Modifiers Modifiers
+}
+
+// ParseShortcut parses a shortcut written as modifiers and a key joined by "+", for example "Ctrl+S", "Ctrl+Shift+P" or "F5".
+// Modifier and key names are case-insensitive, "Control", "Option", "Cmd", "Meta" and "Win" are accepted as aliases.
+// A trailing "+" names the plus key itself, which shares its physical key with "=".
+//
+// Parameters:
+//   - s: The shortcut to parse.
+//
+// Returns:
+//   - Shortcut: The parsed shortcut.
+//   - error: An error if a part is not a known modifier or key, or the key is missing.
+func ParseShortcut(s string) (Shortcut, error) {
+	var sc Shortcut
+	trimmed := strings.TrimSpace(s)
+	parts := strings.Split(trimmed, "+")
+	if strings.HasSuffix(trimmed, "++") || trimmed == "+" {
+		parts = append(parts[:len(parts)-2], "plus")
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" && i == len(parts)-1 {
+			return Shortcut{}, fmt.Errorf("missing key in shortcut %q", s)
+		}
+		if i < len(parts)-1 {
+			mod, ok := parseModifier(part)
+			if !ok {
+				return Shortcut{}, fmt.Errorf("unknown modifier %q in shortcut %q", part, s)
+			}
+			sc.Modifiers |= mod
+			continue
+		}
+		sc.Key = ParseKey(part)
+		if sc.Key == KeyUnknown {
+			return Shortcut{}, fmt.Errorf("unknown key %q in shortcut %q", part, s)
+		}
+	}
+	return sc, nil
+}
+
+// Matches reports whether the key pressed with the given modifiers triggers the shortcut.
+//
+// Parameters:
+//   - key: The pressed key.
+//   - mods: The modifiers held while the key was pressed.
+//
+// Returns:
+//   - bool: True if the key and the modifiers match exactly, false otherwise.
+func (sc Shortcut) Matches(key Key, mods Modifiers) bool {
+	return sc.Key == key && sc.Modifiers == mods
+}
+
+// String formats the shortcut the way ParseShortcut accepts it, modifiers first in the order Ctrl, Alt, Shift, Super.
+func (sc Shortcut) String() string {
+	var b strings.Builder
+	for _, m := range []struct {
+		mod  Modifiers
+		name string
+	}{{ModCtrl, "Ctrl"}, {ModAlt, "Alt"}, {ModShift, "Shift"}, {ModSuper, "Super"}} {
+		if sc.Modifiers.Has(m.mod) {
+			b.WriteString(m.name)
+			b.WriteByte('+')
+		}
+	}
+	b.WriteString(sc.Key.String())
+	return b.String()
+}
+
+// parseModifier returns the modifier with the given name, ignoring case.
+func parseModifier(name string) (Modifiers, bool) {
+	switch strings.ToLower(name) {
+	case "ctrl", "control":
+		return ModCtrl, true
+	case "shift":
+		return ModShift, true
+	case "alt", "option":
+		return ModAlt, true
+	case "super", "cmd", "meta", "win":
+		return ModSuper, true
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Shortcut
+		// wantString is what String formats the shortcut as, parsing it again must give the same shortcut
+		wantString string
+		wantErr    string
+	}{
+		{in: "Ctrl+S", want: Shortcut{Key: KeyS, Modifiers: ModCtrl}, wantString: "Ctrl+S"},
+		{in: "ctrl+shift+p", want: Shortcut{Key: KeyP, Modifiers: ModCtrl | ModShift}, wantString: "Ctrl+Shift+P"},
+		{in: "Shift+Ctrl+P", want: Shortcut{Key: KeyP, Modifiers: ModCtrl | ModShift}, wantString: "Ctrl+Shift+P"},
+		{in: "F5", want: Shortcut{Key: KeyF5}, wantString: "F5"},
+		{in: " Alt + Enter ", want: Shortcut{Key: KeyEnter, Modifiers: ModAlt}, wantString: "Alt+Enter"},
+		{in: "Control+Option+Esc", want: Shortcut{Key: KeyEscape, Modifiers: ModCtrl | ModAlt}, wantString: "Ctrl+Alt+Escape"},
+		{in: "Cmd+Return", want: Shortcut{Key: KeyEnter, Modifiers: ModSuper}, wantString: "Super+Enter"},
+		{in: "Meta+Win+PgDn", want: Shortcut{Key: KeyPageDown, Modifiers: ModSuper}, wantString: "Super+PageDown"},
+		{in: "Ctrl+Alt+Shift+Super+Del", want: Shortcut{Key: KeyDelete, Modifiers: ModCtrl | ModAlt | ModShift | ModSuper}, wantString: "Ctrl+Alt+Shift+Super+Delete"},
+		{in: "Ctrl++", want: Shortcut{Key: KeyEqual, Modifiers: ModCtrl}, wantString: "Ctrl+="},
+		{in: " Ctrl++ ", want: Shortcut{Key: KeyEqual, Modifiers: ModCtrl}, wantString: "Ctrl+="},
+		{in: "Ctrl+Shift++", want: Shortcut{Key: KeyEqual, Modifiers: ModCtrl | ModShift}, wantString: "Ctrl+Shift+="},
+		{in: "+", want: Shortcut{Key: KeyEqual}, wantString: "="},
+		{in: "Ctrl+Plus", want: Shortcut{Key: KeyEqual, Modifiers: ModCtrl}, wantString: "Ctrl+="},
+		{in: "Ctrl+-", want: Shortcut{Key: KeyMinus, Modifiers: ModCtrl}, wantString: "Ctrl+-"},
+		{in: "Hyper+S", wantErr: `unknown modifier "Hyper"`},
+		{in: "Ctrl+Q+S", wantErr: `unknown modifier "Q"`},
+		{in: "Ctrl+Nope", wantErr: `unknown key "Nope"`},
+		{in: "Ctrl+F13", wantErr: `unknown key "F13"`},
+		{in: "Ctrl+", wantErr: "missing key"},
+		{in: "Ctrl+ ", wantErr: "missing key"},
+		{in: "", wantErr: "missing key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseShortcut(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseShortcut(%q) = %v, %v, want an error containing %q", tt.in, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseShortcut(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+			}
+			if s := got.String(); s != tt.wantString {
+				t.Errorf("String() = %q, want %q", s, tt.wantString)
+			}
+			if again, err := ParseShortcut(got.String()); err != nil || again != got {
+				t.Errorf("ParseShortcut(%q) = %+v, %v, want the round-tripped %+v", got.String(), again, err, got)
+			}
+		})
+	}
+}
+
+func TestShortcutMatches(t *testing.T) {
+	sc := Shortcut{Key: KeyS, Modifiers: ModCtrl}
+	tests := []struct {
+		key  Key
+		mods Modifiers
+		want bool
+	}{
+		{key: KeyS, mods: ModCtrl, want: true},
+		{key: KeyS, mods: ModCtrl | ModShift},
+		{key: KeyS},
+		{key: KeyA, mods: ModCtrl},
+	}
+	for _, tt := range tests {
+		if got := sc.Matches(tt.key, tt.mods); got != tt.want {
+			t.Errorf("Matches(%v, %v) = %v, want %v", tt.key, tt.mods, got, tt.want)
+		}
+	}
+}
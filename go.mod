@@ -2,4 +2,7 @@ module github.com/Carmen-Shannon/gooey
 
 go 1.24.2
 
-require golang.org/x/sys v0.33.0
+require (
+	golang.org/x/sys v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -8,7 +8,6 @@ package linux
 #include <X11/Xlib.h>
 #include <X11/Xatom.h>
 #include <X11/Xutil.h>
-#include <X11/cursorfont.h>
 #include <X11/extensions/Xrender.h>
 #include <stdlib.h>
 #define GO_FALSE 0
@@ -43,10 +42,13 @@ void set_client_message_event(
 import "C"
 import (
 	"runtime"
+	"strings"
 	"sync"
 	"unsafe"
 
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
 )
 
 type C_Window = C.Window
@@ -57,20 +59,25 @@ type C_char = C.char
 type C_KeySym = C.KeySym
 
 var (
-	displayMap          = make(map[uintptr]uintptr)
+	displayMap          = make(map[uintptr]*C.Display)
 	displayMapMu        sync.Mutex
+	measureDisplay      *C.Display
+	measureDisplayOnce  sync.Once
 	drawCallbackMap     = make(map[uintptr]func(hdc uintptr))
 	drawCallbackMu      sync.Mutex
+	resizeCallbackMap   = make(map[uintptr]func(width, height int32))
+	resizeCallbackMu    sync.Mutex
+	clipStackMap        = make(map[C.Drawable][]common.Rect)
+	clipStackMu         sync.Mutex
 	resizingState       = make(map[uintptr]bool)
 	resizingStateMu     sync.Mutex
-	customCursorDraw    = false
-	customCursorDrawMu  sync.Mutex
 	wdwColorMap         = make(map[uintptr]common.Color)
 	wdwColorMapMu       sync.Mutex
 	buttonBoundsMap     = make(map[uintptr][4]int32)
 	buttonBoundsMapMu   sync.Mutex
-	buttonCbMap         = make(map[uintptr]map[string]func(any))
-	buttonCbMapMu       sync.Mutex
+	buttonEventsMap     = make(map[uintptr]*event.Dispatcher)
+	buttonPressedMap    = make(map[uintptr]bool)
+	buttonEventsMapMu   sync.Mutex
 	textInputStateMap   = make(map[uintptr]*common.TextInputState)
 	textInputStateMapMu sync.Mutex
 	selectorStateMap    = make(map[uintptr]*common.SelectorState)
@@ -79,6 +86,8 @@ var (
 	overlay          *selectorOverlay
 	fallbackSelector *fallbackSelectorState
 	argbSelector     *argbOverlay
+	// selectorOverlayMu guards argbSelector, which the overlay thread and the window's event loop both use.
+	selectorOverlayMu sync.Mutex
 
 	// Caret Ticker \\
 	CT   = common.NewCaretTicker()
@@ -99,6 +108,9 @@ const (
 	C_BUTTONRELEASE   = 5
 	C_MOTIONNOTIFY    = 6
 	C_KEYPRESS        = 2
+	C_KEYRELEASE      = 3
+	C_LEAVENOTIFY     = 8
+	C_CLIENTMESSAGE   = 33
 
 	// Text Alignment
 	ALIGN_LEFT       = 0
@@ -114,6 +126,7 @@ const (
 	ButtonPressMask     = 1 << 2
 	ButtonReleaseMask   = 1 << 3
 	PointerMotionMask   = 1 << 6
+	LeaveWindowMask     = 1 << 5
 	KeyPressMask        = 1 << 0
 	KeyReleaseMask      = 1 << 1
 
@@ -157,85 +170,131 @@ func init() {
 }
 
 func WindowProc(hwnd uintptr, display *C.Display, event *C.XEvent) bool {
+	if filterInputMethodEvent(hwnd, event) {
+		return true
+	}
 	switch EventType(event) {
 	case C_EXPOSE:
 		HandlePaint(hwnd, display)
 		return true
 	case C_CONFIGURENOTIFY:
+		configureEvent := (*C.XConfigureEvent)(unsafe.Pointer(event))
+		handleResize(hwnd, int32(configureEvent.width), int32(configureEvent.height))
 		HandlePaint(hwnd, display)
 		return true
 	case C_DESTROYNOTIFY:
+		closeInvokeQueue(hwnd)
+		closeInputMethod(hwnd)
+		forgetCursors(display)
+		forgetFonts(display)
 		XCloseDisplay(display)
 		UnregisterDisplay(hwnd)
 		return false
 	case C_KEYPRESS:
-		if HLTR.TextInputID != 0 {
+		input := keyInputFromEvent(common.KeyInputDown, (*C.XKeyEvent)(unsafe.Pointer(event)))
+		if handleKey(hwnd, input) {
+			return true
+		}
+		if HLTR.TextInputID == 0 {
 			keyEvent := (*C.XKeyEvent)(unsafe.Pointer(event))
-			ctrlDown := (keyEvent.state & C.ControlMask) != 0
 			var keysym C.KeySym
 			C.XLookupString(keyEvent, nil, 0, &keysym, nil)
-			switch keysym {
-			case XK_BACKSPACE:
-				handleTextInputBackspace(HLTR.TextInputID)
-			case XK_DELETE:
-				handleTextInputDelete(HLTR.TextInputID)
-			case 0x0063, 0x0043: // 'c' or 'C'
-				if ctrlDown {
-					handleTextInputCopy(HLTR.TextInputID)
-					return true
-				}
-			case 0x0076, 0x0056: // 'v' or 'V'
-				if ctrlDown {
-					handleTextInputPaste(HLTR.TextInputID)
-					return true
-				}
-			case 0x0078, 0x0058: // 'x' or 'X'
-				if ctrlDown {
-					handleTextInputCopy(HLTR.TextInputID)
-					handleTextInputBackspace(HLTR.TextInputID)
-					return true
-				}
-			default:
-				handleTextInputKeyPress(HLTR.TextInputID, hwnd, event, display)
-			}
+			handleScrollViewKey(keysym)
+			return true
+		}
+		if HLTR.TextInputID != 0 && !handleTextInputCommand(hwnd, HLTR.TextInputID, textedit.CommandForKey(input.Key, input.Modifiers)) {
+			handleTextInputKeyPress(HLTR.TextInputID, hwnd, event, display)
+		}
+		placeInputMethod(hwnd)
+		return true
+	case C_KEYRELEASE:
+		keyEvent := (*C.XKeyEvent)(unsafe.Pointer(event))
+		if !isAutoRepeatRelease(display, keyEvent) {
+			handleKey(hwnd, keyInputFromEvent(common.KeyInputUp, keyEvent))
 		}
 		return true
 	case C_BUTTONPRESS:
 		x, y := GetMouseState(hwnd)
-		btnId, btnFound := FindButtonAt(x, y)
-		handleButtonCallbacks(btnId, btnFound, true)
-		tiId, tiFound := FindTextInputAt(x, y)
-
 		ev := (*C.XButtonEvent)(unsafe.Pointer(event))
-		dblClk := isDoubleClick(hwnd, ev, x, y)
-		handleTextInputClickCallbacks(tiId, tiFound, hwnd, x, dblClk)
+		mods := x11Modifiers(ev.state)
+		if isWheelButton(ev.button) {
+			dx, dy := x11WheelDelta(ev.button, ev.state&C.ShiftMask != 0)
+			activateScrollViewAt(x, y)
+			handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputWheel, X: x, Y: y, Modifiers: mods, DeltaX: dx, DeltaY: dy})
+			return true
+		}
+		if ev.button == 1 {
+			btnId, btnFound := FindButtonAt(x, y)
+			handleButtonEvents(btnId, btnFound, true)
+			tiId, tiFound := FindTextInputAt(x, y)
+			if !tiFound && !btnFound {
+				activateScrollViewAt(x, y)
+			}
+
+			dblClk := isDoubleClick(hwnd, ev, x, y)
+			handleTextInputClickCallbacks(tiId, tiFound, hwnd, x, y, dblClk)
+		}
+		if ev.button == 2 {
+			if tiId, tiFound := FindTextInputAt(x, y); tiFound {
+				handleTextInputMiddleClick(hwnd, tiId, x, y)
+			}
+		}
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputDown, X: x, Y: y, Button: x11MouseButton(ev.button), Modifiers: mods})
 		return true
 	case C_BUTTONRELEASE:
 		x, y := GetMouseState(hwnd)
-		btnId, btnFound := FindButtonAt(x, y)
-		handleButtonCallbacks(btnId, btnFound, false)
-		tiId, tiFound := FindTextInputAt(x, y)
-		if tiFound && HLTR.TextInputID == tiId {
-			updateTextInputSelection(tiId, hwnd, x, "end")
-			handleTextInputCaretCallbacks(tiId)
+		ev := (*C.XButtonEvent)(unsafe.Pointer(event))
+		if isWheelButton(ev.button) {
+			return true
 		}
-		HLTR.Active = false
-		HLTR.SuppressSelection = false
+		if ev.button == 1 {
+			btnId, btnFound := FindButtonAt(x, y)
+			handleButtonEvents(btnId, btnFound, false)
+			selecting := HLTR.Active && HLTR.TextInputID != 0
+			tiId, tiFound := FindTextInputAt(x, y)
+			if tiFound && HLTR.TextInputID == tiId {
+				updateTextInputSelection(tiId, hwnd, x, y, "end")
+				handleTextInputCaretCallbacks(tiId)
+			}
+			if selecting {
+				// a selection made with the pointer becomes the PRIMARY selection once the button is released
+				publishPrimarySelection(HLTR.TextInputID)
+			}
+			HLTR.Active = false
+			HLTR.SuppressSelection = false
+		}
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputUp, X: x, Y: y, Button: x11MouseButton(ev.button), Modifiers: x11Modifiers(ev.state)})
 		return true
 	case C_MOTIONNOTIFY:
 		x, y := GetMouseState(hwnd)
+		ev := (*C.XMotionEvent)(unsafe.Pointer(event))
 		if HLTR.Active && HLTR.TextInputID != 0 && !HLTR.SuppressSelection {
 			tiId, tiFound := FindTextInputAt(x, y)
 			if tiFound && tiId == HLTR.TextInputID {
-				updateTextInputSelection(tiId, hwnd, x, "update")
+				updateTextInputSelection(tiId, hwnd, x, y, "update")
 			}
 		}
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputMove, X: x, Y: y, Modifiers: x11Modifiers(ev.state)})
+		return true
+	case C_CLIENTMESSAGE:
+		if isInvokeMessage(display, (*C.XClientMessageEvent)(unsafe.Pointer(event))) {
+			runInvoked(hwnd)
+		}
+		return true
+	case C_LEAVENOTIFY:
+		ev := (*C.XCrossingEvent)(unsafe.Pointer(event))
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputLeave, X: int32(ev.x), Y: int32(ev.y), Modifiers: x11Modifiers(ev.state)})
 		return true
 	default:
 	}
 	return true
 }
 
+// isWheelButton reports whether the X11 button number belongs to the mouse wheel rather than a physical button.
+func isWheelButton(button C.uint) bool {
+	return button >= 4 && button <= 7
+}
+
 func isDoubleClick(hwnd uintptr, event *C.XButtonEvent, x, y int32) bool {
 	clkTime := event.time
 
@@ -308,96 +367,16 @@ func HandlePaint(hwnd uintptr, display *C.Display) {
 }
 
 func handleTextInputKeyPress(id uintptr, hwnd uintptr, event *C.XEvent, display *C.Display) {
-	keyEvent := (*C.XKeyEvent)(unsafe.Pointer(event))
-	var buf [8]C.char
-	var keysym C.KeySym
-	n := C.XLookupString(keyEvent, &buf[0], 8, &keysym, nil)
-	if n <= 0 {
-		return
-	}
-	input := string(C.GoBytes(unsafe.Pointer(&buf[0]), n))
-	runes := []rune(input)
-	if len(runes) == 0 {
-		return
-	}
-	ch := runes[0]
-	if ch < 32 || ch == 127 {
-		return
-	}
-	handleTextInputChar(id, ch)
-}
-
-func handleTextInputChar(id uintptr, ch rune) {
-	if ch < 32 || ch == 127 {
-		return
-	}
-	state := GetTextInputState(id)
-	if state == nil {
-		return
-	}
-	runes := []rune(state.Value)
-	caret := HLTR.SelectionEnd
-	if caret < 0 {
-		caret = 0
-	}
-	if caret > int32(len(runes)) {
-		caret = int32(len(runes))
-	}
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
-	}
-	if start < 0 {
-		start = 0
-	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
-	}
-
-	var newVal string
-	var newCaret int32
-	if start != end {
-		newVal = string(runes[:start]) + string(ch) + string(runes[end:])
-		newCaret = start + int32(len([]rune(string(ch))))
-	} else {
-		newVal = string(runes[:caret]) + string(ch) + string(runes[caret:])
-		newCaret = caret + int32(len([]rune(string(ch))))
-	}
-
-	if state.MaxLength > 0 && int32(len([]rune(newVal))) > state.MaxLength {
+	text := strings.Map(func(ch rune) rune {
+		if ch < 32 || ch == 127 {
+			return -1
+		}
+		return ch
+	}, lookupKeyText(hwnd, (*C.XKeyEvent)(unsafe.Pointer(event))))
+	if text == "" {
 		return
 	}
-	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
-	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	if cb, ok := state.CbMap["value"]; ok {
-		cb(newVal)
-	}
-	if cb, ok := state.CbMap["caretPos"]; ok {
-		cb(newCaret)
-	}
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
-}
-
-// LoadArrowCursor returns the standard pointer cursor.
-func LoadArrowCursor(display *C.Display) C.Cursor {
-	return C.XCreateFontCursor(display, C.XC_left_ptr)
-}
-
-// LoadIBeamCursor returns the I-beam (text) cursor.
-func LoadIBeamCursor(display *C.Display) C.Cursor {
-	return C.XCreateFontCursor(display, C.XC_xterm)
-}
-
-// SetCursor sets the cursor for the given window.
-func SetCursor(display *C.Display, window C.Window, cursor C.Cursor) {
-	C.XDefineCursor(display, window, cursor)
-	C.XFlush(display)
+	handleTextInputText(id, text)
 }
 
 func XPending(display *C.Display) int {
@@ -479,9 +458,64 @@ func XMinimizeWindow(display *C.Display, window C.Window) {
 	C.XIconifyWindow(display, window, C.XDefaultScreen(display))
 }
 
+// PushClipRect restricts all following drawing on the drawable to the given rectangle.
+// The rectangle is intersected with any clip that is already active, and must be balanced with PopClipRect.
+//
+// Parameters:
+//   - drawable: The drawable being painted
+//   - x: The x-coordinate of the clip rectangle
+//   - y: The y-coordinate of the clip rectangle
+//   - w: The width of the clip rectangle
+//   - h: The height of the clip rectangle
+func PushClipRect(drawable C.Drawable, x, y, w, h int32) {
+	clipStackMu.Lock()
+	defer clipStackMu.Unlock()
+	rect := common.Rect{X: x, Y: y, W: w, H: h}
+	stack := clipStackMap[drawable]
+	if len(stack) > 0 {
+		rect = rect.Intersect(stack[len(stack)-1])
+	}
+	clipStackMap[drawable] = append(stack, rect)
+}
+
+// PopClipRect removes the clip rectangle pushed last by PushClipRect.
+//
+// Parameters:
+//   - drawable: The drawable being painted
+func PopClipRect(drawable C.Drawable) {
+	clipStackMu.Lock()
+	defer clipStackMu.Unlock()
+	stack := clipStackMap[drawable]
+	if len(stack) <= 1 {
+		delete(clipStackMap, drawable)
+		return
+	}
+	clipStackMap[drawable] = stack[:len(stack)-1]
+}
+
+// newGC creates a graphics context for the drawable with the active clip rectangle applied.
+// The caller is responsible for freeing the GC.
+func newGC(display *C.Display, drawable C.Drawable) C.GC {
+	gc := C.XCreateGC(display, drawable, 0, nil)
+	clipStackMu.Lock()
+	stack := clipStackMap[drawable]
+	clipStackMu.Unlock()
+	if len(stack) > 0 {
+		clip := stack[len(stack)-1]
+		rect := C.XRectangle{
+			x:      C.short(clip.X),
+			y:      C.short(clip.Y),
+			width:  C.ushort(max(0, clip.W)),
+			height: C.ushort(max(0, clip.H)),
+		}
+		C.XSetClipRectangles(display, gc, 0, 0, &rect, 1, C.Unsorted)
+	}
+	return gc
+}
+
 // Fill a rectangle with a color
 func XFillRect(display *C.Display, drawable C.Drawable, x, y, w, h int, color *common.Color) {
-	gc := C.XCreateGC(display, drawable, 0, nil)
+	gc := newGC(display, drawable)
 	defer C.XFreeGC(display, gc)
 	pixel := (uint32(color.Red) << 16) | (uint32(color.Green) << 8) | uint32(color.Blue)
 	C.XSetForeground(display, gc, C.ulong(pixel))
@@ -490,7 +524,7 @@ func XFillRect(display *C.Display, drawable C.Drawable, x, y, w, h int, color *c
 
 // Fill a rounded rectangle with a color
 func XFillRoundedRect(display *C.Display, drawable C.Drawable, x, y, w, h, radius int, color *common.Color) {
-	gc := C.XCreateGC(display, drawable, 0, nil)
+	gc := newGC(display, drawable)
 	defer C.XFreeGC(display, gc)
 	pixel := (uint32(color.Red) << 16) | (uint32(color.Green) << 8) | uint32(color.Blue)
 	C.XSetForeground(display, gc, C.ulong(pixel))
@@ -504,58 +538,116 @@ func XFillRoundedRect(display *C.Display, drawable C.Drawable, x, y, w, h, radiu
 
 // Draw centered text in a rectangle
 func XDrawTextCentered(display *C.Display, drawable C.Drawable, x, y, w, h int, fontName string, fontSize int, text string, color *common.Color) {
-	gc := C.XCreateGC(display, drawable, 0, nil)
+	gc := newGC(display, drawable)
 	defer C.XFreeGC(display, gc)
 	pixel := (uint32(color.Red) << 16) | (uint32(color.Green) << 8) | uint32(color.Blue)
 	C.XSetForeground(display, gc, C.ulong(pixel))
 
 	cstr := C.CString(text)
 	defer C.free(unsafe.Pointer(cstr))
-	fontStruct := C.XQueryFont(display, C.XGContextFromGC(gc))
+	fontStruct, release := textFont(display, gc, fontName, fontSize)
+	defer release()
 	if fontStruct != nil {
 		textWidth := C.XTextWidth(fontStruct, cstr, C.int(len(text)))
 		textX := x + (w-int(textWidth))/2
-		textY := y + h/2 + fontSize/2 // crude vertical centering
+		textY := baseline(fontStruct, y, h)
 		C.XDrawString(display, drawable, gc, C.int(textX), C.int(textY), cstr, C.int(len(text)))
 	}
 }
 
+// baseline returns the baseline centering a line of text of a font vertically in a rectangle.
+func baseline(fontStruct *C.XFontStruct, y, h int) int {
+	return y + (h-int(fontStruct.ascent+fontStruct.descent))/2 + int(fontStruct.ascent)
+}
+
 // XTextWidth measures the width of the text in pixels for the given font and size.
 func XTextWidth(display *C.Display, drawable C.Drawable, fontName string, fontSize int, text string) int {
-	cstr := C.CString(text)
-	defer C.free(unsafe.Pointer(cstr))
+	if fontStruct := loadFont(display, fontName, fontSize); fontStruct != nil {
+		return textWidth(fontStruct, text)
+	}
 	gc := C.XCreateGC(display, drawable, 0, nil)
 	defer C.XFreeGC(display, gc)
-	fontStruct := C.XQueryFont(display, C.XGContextFromGC(gc))
+	fontStruct, release := textFont(display, gc, fontName, fontSize)
+	defer release()
 	if fontStruct != nil {
-		return int(C.XTextWidth(fontStruct, cstr, C.int(len(text))))
+		return textWidth(fontStruct, text)
 	}
 	return 0
 }
 
+// textWidth returns the width of text in pixels drawn with a font.
+func textWidth(fontStruct *C.XFontStruct, text string) int {
+	cstr := C.CString(text)
+	defer C.free(unsafe.Pointer(cstr))
+	return int(C.XTextWidth(fontStruct, cstr, C.int(len(text))))
+}
+
+// MeasureText measures the width and height of the text in pixels without requiring a drawable.
+// It uses any registered window display, falling back to a private display when no window exists yet.
+// The text is measured with the font it is drawn with, the requested font or the default font if the server has none.
+func MeasureText(fontName string, fontSize int, text string) (int, int) {
+	display := anyDisplay()
+	if display == nil {
+		return len([]rune(text)) * fontSize / 2, fontSize
+	}
+	root := C.XDefaultRootWindow(display)
+	gc := C.XCreateGC(display, C.Drawable(root), 0, nil)
+	defer C.XFreeGC(display, gc)
+	fontStruct, release := textFont(display, gc, fontName, fontSize)
+	defer release()
+	if fontStruct == nil {
+		return len([]rune(text)) * fontSize / 2, fontSize
+	}
+	height := max(int(fontStruct.ascent+fontStruct.descent), fontSize)
+	return textWidth(fontStruct, text), height
+}
+
+// anyDisplay returns the display of any registered window, or a lazily opened private display used for measurement.
+func anyDisplay() *C.Display {
+	displayMapMu.Lock()
+	for _, display := range displayMap {
+		displayMapMu.Unlock()
+		return display
+	}
+	displayMapMu.Unlock()
+
+	measureDisplayOnce.Do(func() {
+		measureDisplay = C.XOpenDisplay(nil)
+	})
+	return measureDisplay
+}
+
 // XDrawTextRect draws text in a rectangle with alignment and word wrap.
 func XDrawTextRect(display *C.Display, drawable C.Drawable, x, y, w, h int, fontName string, fontSize int, text string, color *common.Color, format int) {
-	gc := C.XCreateGC(display, drawable, 0, nil)
+	gc := newGC(display, drawable)
 	defer C.XFreeGC(display, gc)
 	pixel := (uint32(color.Red) << 16) | (uint32(color.Green) << 8) | uint32(color.Blue)
 	C.XSetForeground(display, gc, C.ulong(pixel))
 
 	cstr := C.CString(text)
 	defer C.free(unsafe.Pointer(cstr))
+	fontStruct, release := textFont(display, gc, fontName, fontSize)
+	defer release()
 
 	// Alignment
-	textWidth := XTextWidth(display, drawable, fontName, fontSize, text)
+	var width int
+	if fontStruct != nil {
+		width = textWidth(fontStruct, text)
+	}
 	var textX, textY int
 	switch format & 3 { // ALIGN_LEFT, ALIGN_CENTER, ALIGN_RIGHT
 	case ALIGN_LEFT:
 		textX = x
 	case ALIGN_CENTER:
-		textX = x + (w-textWidth)/2
+		textX = x + (w-width)/2
 	case ALIGN_RIGHT:
-		textX = x + w - textWidth
+		textX = x + w - width
 	}
 	// Vertical alignment (simple)
 	textY = y + h/2 + fontSize/2
+	if fontStruct != nil {
+		textY = baseline(fontStruct, y, h)
+	}
 
 	// Word wrap not implemented here, but you could split text and draw multiple lines.
 	C.XDrawString(display, drawable, gc, C.int(textX), C.int(textY), cstr, C.int(len(text)))
@@ -563,7 +655,7 @@ func XDrawTextRect(display *C.Display, drawable C.Drawable, x, y, w, h int, font
 
 // Draw a rectangle border
 func XDrawRect(display *C.Display, drawable C.Drawable, x, y, w, h int, color *common.Color) {
-	gc := C.XCreateGC(display, drawable, 0, nil)
+	gc := newGC(display, drawable)
 	defer C.XFreeGC(display, gc)
 	pixel := (uint32(color.Red) << 16) | (uint32(color.Green) << 8) | uint32(color.Blue)
 	C.XSetForeground(display, gc, C.ulong(pixel))
@@ -620,16 +712,13 @@ func EventType(event *C_XEvent) int {
 func RegisterDisplay(hwnd uintptr, display *C.Display) {
 	displayMapMu.Lock()
 	defer displayMapMu.Unlock()
-	displayMap[hwnd] = uintptr(unsafe.Pointer(display))
+	displayMap[hwnd] = display
 }
 
 func GetDisplay(hwnd uintptr) *C.Display {
 	displayMapMu.Lock()
 	defer displayMapMu.Unlock()
-	if ptr, ok := displayMap[hwnd]; ok {
-		return (*C.Display)(unsafe.Pointer(ptr))
-	}
-	return nil
+	return displayMap[hwnd]
 }
 
 func UnregisterDisplay(hwnd uintptr) {
@@ -679,12 +768,13 @@ func findARGBVisual(display *C.Display, screen C.int) *C.XVisualInfo {
 	return nil
 }
 
-// Launch the ARGB selector overlay on a new thread
-func LaunchSelectorOverlayOnThread(sID uintptr) {
+// Launch the ARGB selector overlay on a new thread.
+// The selector's events are published on the event loop of the owner window, see UpdateSelectorStateFrom.
+func LaunchSelectorOverlayOnThread(owner, sID uintptr) {
 	go func() {
 		runtime.LockOSThread()
-		state := GetSelectorState(sID)
-		if state == nil {
+		state, ok := SelectorStateSnapshot(sID)
+		if !ok {
 			return
 		}
 
@@ -729,7 +819,9 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 		C.XMapRaised(display, win)
 		C.XFlush(display)
 
+		selectorOverlayMu.Lock()
 		argbSelector = &argbOverlay{display, win, screen, visual, colormap, true}
+		selectorOverlayMu.Unlock()
 
 		// Select input events
 		C.XSelectInput(display, win, C.ExposureMask|C.ButtonPressMask|C.ButtonReleaseMask|C.PointerMotionMask|C.KeyPressMask)
@@ -790,15 +882,15 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 		// Initial overlay (full screen, transparent)
 		drawOverlay(common.Rect{X: 0, Y: 0, W: int32(screenW), H: int32(screenH)}, common.ColorBlack, 0.0)
 
-		for argbSelector != nil && argbSelector.active {
+		for argbSelectorActive() {
 			var event C.XEvent
 			C.XNextEvent(display, &event)
 			eventType := int(*(*C.int)(unsafe.Pointer(&event)))
 
 			switch eventType {
 			case C.Expose:
-				currentState := GetSelectorState(sID)
-				if currentState != nil && currentState.Visible {
+				currentState, ok := SelectorStateSnapshot(sID)
+				if ok && currentState.Visible {
 					drawOverlay(currentState.Bounds, currentState.Color, currentState.Opacity)
 				}
 			case C.ButtonPress:
@@ -809,7 +901,7 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 					dragging = true
 					C.XGrabPointer(display, win, 1, C.ButtonPressMask|C.ButtonReleaseMask|C.PointerMotionMask,
 						C.GrabModeAsync, C.GrabModeAsync, C.None, C.None, C.CurrentTime)
-					UpdateSelectorState(sID, common.UpdateSelectorDrawing(true), common.UpdateSelectorBlocking(true))
+					UpdateSelectorStateFrom(owner, sID, common.UpdateSelectorDrawing(true), common.UpdateSelectorBlocking(true))
 					drawOverlay(common.Rect{X: startX, Y: startY, W: 0, H: 0}, state.Color, state.Opacity)
 				}
 			case C.MotionNotify:
@@ -827,7 +919,7 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 						rectH = -rectH
 					}
 					newBounds := common.Rect{X: rectX, Y: rectY, W: rectW, H: rectH}
-					UpdateSelectorState(sID, common.UpdateSelectorBounds(newBounds))
+					UpdateSelectorStateFrom(owner, sID, common.UpdateSelectorBounds(newBounds))
 					drawOverlay(newBounds, state.Color, state.Opacity)
 				}
 			case C.ButtonRelease:
@@ -846,28 +938,28 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 						rectH = -rectH
 					}
 					finalBounds := common.Rect{X: rectX, Y: rectY, W: rectW, H: rectH}
-					UpdateSelectorState(sID,
+					UpdateSelectorStateFrom(owner, sID,
 						common.UpdateSelectorBounds(finalBounds),
 						common.UpdateSelectorBlocking(false),
 						common.UpdateSelectorDrawing(false),
 						common.UpdateSelectorVisible(false),
 					)
 					drawOverlay(finalBounds, state.Color, state.Opacity)
-					argbSelector.active = false
+					deactivateARGBSelector()
 				}
 			case C.KeyPress:
 				keyEvent := (*C.XKeyEvent)(unsafe.Pointer(&event))
 				keysym := C.XLookupKeysym(keyEvent, 0)
 				if keysym == C.XK_Escape {
-					UpdateSelectorState(sID,
+					UpdateSelectorStateFrom(owner, sID,
 						common.UpdateSelectorBlocking(false),
 						common.UpdateSelectorDrawing(false),
 						common.UpdateSelectorVisible(false),
 					)
-					argbSelector.active = false
+					deactivateARGBSelector()
 				}
 			}
-			if argbSelector == nil || !argbSelector.active {
+			if !argbSelectorActive() {
 				break
 			}
 		}
@@ -875,7 +967,9 @@ func LaunchSelectorOverlayOnThread(sID uintptr) {
 		C.XDestroyWindow(display, win)
 		C.XFreeColormap(display, colormap)
 		C.XCloseDisplay(display)
+		selectorOverlayMu.Lock()
 		argbSelector = nil
+		selectorOverlayMu.Unlock()
 	}()
 }
 
@@ -1057,14 +1151,9 @@ func UpdateSelectorOverlay(x, y, w, h int32, color *common.Color, opacity float3
 }
 
 // DestroySelectorOverlay cleans up both windows in the fallback path.
+// The ARGB overlay is owned by its own thread, which is asked to stop and then destroys its window and display itself.
 func DestroySelectorOverlay() {
-	if argbSelector != nil {
-		C.XUnmapWindow(argbSelector.display, argbSelector.window)
-		C.XDestroyWindow(argbSelector.display, argbSelector.window)
-		C.XFreeColormap(argbSelector.display, argbSelector.colormap)
-		C.XCloseDisplay(argbSelector.display)
-		argbSelector = nil
-	}
+	deactivateARGBSelector()
 	if fallbackSelector != nil {
 		if fallbackSelector.selectorDrawn && fallbackSelector.selectorWin != 0 {
 			C.XUnmapWindow(fallbackSelector.display, fallbackSelector.selectorWin)
@@ -1078,7 +1167,28 @@ func DestroySelectorOverlay() {
 }
 
 func SelectorOverlayActive() bool {
-	return (argbSelector != nil && argbSelector.active) || (fallbackSelector != nil && fallbackSelector.active)
+	return argbSelectorActive() || (fallbackSelector != nil && fallbackSelector.active)
+}
+
+// argbSelectorActive reports whether the ARGB overlay thread is running and has not been asked to stop.
+func argbSelectorActive() bool {
+	selectorOverlayMu.Lock()
+	defer selectorOverlayMu.Unlock()
+	return argbSelector != nil && argbSelector.active
+}
+
+// deactivateARGBSelector asks the ARGB overlay thread to stop, waking it with an Expose event in case it waits for input.
+func deactivateARGBSelector() {
+	selectorOverlayMu.Lock()
+	defer selectorOverlayMu.Unlock()
+	if argbSelector == nil || !argbSelector.active {
+		return
+	}
+	argbSelector.active = false
+	var event C.XEvent
+	(*(*C.int)(unsafe.Pointer(&event))) = C.Expose
+	C.XSendEvent(argbSelector.display, argbSelector.window, 0, 0, &event)
+	C.XFlush(argbSelector.display)
 }
 
 // RegisterDrawCallback registers a callback function to be called when the window needs to be redrawn.
@@ -1107,6 +1217,34 @@ func getDrawCallback(hwnd uintptr) func(hdc uintptr) {
 	return drawCallbackMap[hwnd]
 }
 
+// RegisterResizeCallback registers a callback function to be called when the window changes size.
+// It takes a window handle and a callback function as parameters.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with the new width and height
+func RegisterResizeCallback(hwnd uintptr, cb func(width, height int32)) {
+	resizeCallbackMu.Lock()
+	defer resizeCallbackMu.Unlock()
+	resizeCallbackMap[hwnd] = cb
+}
+
+// handleResize invokes the resize callback registered for the window handle, if any.
+// ConfigureNotify is also delivered for moves, so the callback is responsible for ignoring unchanged sizes.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - width: The new width of the window
+//   - height: The new height of the window
+func handleResize(hwnd uintptr, width, height int32) {
+	resizeCallbackMu.Lock()
+	cb := resizeCallbackMap[hwnd]
+	resizeCallbackMu.Unlock()
+	if cb != nil && width > 0 && height > 0 {
+		cb(width, height)
+	}
+}
+
 // SetResizingState sets the resizing state for a window handle.
 //
 // Parameters:
@@ -1159,6 +1297,45 @@ func GetWindowColor(hwnd uintptr) *common.Color {
 	return &color
 }
 
+// UnregisterComponent forgets everything the backend tracks for a component removed from its window, so the bounds
+// it was last drawn at are no longer hit-tested and a removed text input cannot keep the keyboard focus.
+// Adding the component to a window registers it again, so a component added back later works as before.
+//
+// Parameters:
+//   - componentID: The ID of the removed component
+func UnregisterComponent(componentID uintptr) {
+	buttonBoundsMapMu.Lock()
+	delete(buttonBoundsMap, componentID)
+	buttonBoundsMapMu.Unlock()
+
+	buttonEventsMapMu.Lock()
+	delete(buttonEventsMap, componentID)
+	delete(buttonPressedMap, componentID)
+	buttonEventsMapMu.Unlock()
+
+	textInputStateMapMu.Lock()
+	delete(textInputStateMap, componentID)
+	textInputStateMapMu.Unlock()
+	if HLTR.TextInputID == componentID {
+		HLTR.TextInputID = 0
+		HLTR.Active = false
+		HLTR.SelectionStart = 0
+		HLTR.SelectionEnd = 0
+		CT.Stop()
+	}
+
+	selectorStateMapMu.Lock()
+	delete(selectorStateMap, componentID)
+	selectorStateMapMu.Unlock()
+
+	scrollViewStateMapMu.Lock()
+	delete(scrollViewStateMap, componentID)
+	scrollViewStateMapMu.Unlock()
+	if activeScrollViewID == componentID {
+		activeScrollViewID = 0
+	}
+}
+
 // RegisterButtonBounds registers the bounds of a button control.
 // It is used to track the position and size of the button control.
 // This is useful for handling mouse events and determining if the button control is being interacted with.
@@ -1211,30 +1388,29 @@ func FindButtonAt(x, y int32) (componentID uintptr, found bool) {
 	return 0, false
 }
 
-// RegisterButtonCallback registers a callback function for button events.
-// It is used to handle button click events and other button-related actions.
+// RegisterButtonEvents registers the event dispatcher of a button.
+// The backend publishes PressedEvent and ClickEvent on it when the button is pressed and released with the mouse.
 //
 // Parameters:
 //   - componentID: The ID of the component associated with the button
-//   - cbMap: A map of callback functions for different button events
-func RegisterButtonCallback(componentID uintptr, cbMap map[string]func(any)) {
-	buttonCbMapMu.Lock()
-	defer buttonCbMapMu.Unlock()
-	buttonCbMap[componentID] = cbMap
+//   - events: The event dispatcher of the button
+func RegisterButtonEvents(componentID uintptr, events *event.Dispatcher) {
+	buttonEventsMapMu.Lock()
+	defer buttonEventsMapMu.Unlock()
+	buttonEventsMap[componentID] = events
 }
 
-// GetButtonCallback retrieves the callback function for a given component ID.
-// It is used to retrieve the callback function associated with a specific component ID.
+// GetButtonEvents retrieves the event dispatcher registered for a given component ID.
 //
 // Parameters:
 //   - componentID: The ID of the component
 //
 // Returns:
-//   - func(any): The callback function to be called when the button is clicked
-func GetButtonCallback(componentID uintptr) map[string]func(any) {
-	buttonCbMapMu.Lock()
-	defer buttonCbMapMu.Unlock()
-	return buttonCbMap[componentID]
+//   - *event.Dispatcher: The event dispatcher of the button, or nil if it is not registered
+func GetButtonEvents(componentID uintptr) *event.Dispatcher {
+	buttonEventsMapMu.Lock()
+	defer buttonEventsMapMu.Unlock()
+	return buttonEventsMap[componentID]
 }
 
 // RegisterTextInputState registers the state of a text input control.
@@ -1250,6 +1426,18 @@ func RegisterTextInputState(componentID uintptr, state *common.TextInputState) {
 	textInputStateMap[componentID] = state
 }
 
+// textInputIDs returns the IDs of all registered text input controls.
+// The IDs are copied so the caller can update the states without holding the map lock.
+func textInputIDs() []uintptr {
+	textInputStateMapMu.Lock()
+	defer textInputStateMapMu.Unlock()
+	ids := make([]uintptr, 0, len(textInputStateMap))
+	for id := range textInputStateMap {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // GetTextInputState retrieves the state of a text input control.
 // It is used to get the current state of the text input control, including its bounds and other properties.
 // This is useful for handling text input events and managing the state of the text input control.
@@ -1274,14 +1462,21 @@ func GetTextInputState(componentID uintptr) *common.TextInputState {
 //   - updates: A variadic number of update functions to modify the state
 func UpdateTextInputState(componentID uintptr, updates ...common.UpdateTextInputState) {
 	textInputStateMapMu.Lock()
-	defer textInputStateMapMu.Unlock()
 	state, ok := textInputStateMap[componentID]
 	if !ok {
+		textInputStateMapMu.Unlock()
 		return
 	}
 	for _, update := range updates {
 		update(state)
 	}
+	pending := state.TakeEvents()
+	textInputStateMapMu.Unlock()
+
+	// the events are published after the lock is released so listeners may query or update the state again
+	for _, publish := range pending {
+		publish()
+	}
 }
 
 // FindTextInputAt checks if a point (x, y) is within the bounds of any text input control.
@@ -1299,6 +1494,9 @@ func FindTextInputAt(x, y int32) (componentID uintptr, found bool) {
 	textInputStateMapMu.Lock()
 	defer textInputStateMapMu.Unlock()
 	for id, state := range textInputStateMap {
+		if state.Clip != nil && !state.Clip.Contains(x, y) {
+			continue
+		}
 		if x >= state.Bounds.X && x < state.Bounds.X+state.Bounds.Width &&
 			y >= state.Bounds.Y && y < state.Bounds.Y+state.Bounds.Height {
 			return id, true
@@ -1307,28 +1505,6 @@ func FindTextInputAt(x, y int32) (componentID uintptr, found bool) {
 	return 0, false
 }
 
-// SetCustomCursorDraw enables or disables custom cursor drawing.
-// It is used to control whether the custom cursor drawing logic should be applied.
-//
-// Parameters:
-//   - enabled: A boolean value indicating whether custom cursor drawing should be enabled
-func SetCustomCursorDraw(enabled bool) {
-	customCursorDrawMu.Lock()
-	defer customCursorDrawMu.Unlock()
-	customCursorDraw = enabled
-}
-
-// isCustomCursorDraw checks if custom cursor drawing is enabled.
-// It returns true if custom cursor drawing is enabled, false otherwise.
-//
-// Returns:
-//   - bool: true if custom cursor drawing is enabled, false otherwise
-func IsCustomCursorDraw() bool {
-	customCursorDrawMu.Lock()
-	defer customCursorDrawMu.Unlock()
-	return customCursorDraw
-}
-
 // RegisterSelectorState registers the state of a selector control.
 // It is used to track the state of the selector control, including its bounds and other properties.
 // This is useful for handling selector events and managing the state of the selector control.
@@ -1351,14 +1527,74 @@ func RegisterSelectorState(componentID uintptr, state *common.SelectorState) {
 //   - updates: A variadic number of update functions to modify the state
 func UpdateSelectorState(componentID uintptr, updates ...common.UpdateSelectorState) {
 	selectorStateMapMu.Lock()
-	defer selectorStateMapMu.Unlock()
 	state, ok := selectorStateMap[componentID]
 	if !ok {
+		selectorStateMapMu.Unlock()
+		return
+	}
+	for _, update := range updates {
+		update(state)
+	}
+	pending := state.TakeEvents()
+	selectorStateMapMu.Unlock()
+
+	// the events are published after the lock is released so listeners may query or update the state again
+	for _, publish := range pending {
+		publish()
+	}
+}
+
+// UpdateSelectorStateFrom applies updates made outside of the owner window's event loop, such as by the selector overlay's thread.
+// The state changes under the lock right away while the resulting events are published on the owner window's event loop,
+// so the selector's listeners never run concurrently with the rest of the UI.
+//
+// Parameters:
+//   - owner: The handle of the window the selector belongs to
+//   - componentID: The ID of the component associated with the selector control
+//   - updates: A variadic number of update functions to modify the state
+func UpdateSelectorStateFrom(owner, componentID uintptr, updates ...common.UpdateSelectorState) {
+	selectorStateMapMu.Lock()
+	state, ok := selectorStateMap[componentID]
+	if !ok {
+		selectorStateMapMu.Unlock()
 		return
 	}
 	for _, update := range updates {
 		update(state)
 	}
+	pending := state.TakeEvents()
+	selectorStateMapMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	publish := func() {
+		for _, p := range pending {
+			p()
+		}
+	}
+	if !PostInvoke(owner, publish) {
+		publish()
+	}
+}
+
+// SelectorStateSnapshot returns a copy of the state of a selector control taken under the lock.
+// Unlike GetSelectorState the copy is safe to read while another thread updates the state.
+//
+// Parameters:
+//   - componentID: The ID of the component associated with the selector control
+//
+// Returns:
+//   - common.SelectorState: A copy of the selector state
+//   - bool: True if a selector with the ID is registered, false otherwise
+func SelectorStateSnapshot(componentID uintptr) (common.SelectorState, bool) {
+	selectorStateMapMu.Lock()
+	defer selectorStateMapMu.Unlock()
+	state, ok := selectorStateMap[componentID]
+	if !ok {
+		return common.SelectorState{}, false
+	}
+	return state.Snapshot(), true
 }
 
 // GetSelectorState retrieves the state of a selector control.
@@ -0,0 +1,537 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/Xatom.h>
+#include <poll.h>
+#include <stdlib.h>
+
+// gooey_wait_readable blocks until the X server sent something or timeout_ms passed.
+static void gooey_wait_readable(Display *display, int timeout_ms) {
+    struct pollfd fd = { ConnectionNumber(display), POLLIN, 0 };
+    poll(&fd, 1, timeout_ms);
+}
+*/
+import "C"
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Selection names one of the X11 selections data is exchanged between applications through.
+type Selection int
+
+const (
+	// SelectionClipboard is the clipboard, written by copying and read by pasting.
+	SelectionClipboard Selection = iota
+	// SelectionPrimary holds the most recently selected text, pasted with the middle mouse button.
+	SelectionPrimary
+)
+
+var (
+	// ErrSelectionEmpty is returned when no application owns a selection or its owner cannot convert it to the requested target.
+	ErrSelectionEmpty = errors.New("selection holds no data of the requested type")
+	// ErrSelectionTimeout is returned when the owner of a selection did not hand over its data in time.
+	ErrSelectionTimeout = errors.New("selection owner did not respond")
+	// ErrSelectionUnavailable is returned when there is no X display to exchange selections through, or the
+	// process could not take ownership of a selection.
+	ErrSelectionUnavailable = errors.New("selection is unavailable")
+
+	selectionsOnce sync.Once
+	selectionsInst *selectionOwner
+)
+
+const (
+	// selectionTimeout is how long reading a selection waits for its owner, also between the chunks of a large transfer.
+	selectionTimeout = 2 * time.Second
+	// selectionPropertyName is the property of the selection window conversions are delivered to.
+	selectionPropertyName = "GOOEY_SELECTION"
+)
+
+// selectionOwner owns the selections of the process through a hidden window on a display connection of its own,
+// so they are served while the application's windows are busy, and after they closed.
+type selectionOwner struct {
+	display *C.Display
+	window  C.Window
+	// chunk is the most data put into a property at once, larger data is transferred incrementally
+	chunk int
+
+	clipboard  C.Atom
+	targets    C.Atom
+	incr       C.Atom
+	text       C.Atom
+	utf8String C.Atom
+	property   C.Atom
+
+	mu    sync.Mutex
+	atoms map[string]C.Atom
+	// owned holds the data of each selection the process owns, by target
+	owned map[C.Atom]map[C.Atom][]byte
+	// outgoing are the incremental transfers to other applications in progress
+	outgoing map[incrKey]*incrTransfer
+	// incoming is the conversion GetSelection waits for, nil when it waits for none
+	incoming *selectionTransfer
+
+	// getMu lets one conversion at a time use the property of the selection window
+	getMu sync.Mutex
+}
+
+// incrKey identifies an incremental transfer by the window and property it is written to.
+type incrKey struct {
+	window   C.Window
+	property C.Atom
+}
+
+// incrTransfer is the rest of the data of an incremental transfer, sent a chunk each time the requestor deleted the last.
+type incrTransfer struct {
+	typ  C.Atom
+	data []byte
+}
+
+// selectionTransfer is a conversion of another application's selection into the property of the selection window.
+type selectionTransfer struct {
+	selection C.Atom
+	target    C.Atom
+	// incr is set once the owner announced an incremental transfer, whose chunks are collected in data
+	incr bool
+	data []byte
+	// progress receives a value with every chunk, so a large transfer only times out when it stalls
+	progress chan struct{}
+	done     chan selectionResult
+}
+
+type selectionResult struct {
+	data []byte
+	err  error
+}
+
+// finish hands the result of the transfer to GetSelection, a transfer finishes only once.
+func (t *selectionTransfer) finish(data []byte, err error) {
+	select {
+	case t.done <- selectionResult{data: data, err: err}:
+	default:
+	}
+}
+
+// selections returns the selection owner of the process, opening its display connection on first use.
+func selections() (*selectionOwner, error) {
+	selectionsOnce.Do(func() {
+		display := C.XOpenDisplay(nil)
+		if display == nil {
+			return
+		}
+		screen := C.XDefaultScreen(display)
+		window := C.XCreateSimpleWindow(display, C.XRootWindow(display, screen), 0, 0, 1, 1, 0, 0, 0)
+		C.XSelectInput(display, window, C.PropertyChangeMask)
+
+		o := &selectionOwner{
+			display:  display,
+			window:   window,
+			chunk:    int(C.XMaxRequestSize(display))*4 - 256,
+			atoms:    make(map[string]C.Atom),
+			owned:    make(map[C.Atom]map[C.Atom][]byte),
+			outgoing: make(map[incrKey]*incrTransfer),
+		}
+		o.clipboard = o.atom("CLIPBOARD")
+		o.targets = o.atom("TARGETS")
+		o.incr = o.atom("INCR")
+		o.text = o.atom("TEXT")
+		o.utf8String = o.atom("UTF8_STRING")
+		o.property = o.atom(selectionPropertyName)
+		go o.run()
+		selectionsInst = o
+	})
+	if selectionsInst == nil {
+		return nil, ErrSelectionUnavailable
+	}
+	return selectionsInst, nil
+}
+
+// SetSelectionText makes the process the owner of a selection holding text.
+// Other applications may read it as UTF8_STRING, TEXT, text/plain;charset=utf-8 or, with the characters outside of
+// Latin-1 replaced, as STRING.
+//
+// Parameters:
+//   - sel: The selection to own
+//   - text: The text of the selection
+//
+// Returns:
+//   - error: ErrSelectionUnavailable if the selection could not be taken
+func SetSelectionText(sel Selection, text string) error {
+	value := []byte(text)
+	latin1 := make([]byte, 0, len(text))
+	for _, ch := range text {
+		if ch > 0xff {
+			ch = '?'
+		}
+		latin1 = append(latin1, byte(ch))
+	}
+	return SetSelection(sel, map[string][]byte{
+		"UTF8_STRING":              value,
+		"TEXT":                     value,
+		"text/plain;charset=utf-8": value,
+		"STRING":                   latin1,
+	})
+}
+
+// GetSelectionText reads the text of a selection, as UTF8_STRING or, if its owner does not offer that, as STRING.
+// It blocks until the owner handed the text over.
+//
+// Parameters:
+//   - sel: The selection to read
+//
+// Returns:
+//   - string: The text of the selection
+//   - error: ErrSelectionEmpty if the selection holds no text, ErrSelectionTimeout or ErrSelectionUnavailable
+//     if it could not be read
+func GetSelectionText(sel Selection) (string, error) {
+	data, err := GetSelection(sel, "UTF8_STRING")
+	if errors.Is(err, ErrSelectionEmpty) {
+		if data, err = GetSelection(sel, "STRING"); err == nil {
+			runes := make([]rune, len(data))
+			for i, b := range data {
+				runes[i] = rune(b)
+			}
+			return string(runes), nil
+		}
+	}
+	return string(data), err
+}
+
+// SetSelection makes the process the owner of a selection holding data in one or more targets, such as
+// UTF8_STRING or image/png. The data is served to other applications until one of them takes the selection over.
+//
+// Parameters:
+//   - sel: The selection to own
+//   - data: The data of the selection by target name
+//
+// Returns:
+//   - error: ErrSelectionUnavailable if the selection could not be taken
+func SetSelection(sel Selection, data map[string][]byte) error {
+	o, err := selections()
+	if err != nil {
+		return err
+	}
+	targets := make(map[C.Atom][]byte, len(data))
+	for name, value := range data {
+		targets[o.atom(name)] = value
+	}
+	selection := o.selectionAtom(sel)
+
+	o.mu.Lock()
+	_, owned := o.owned[selection]
+	o.owned[selection] = targets
+	o.mu.Unlock()
+	if owned {
+		// still the owner, requests are served the new data without claiming the selection again
+		return nil
+	}
+
+	C.XSetSelectionOwner(o.display, selection, o.window, C.CurrentTime)
+	if C.XGetSelectionOwner(o.display, selection) != o.window {
+		o.mu.Lock()
+		delete(o.owned, selection)
+		o.mu.Unlock()
+		return ErrSelectionUnavailable
+	}
+	C.XFlush(o.display)
+	return nil
+}
+
+// GetSelection reads the data of a selection converted to a target, such as UTF8_STRING or image/png.
+// It blocks until the owner handed the data over, a selection the process owns itself is read right away.
+//
+// Parameters:
+//   - sel: The selection to read
+//   - target: The name of the target to convert the selection to
+//
+// Returns:
+//   - []byte: The data of the selection
+//   - error: ErrSelectionEmpty if nobody owns the selection or its owner cannot convert it to target,
+//     ErrSelectionTimeout or ErrSelectionUnavailable if it could not be read
+func GetSelection(sel Selection, target string) ([]byte, error) {
+	o, err := selections()
+	if err != nil {
+		return nil, err
+	}
+	selection, targetAtom := o.selectionAtom(sel), o.atom(target)
+
+	o.mu.Lock()
+	if data, ok := o.owned[selection]; ok {
+		value, ok := data[targetAtom]
+		o.mu.Unlock()
+		if !ok {
+			return nil, ErrSelectionEmpty
+		}
+		return append([]byte(nil), value...), nil
+	}
+	o.mu.Unlock()
+
+	o.getMu.Lock()
+	defer o.getMu.Unlock()
+	if C.XGetSelectionOwner(o.display, selection) == C.None {
+		return nil, ErrSelectionEmpty
+	}
+	t := &selectionTransfer{
+		selection: selection,
+		target:    targetAtom,
+		progress:  make(chan struct{}, 1),
+		done:      make(chan selectionResult, 1),
+	}
+	o.mu.Lock()
+	o.incoming = t
+	o.mu.Unlock()
+	defer func() {
+		o.mu.Lock()
+		o.incoming = nil
+		o.mu.Unlock()
+	}()
+
+	C.XConvertSelection(o.display, selection, targetAtom, o.property, o.window, C.CurrentTime)
+	C.XFlush(o.display)
+	timer := time.NewTimer(selectionTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case r := <-t.done:
+			return r.data, r.err
+		case <-t.progress:
+			timer.Reset(selectionTimeout)
+		case <-timer.C:
+			return nil, ErrSelectionTimeout
+		}
+	}
+}
+
+// selectionAtom returns the atom naming a selection.
+func (o *selectionOwner) selectionAtom(sel Selection) C.Atom {
+	if sel == SelectionPrimary {
+		return C.XA_PRIMARY
+	}
+	return o.clipboard
+}
+
+// atom returns the atom of a name, interning it on the display of the selection owner the first time.
+func (o *selectionOwner) atom(name string) C.Atom {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if a, ok := o.atoms[name]; ok {
+		return a
+	}
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	a := C.XInternAtom(o.display, cname, C.False)
+	o.atoms[name] = a
+	return a
+}
+
+// run is the event loop of the selection window, answering other applications and collecting conversions.
+func (o *selectionOwner) run() {
+	var event C.XEvent
+	for {
+		if C.XPending(o.display) == 0 {
+			// events read by calls on other goroutines are queued without waking poll, so it never waits for long
+			C.gooey_wait_readable(o.display, 50)
+			continue
+		}
+		C.XNextEvent(o.display, &event)
+		switch EventType(&event) {
+		case C.SelectionRequest:
+			o.handleRequest((*C.XSelectionRequestEvent)(unsafe.Pointer(&event)))
+		case C.SelectionClear:
+			ev := (*C.XSelectionClearEvent)(unsafe.Pointer(&event))
+			o.mu.Lock()
+			delete(o.owned, ev.selection)
+			o.mu.Unlock()
+		case C.SelectionNotify:
+			o.handleNotify((*C.XSelectionEvent)(unsafe.Pointer(&event)))
+		case C.PropertyNotify:
+			o.handlePropertyNotify((*C.XPropertyEvent)(unsafe.Pointer(&event)))
+		}
+	}
+}
+
+// handleRequest converts a selection the process owns for another application and tells it whether that worked.
+func (o *selectionOwner) handleRequest(req *C.XSelectionRequestEvent) {
+	property := req.property
+	if property == C.None {
+		// obsolete clients expect the data in a property named like the target
+		property = req.target
+	}
+
+	var reply C.XEvent
+	notify := (*C.XSelectionEvent)(unsafe.Pointer(&reply))
+	notify._type = C.SelectionNotify
+	notify.display = req.display
+	notify.requestor = req.requestor
+	notify.selection = req.selection
+	notify.target = req.target
+	notify.time = req.time
+	notify.property = C.None
+	if o.convert(req.requestor, property, req.selection, req.target) {
+		notify.property = property
+	}
+	C.XSendEvent(o.display, req.requestor, C.False, 0, &reply)
+	C.XFlush(o.display)
+}
+
+// convert writes a selection the process owns to the property of the requestor, announcing an incremental transfer
+// when the data is too large for a single request.
+//
+// Returns:
+//   - bool: True if the selection is owned and has the target
+func (o *selectionOwner) convert(requestor C.Window, property, selection, target C.Atom) bool {
+	o.mu.Lock()
+	data, ok := o.owned[selection]
+	o.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	if target == o.targets {
+		atoms := make([]C.Atom, 0, len(data)+1)
+		atoms = append(atoms, o.targets)
+		for t := range data {
+			atoms = append(atoms, t)
+		}
+		C.XChangeProperty(o.display, requestor, property, C.XA_ATOM, 32, C.PropModeReplace, (*C.uchar)(unsafe.Pointer(&atoms[0])), C.int(len(atoms)))
+		return true
+	}
+	value, ok := data[target]
+	if !ok {
+		return false
+	}
+	typ := target
+	if target == o.text {
+		typ = o.utf8String
+	}
+
+	if len(value) > o.chunk {
+		// the requestor deleting the INCR property asks for the first chunk
+		C.XSelectInput(o.display, requestor, C.PropertyChangeMask)
+		size := C.long(len(value))
+		C.XChangeProperty(o.display, requestor, property, o.incr, 32, C.PropModeReplace, (*C.uchar)(unsafe.Pointer(&size)), 1)
+		o.mu.Lock()
+		o.outgoing[incrKey{window: requestor, property: property}] = &incrTransfer{typ: typ, data: value}
+		o.mu.Unlock()
+		return true
+	}
+	o.changeProperty(requestor, property, typ, value)
+	return true
+}
+
+// changeProperty replaces a property of a window with 8-bit data.
+func (o *selectionOwner) changeProperty(window C.Window, property, typ C.Atom, value []byte) {
+	var ptr *C.uchar
+	if len(value) > 0 {
+		ptr = (*C.uchar)(unsafe.Pointer(&value[0]))
+	}
+	C.XChangeProperty(o.display, window, property, typ, 8, C.PropModeReplace, ptr, C.int(len(value)))
+}
+
+// handleNotify reads the property a conversion of another application's selection was delivered to.
+func (o *selectionOwner) handleNotify(ev *C.XSelectionEvent) {
+	o.mu.Lock()
+	t := o.incoming
+	o.mu.Unlock()
+	if t == nil || ev.selection != t.selection || ev.target != t.target {
+		return
+	}
+	if ev.property == C.None {
+		t.finish(nil, ErrSelectionEmpty)
+		return
+	}
+	// reading deletes the property, which asks the owner of an incremental transfer for the first chunk
+	typ, data, ok := o.readProperty(ev.property)
+	switch {
+	case !ok:
+		t.finish(nil, ErrSelectionEmpty)
+	case typ == o.incr:
+		t.incr = true
+	default:
+		t.finish(data, nil)
+	}
+}
+
+// handlePropertyNotify moves incremental transfers along: a chunk arrived for the selection window, or a requestor
+// deleted the chunk it was sent and is ready for the next.
+func (o *selectionOwner) handlePropertyNotify(ev *C.XPropertyEvent) {
+	if ev.window == o.window {
+		o.mu.Lock()
+		t := o.incoming
+		o.mu.Unlock()
+		if t == nil || !t.incr || ev.atom != o.property || ev.state != C.PropertyNewValue {
+			return
+		}
+		_, chunk, ok := o.readProperty(o.property)
+		switch {
+		case !ok:
+			t.finish(nil, ErrSelectionEmpty)
+		case len(chunk) == 0:
+			// an empty chunk ends the transfer
+			t.incr = false
+			t.finish(t.data, nil)
+		default:
+			t.data = append(t.data, chunk...)
+			select {
+			case t.progress <- struct{}{}:
+			default:
+			}
+		}
+		return
+	}
+
+	if ev.state != C.PropertyDelete {
+		return
+	}
+	key := incrKey{window: ev.window, property: ev.atom}
+	o.mu.Lock()
+	transfer, ok := o.outgoing[key]
+	o.mu.Unlock()
+	if !ok {
+		return
+	}
+	chunk := transfer.data[:min(len(transfer.data), o.chunk)]
+	transfer.data = transfer.data[len(chunk):]
+	o.changeProperty(ev.window, ev.atom, transfer.typ, chunk)
+	if len(chunk) == 0 {
+		o.mu.Lock()
+		delete(o.outgoing, key)
+		o.mu.Unlock()
+		C.XSelectInput(o.display, ev.window, C.NoEventMask)
+	}
+	C.XFlush(o.display)
+}
+
+// readProperty reads and deletes a property of the selection window.
+//
+// Returns:
+//   - C.Atom: The type of the property
+//   - []byte: The data of the property
+//   - bool: False if the property could not be read
+func (o *selectionOwner) readProperty(property C.Atom) (C.Atom, []byte, bool) {
+	var typ C.Atom
+	var format C.int
+	var items, after C.ulong
+	var value *C.uchar
+	if C.XGetWindowProperty(o.display, o.window, property, 0, 0x1fffffff, C.True, C.AnyPropertyType,
+		&typ, &format, &items, &after, &value) != C.Success {
+		return 0, nil, false
+	}
+	if value == nil {
+		return typ, nil, typ != C.None
+	}
+	defer C.XFree(unsafe.Pointer(value))
+	// 32-bit items are handed out as longs
+	size := int(format) / 8
+	if format == 32 {
+		size = int(unsafe.Sizeof(C.long(0)))
+	}
+	return typ, C.GoBytes(unsafe.Pointer(value), C.int(int(items)*size)), true
+}
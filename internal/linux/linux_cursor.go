@@ -0,0 +1,147 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11 -lXrender
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+#include <X11/cursorfont.h>
+#include <X11/extensions/Xrender.h>
+#include <stdlib.h>
+#include <string.h>
+
+// gooey_create_argb_cursor creates a cursor from premultiplied 0xAARRGGBB pixels in host byte order.
+static Cursor gooey_create_argb_cursor(Display *display, unsigned int *pixels, int width, int height, int hot_x, int hot_y) {
+    XRenderPictFormat *format = XRenderFindStandardFormat(display, PictStandardARGB32);
+    if (format == NULL) {
+        return None;
+    }
+    char *data = malloc((size_t)width * height * 4);
+    if (data == NULL) {
+        return None;
+    }
+    memcpy(data, pixels, (size_t)width * height * 4);
+    XImage *image = XCreateImage(display, NULL, 32, ZPixmap, 0, data, width, height, 32, width * 4);
+    if (image == NULL) {
+        free(data);
+        return None;
+    }
+    int one = 1;
+    image->byte_order = (*(char *)&one) ? LSBFirst : MSBFirst;
+
+    Pixmap pixmap = XCreatePixmap(display, DefaultRootWindow(display), width, height, 32);
+    GC gc = XCreateGC(display, pixmap, 0, NULL);
+    XPutImage(display, pixmap, gc, image, 0, 0, 0, 0, width, height);
+    XFreeGC(display, gc);
+    XDestroyImage(image);
+
+    Picture picture = XRenderCreatePicture(display, pixmap, format, 0, NULL);
+    Cursor cursor = XRenderCreateCursor(display, picture, hot_x, hot_y);
+    XRenderFreePicture(display, picture);
+    XFreePixmap(display, pixmap);
+    return cursor;
+}
+*/
+import "C"
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+)
+
+// cursorKey identifies a native cursor of a display. Standard cursors are shared by shape, image cursors by their description.
+type cursorKey struct {
+	display *C.Display
+	shape   common.CursorShape
+	image   *common.Cursor
+}
+
+var (
+	cursorCache   = make(map[cursorKey]C.Cursor)
+	cursorCacheMu sync.Mutex
+)
+
+// SetWindowCursor sets the mouse pointer shown over the window.
+// Native cursors are created the first time they are used and reused afterwards.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cursor: The cursor to show, nil for the arrow
+func SetWindowCursor(hwnd uintptr, cursor *common.Cursor) {
+	display := GetDisplay(hwnd)
+	if display == nil {
+		return
+	}
+	if cursor == nil {
+		cursor = common.CursorArrow
+	}
+	C.XDefineCursor(display, C.Window(hwnd), loadCursor(display, cursor))
+	C.XFlush(display)
+}
+
+// loadCursor returns the native cursor of the display for the cursor, creating it if needed.
+// Image cursors that cannot be created fall back to the arrow.
+func loadCursor(display *C.Display, cursor *common.Cursor) C.Cursor {
+	key := cursorKey{display: display, shape: cursor.Shape}
+	if cursor.Shape == common.CursorShapeImage {
+		key.image = cursor
+	}
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	if xc, ok := cursorCache[key]; ok {
+		return xc
+	}
+
+	var xc C.Cursor
+	if cursor.Shape == common.CursorShapeImage {
+		if pixels, w, h := cursor.ARGB(); w > 0 && h > 0 {
+			xc = C.gooey_create_argb_cursor(display, (*C.uint)(&pixels[0]), C.int(w), C.int(h), C.int(cursor.HotX), C.int(cursor.HotY))
+		}
+	}
+	if xc == 0 {
+		xc = C.XCreateFontCursor(display, fontCursorShape(cursor.Shape))
+	}
+	cursorCache[key] = xc
+	return xc
+}
+
+// fontCursorShape maps a cursor shape to the closest glyph of the X cursor font.
+func fontCursorShape(shape common.CursorShape) C.uint {
+	switch shape {
+	case common.CursorShapeIBeam:
+		return C.XC_xterm
+	case common.CursorShapeHand:
+		return C.XC_hand2
+	case common.CursorShapeCrosshair:
+		return C.XC_crosshair
+	case common.CursorShapeResizeEW:
+		return C.XC_sb_h_double_arrow
+	case common.CursorShapeResizeNS:
+		return C.XC_sb_v_double_arrow
+	case common.CursorShapeResizeNWSE:
+		return C.XC_bottom_right_corner
+	case common.CursorShapeResizeNESW:
+		return C.XC_bottom_left_corner
+	case common.CursorShapeMove:
+		return C.XC_fleur
+	case common.CursorShapeWait:
+		return C.XC_watch
+	case common.CursorShapeNotAllowed:
+		return C.XC_X_cursor
+	default:
+		return C.XC_left_ptr
+	}
+}
+
+// forgetCursors drops the cached cursors of a display that is being closed, closing it frees them.
+func forgetCursors(display *C.Display) {
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	for key := range cursorCache {
+		if key.display == display {
+			delete(cursorCache, key)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// fontKey identifies a core font loaded on a display by the family and pixel size it was asked for.
+type fontKey struct {
+	display *C.Display
+	name    string
+	size    int
+}
+
+var (
+	// fontCache holds the loaded fonts, nil for requests the server has no font for so they are not looked up again.
+	fontCache   = make(map[fontKey]*C.XFontStruct)
+	fontCacheMu sync.Mutex
+)
+
+// loadFont returns the core font closest to a family and pixel size, loading it the first time it is asked for.
+// The family is matched first, then any family of the size, the same way CreateFontW picks a substitute on Windows.
+//
+// Parameters:
+//   - display: The display to load the font on
+//   - fontName: The family of the font, such as "DejaVu Sans", empty for any family
+//   - fontSize: The pixel size of the font, 0 or less for any size
+//
+// Returns:
+//   - *C.XFontStruct: The font, or nil if the server has no font of the size at all
+func loadFont(display *C.Display, fontName string, fontSize int) *C.XFontStruct {
+	key := fontKey{display: display, name: fontName, size: fontSize}
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+	if font, ok := fontCache[key]; ok {
+		return font
+	}
+	var font *C.XFontStruct
+	for _, pattern := range fontPatterns(fontName, fontSize) {
+		cpattern := C.CString(pattern)
+		font = C.XLoadQueryFont(display, cpattern)
+		C.free(unsafe.Pointer(cpattern))
+		if font != nil {
+			break
+		}
+	}
+	fontCache[key] = font
+	return font
+}
+
+// fontPatterns returns the XLFD patterns looked up for a font, from the most to the least specific.
+func fontPatterns(fontName string, fontSize int) []string {
+	size := "*"
+	if fontSize > 0 {
+		size = fmt.Sprint(fontSize)
+	}
+	var patterns []string
+	// XLFD separates its fields with dashes, a dash in the family is left to the wildcard
+	if family := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(fontName)), "-", "?"); family != "" {
+		patterns = append(patterns,
+			fmt.Sprintf("-*-%s-medium-r-normal--%s-*-*-*-*-*-iso10646-1", family, size),
+			fmt.Sprintf("-*-%s-medium-r-normal--%s-*-*-*-*-*-*-*", family, size),
+			fmt.Sprintf("-*-%s-*-r-*--%s-*-*-*-*-*-*-*", family, size),
+		)
+	}
+	return append(patterns,
+		fmt.Sprintf("-*-*-medium-r-normal--%s-*-*-*-*-*-iso10646-1", size),
+		fmt.Sprintf("-*-*-medium-r-normal--%s-*-*-*-*-*-*-*", size),
+	)
+}
+
+// textFont selects the font text is drawn and measured with into a GC: the requested font, or the GC's default font
+// when the server has no font of the size.
+//
+// Parameters:
+//   - display: The display the GC belongs to
+//   - gc: The GC to draw with
+//   - fontName: The family of the font
+//   - fontSize: The pixel size of the font
+//
+// Returns:
+//   - *C.XFontStruct: The metrics of the selected font, nil if they could not be queried
+//   - func(): Releases the metrics, to be called once the text was drawn or measured
+func textFont(display *C.Display, gc C.GC, fontName string, fontSize int) (*C.XFontStruct, func()) {
+	if font := loadFont(display, fontName, fontSize); font != nil {
+		C.XSetFont(display, gc, font.fid)
+		return font, func() {}
+	}
+	font := C.XQueryFont(display, C.XGContextFromGC(gc))
+	if font == nil {
+		return nil, func() {}
+	}
+	return font, func() { C.XFreeFontInfo(nil, font, 1) }
+}
+
+// forgetFonts drops the cached fonts of a display that is being closed, the server frees them with the connection.
+//
+// Parameters:
+//   - display: The display being closed
+func forgetFonts(display *C.Display) {
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+	for key := range fontCache {
+		if key.display == display {
+			delete(fontCache, key)
+		}
+	}
+}
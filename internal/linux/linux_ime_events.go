@@ -0,0 +1,377 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+#include <locale.h>
+#include <stdlib.h>
+#include <string.h>
+#include <wchar.h>
+
+#define GOOEY_PREEDIT_MAX 256
+
+// gooey_ime is the input context of a window together with the text its input method is composing.
+// The preedit callbacks write the composition here and set changed, the event loop picks it up after filtering an event.
+typedef struct {
+    XIM im;
+    XIC ic;
+    int changed;
+    int length;
+    int caret;
+    wchar_t text[GOOEY_PREEDIT_MAX];
+    XIMCallback start_cb;
+    XIMCallback done_cb;
+    XIMCallback draw_cb;
+    XIMCallback caret_cb;
+} gooey_ime;
+
+static int gooey_clamp(int v, int lo, int hi) {
+    return v < lo ? lo : (v > hi ? hi : v);
+}
+
+static int gooey_preedit_start(XIC ic, XPointer client, XPointer call) {
+    gooey_ime *ime = (gooey_ime *)client;
+    ime->length = 0;
+    ime->caret = 0;
+    ime->changed = 1;
+    return GOOEY_PREEDIT_MAX;
+}
+
+static void gooey_preedit_done(XIC ic, XPointer client, XPointer call) {
+    gooey_ime *ime = (gooey_ime *)client;
+    ime->length = 0;
+    ime->caret = 0;
+    ime->changed = 1;
+}
+
+// gooey_preedit_draw replaces chg_length characters at chg_first of the composition with the text of the call.
+static void gooey_preedit_draw(XIC ic, XPointer client, XPointer call) {
+    gooey_ime *ime = (gooey_ime *)client;
+    XIMPreeditDrawCallbackStruct *draw = (XIMPreeditDrawCallbackStruct *)call;
+    wchar_t insert[GOOEY_PREEDIT_MAX];
+    int n = 0;
+    if (draw->text != NULL) {
+        if (draw->text->encoding_is_wchar) {
+            if (draw->text->string.wide_char != NULL) {
+                n = gooey_clamp(draw->text->length, 0, GOOEY_PREEDIT_MAX);
+                wmemcpy(insert, draw->text->string.wide_char, n);
+            }
+        } else if (draw->text->string.multi_byte != NULL) {
+            size_t converted = mbstowcs(insert, draw->text->string.multi_byte, GOOEY_PREEDIT_MAX);
+            if (converted != (size_t)-1) {
+                n = (int)converted;
+            }
+        }
+    }
+    int first = gooey_clamp(draw->chg_first, 0, ime->length);
+    int removed = gooey_clamp(draw->chg_length, 0, ime->length - first);
+    int tail = ime->length - first - removed;
+    n = gooey_clamp(n, 0, GOOEY_PREEDIT_MAX - first - tail);
+    memmove(ime->text + first + n, ime->text + first + removed, tail * sizeof(wchar_t));
+    wmemcpy(ime->text + first, insert, n);
+    ime->length = first + n + tail;
+    ime->caret = gooey_clamp(draw->caret, 0, ime->length);
+    ime->changed = 1;
+}
+
+static void gooey_preedit_caret(XIC ic, XPointer client, XPointer call) {
+    gooey_ime *ime = (gooey_ime *)client;
+    XIMPreeditCaretCallbackStruct *caret = (XIMPreeditCaretCallbackStruct *)call;
+    switch (caret->direction) {
+    case XIMAbsolutePosition:
+        ime->caret = caret->position;
+        break;
+    case XIMForwardChar:
+        ime->caret++;
+        break;
+    case XIMBackwardChar:
+        ime->caret--;
+        break;
+    case XIMLineStart:
+        ime->caret = 0;
+        break;
+    case XIMLineEnd:
+        ime->caret = ime->length;
+        break;
+    default:
+        break;
+    }
+    ime->caret = gooey_clamp(ime->caret, 0, ime->length);
+    caret->position = ime->caret;
+    ime->changed = 1;
+}
+
+// gooey_ime_style picks the input style drawing the composition ourselves if the input method offers it,
+// then one where the input method shows it in a window of its own, then one without any composition.
+static XIMStyle gooey_ime_style(XIM im) {
+    XIMStyles *styles = NULL;
+    XIMStyle best = 0;
+    int rank = 0;
+    if (XGetIMValues(im, XNQueryInputStyle, &styles, NULL) != NULL || styles == NULL) {
+        return XIMPreeditNothing | XIMStatusNothing;
+    }
+    for (int i = 0; i < styles->count_styles; i++) {
+        XIMStyle style = styles->supported_styles[i];
+        if (!(style & (XIMStatusNothing | XIMStatusNone))) {
+            continue;
+        }
+        int r = (style & XIMPreeditCallbacks) ? 3 : (style & XIMPreeditNothing) ? 2 : (style & XIMPreeditNone) ? 1 : 0;
+        if (r > rank) {
+            rank = r;
+            best = style;
+        }
+    }
+    XFree(styles);
+    return best != 0 ? best : (XIMPreeditNothing | XIMStatusNothing);
+}
+
+static void gooey_ime_init_locale() {
+    setlocale(LC_CTYPE, "");
+}
+
+static gooey_ime *gooey_ime_open(Display *display, Window window) {
+    XSetLocaleModifiers("");
+    XIM im = XOpenIM(display, NULL, NULL, NULL);
+    if (im == NULL) {
+        // the input method named by XMODIFIERS is not running, the built-in one still handles dead keys and compose
+        XSetLocaleModifiers("@im=none");
+        im = XOpenIM(display, NULL, NULL, NULL);
+    }
+    if (im == NULL) {
+        return NULL;
+    }
+    gooey_ime *ime = calloc(1, sizeof(gooey_ime));
+    ime->im = im;
+    XIMStyle style = gooey_ime_style(im);
+    if (style & XIMPreeditCallbacks) {
+        ime->start_cb.client_data = (XPointer)ime;
+        ime->start_cb.callback = (XIMProc)gooey_preedit_start;
+        ime->done_cb.client_data = (XPointer)ime;
+        ime->done_cb.callback = (XIMProc)gooey_preedit_done;
+        ime->draw_cb.client_data = (XPointer)ime;
+        ime->draw_cb.callback = (XIMProc)gooey_preedit_draw;
+        ime->caret_cb.client_data = (XPointer)ime;
+        ime->caret_cb.callback = (XIMProc)gooey_preedit_caret;
+        XVaNestedList preedit = XVaCreateNestedList(0,
+            XNPreeditStartCallback, &ime->start_cb,
+            XNPreeditDoneCallback, &ime->done_cb,
+            XNPreeditDrawCallback, &ime->draw_cb,
+            XNPreeditCaretCallback, &ime->caret_cb,
+            NULL);
+        ime->ic = XCreateIC(im, XNInputStyle, style, XNClientWindow, window, XNFocusWindow, window,
+            XNPreeditAttributes, preedit, NULL);
+        XFree(preedit);
+    } else {
+        ime->ic = XCreateIC(im, XNInputStyle, style, XNClientWindow, window, XNFocusWindow, window, NULL);
+    }
+    if (ime->ic == NULL) {
+        XCloseIM(im);
+        free(ime);
+        return NULL;
+    }
+    // the input method may need events the window does not listen for yet
+    unsigned long filter = 0;
+    if (XGetICValues(ime->ic, XNFilterEvents, &filter, NULL) == NULL && filter != 0) {
+        XWindowAttributes attrs;
+        XGetWindowAttributes(display, window, &attrs);
+        XSelectInput(display, window, attrs.your_event_mask | filter);
+    }
+    XUnsetICFocus(ime->ic);
+    return ime;
+}
+
+static void gooey_ime_close(gooey_ime *ime) {
+    XDestroyIC(ime->ic);
+    XCloseIM(ime->im);
+    free(ime);
+}
+
+// gooey_ime_reset throws away what the input method is composing.
+static void gooey_ime_reset(gooey_ime *ime) {
+    char *committed = Xutf8ResetIC(ime->ic);
+    if (committed != NULL) {
+        XFree(committed);
+    }
+    ime->length = 0;
+    ime->caret = 0;
+    ime->changed = 1;
+}
+
+static void gooey_ime_set_spot(gooey_ime *ime, short x, short y) {
+    XPoint spot = {x, y};
+    XVaNestedList preedit = XVaCreateNestedList(0, XNSpotLocation, &spot, NULL);
+    XSetICValues(ime->ic, XNPreeditAttributes, preedit, NULL);
+    XFree(preedit);
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/Carmen-Shannon/gooey/common"
+)
+
+var (
+	imeMap     = make(map[uintptr]*C.gooey_ime)
+	imeMapMu   sync.Mutex
+	localeOnce sync.Once
+)
+
+// OpenInputMethod connects a window to the input method of its display, so dead keys, compose sequences and the
+// input methods of other scripts work in its text inputs. Windows without an input method fall back to plain key lookups.
+//
+// Parameters:
+//   - hwnd: The handle to the window, registered with RegisterDisplay
+func OpenInputMethod(hwnd uintptr) {
+	display := GetDisplay(hwnd)
+	if display == nil {
+		return
+	}
+	// Go never sets the C locale, which the input method decides its encoding by
+	localeOnce.Do(func() { C.gooey_ime_init_locale() })
+	ime := C.gooey_ime_open(display, C.Window(hwnd))
+	if ime == nil {
+		return
+	}
+	imeMapMu.Lock()
+	defer imeMapMu.Unlock()
+	imeMap[hwnd] = ime
+}
+
+// closeInputMethod releases the input context of a window, it must run before the window's display is closed.
+func closeInputMethod(hwnd uintptr) {
+	imeMapMu.Lock()
+	ime := imeMap[hwnd]
+	delete(imeMap, hwnd)
+	imeMapMu.Unlock()
+	if ime != nil {
+		C.gooey_ime_close(ime)
+	}
+}
+
+func getInputMethod(hwnd uintptr) *C.gooey_ime {
+	imeMapMu.Lock()
+	defer imeMapMu.Unlock()
+	return imeMap[hwnd]
+}
+
+// filterInputMethodEvent hands an event to the input method of the window before the window handles it.
+// Key events only reach the input method while a text input has the keyboard focus, so shortcuts keep working elsewhere.
+// Changes to the composition are stored in the focused text input's state.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - event: The event taken from the window's queue
+//
+// Returns:
+//   - bool: True if the input method consumed the event and the window must ignore it
+func filterInputMethodEvent(hwnd uintptr, event *C.XEvent) bool {
+	ime := getInputMethod(hwnd)
+	if ime == nil {
+		return false
+	}
+	if t := EventType(event); (t == C_KEYPRESS || t == C_KEYRELEASE) && HLTR.TextInputID == 0 {
+		return false
+	}
+	filtered := C.XFilterEvent(event, C.None) != 0
+	syncPreedit(hwnd, ime)
+	return filtered
+}
+
+// syncPreedit copies the composition the preedit callbacks recorded into the state of the focused text input.
+func syncPreedit(hwnd uintptr, ime *C.gooey_ime) {
+	if ime.changed == 0 {
+		return
+	}
+	ime.changed = 0
+	if HLTR.TextInputID == 0 {
+		return
+	}
+	runes := make([]rune, int(ime.length))
+	for i := range runes {
+		runes[i] = rune(ime.text[i])
+	}
+	UpdateTextInputState(HLTR.TextInputID, common.UpdateTIPreedit(string(runes), int32(ime.caret)))
+	placeInputMethod(hwnd)
+}
+
+// lookupKeyText returns the text a key press types, which the input method may have composed from several key presses.
+// Without an input method the key is looked up on its own.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - keyEvent: The key press
+//
+// Returns:
+//   - string: The typed text, empty when the key types nothing
+func lookupKeyText(hwnd uintptr, keyEvent *C.XKeyEvent) string {
+	var keysym C.KeySym
+	ime := getInputMethod(hwnd)
+	if ime == nil {
+		// XLookupString returns Latin-1
+		var buf [32]byte
+		n := C.XLookupString(keyEvent, (*C.char)(unsafe.Pointer(&buf[0])), C.int(len(buf)), &keysym, nil)
+		runes := make([]rune, 0, int(n))
+		for _, b := range buf[:max(int(n), 0)] {
+			runes = append(runes, rune(b))
+		}
+		return string(runes)
+	}
+	buf := make([]byte, 64)
+	var status C.Status
+	n := C.Xutf8LookupString(ime.ic, keyEvent, (*C.char)(unsafe.Pointer(&buf[0])), C.int(len(buf)), &keysym, &status)
+	if status == C.XBufferOverflow {
+		// committed compositions may be longer than any single key, n holds the size they need
+		buf = make([]byte, int(n))
+		n = C.Xutf8LookupString(ime.ic, keyEvent, (*C.char)(unsafe.Pointer(&buf[0])), C.int(len(buf)), &keysym, &status)
+	}
+	if status != C.XLookupChars && status != C.XLookupBoth {
+		return ""
+	}
+	return string(buf[:n])
+}
+
+// focusInputMethod tells the input method of a window whether a text input has the keyboard focus.
+// Whatever was being composed is thrown away and its preedit cleared from the text input which held the focus.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - prevID: The ID of the text input which held the focus, 0 for none
+//   - focused: True if a text input has the focus now
+func focusInputMethod(hwnd, prevID uintptr, focused bool) {
+	ime := getInputMethod(hwnd)
+	if ime == nil {
+		return
+	}
+	C.gooey_ime_reset(ime)
+	ime.changed = 0
+	if prevID != 0 {
+		UpdateTextInputState(prevID, common.UpdateTIPreedit("", 0))
+	}
+	if focused {
+		C.XSetICFocus(ime.ic)
+		placeInputMethod(hwnd)
+	} else {
+		C.XUnsetICFocus(ime.ic)
+	}
+}
+
+// placeInputMethod moves the spot of the window's input method below the caret of the focused text input,
+// where input methods which show their own windows put the composition and candidate list.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func placeInputMethod(hwnd uintptr) {
+	ime := getInputMethod(hwnd)
+	state := GetTextInputState(HLTR.TextInputID)
+	if ime == nil || state == nil {
+		return
+	}
+	caret := state.CaretRect(textMeasure(state))
+	C.gooey_ime_set_spot(ime, C.short(caret.X), C.short(caret.Y+caret.H))
+}
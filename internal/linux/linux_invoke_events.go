@@ -0,0 +1,121 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <stdlib.h>
+
+static void gooey_set_invoke_message(XEvent *event, Display *display, Window window, Atom message_type) {
+    event->xclient.type = ClientMessage;
+    event->xclient.send_event = True;
+    event->xclient.display = display;
+    event->xclient.window = window;
+    event->xclient.message_type = message_type;
+    event->xclient.format = 32;
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+var (
+	invokeQueueMap = make(map[uintptr][]func())
+	invokeQueueMu  sync.Mutex
+)
+
+// invokeAtomName names the client message that wakes a window's event loop to run invoked functions.
+const invokeAtomName = "GOOEY_INVOKE"
+
+// RegisterInvokeQueue opens the queue of functions invoked on the event loop of a window.
+// Until it is called, and after the window was destroyed, PostInvoke rejects functions for the window.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func RegisterInvokeQueue(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	defer invokeQueueMu.Unlock()
+	invokeQueueMap[hwnd] = nil
+}
+
+// PostInvoke queues fn to run on the event loop of the window and wakes the loop by sending it a client message.
+// It is safe to call from any goroutine.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - fn: The function to run on the window's event loop
+//
+// Returns:
+//   - bool: True if fn was queued, false if the window has no open queue because it was never registered or was destroyed.
+func PostInvoke(hwnd uintptr, fn func()) bool {
+	// the lock is held while sending so the window cannot close its display in between, see closeInvokeQueue
+	invokeQueueMu.Lock()
+	defer invokeQueueMu.Unlock()
+
+	queue, ok := invokeQueueMap[hwnd]
+	if !ok {
+		return false
+	}
+	display := GetDisplay(hwnd)
+	if display == nil {
+		return false
+	}
+	invokeQueueMap[hwnd] = append(queue, fn)
+	// a single pending message is enough, the loop runs the whole queue when it arrives
+	if len(queue) > 0 {
+		return true
+	}
+
+	name := C.CString(invokeAtomName)
+	defer C.free(unsafe.Pointer(name))
+	var ev C.XEvent
+	C.gooey_set_invoke_message(&ev, display, C.Window(hwnd), C.XInternAtom(display, name, C.False))
+	C.XSendEvent(display, C.Window(hwnd), C.False, 0, &ev)
+	C.XFlush(display)
+	return true
+}
+
+// runInvoked runs the functions queued for the window in the order they were posted.
+// It is called by WindowProc on the window's event loop when the wake-up message arrives.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func runInvoked(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	queue, ok := invokeQueueMap[hwnd]
+	if ok {
+		invokeQueueMap[hwnd] = nil
+	}
+	invokeQueueMu.Unlock()
+
+	for _, fn := range queue {
+		fn()
+	}
+}
+
+// closeInvokeQueue closes the queue of a window that is being destroyed and runs the functions still queued,
+// so goroutines waiting on them are released. Functions posted afterwards are rejected by PostInvoke.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func closeInvokeQueue(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	queue := invokeQueueMap[hwnd]
+	delete(invokeQueueMap, hwnd)
+	invokeQueueMu.Unlock()
+
+	for _, fn := range queue {
+		fn()
+	}
+}
+
+// isInvokeMessage reports whether a client message is the wake-up message sent by PostInvoke.
+func isInvokeMessage(display *C.Display, ev *C.XClientMessageEvent) bool {
+	name := C.CString(invokeAtomName)
+	defer C.free(unsafe.Pointer(name))
+	return ev.message_type == C.XInternAtom(display, name, C.True)
+}
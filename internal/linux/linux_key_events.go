@@ -0,0 +1,184 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/keysym.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+var (
+	keyCallbackMap = make(map[uintptr]func(common.KeyInput) bool)
+	keyCallbackMu  sync.Mutex
+	// repeatKeycode is the keycode of the key whose next press is an auto-repeat, 0 if none.
+	repeatKeycode C.uint
+)
+
+// RegisterKeyCallback registers a callback function to be called with the raw keyboard input of a window.
+// The callback returns true when it consumed the key, for example because it triggered a shortcut,
+// in which case the key is not passed on to the focused text input.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with each key input
+func RegisterKeyCallback(hwnd uintptr, cb func(common.KeyInput) bool) {
+	keyCallbackMu.Lock()
+	defer keyCallbackMu.Unlock()
+	keyCallbackMap[hwnd] = cb
+}
+
+// handleKey invokes the key callback registered for the window handle, if any.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - input: The key input to report
+//
+// Returns:
+//   - bool: True if the callback consumed the key, false otherwise
+func handleKey(hwnd uintptr, input common.KeyInput) bool {
+	keyCallbackMu.Lock()
+	cb := keyCallbackMap[hwnd]
+	keyCallbackMu.Unlock()
+	return cb != nil && cb(input)
+}
+
+// keyInputFromEvent translates an X11 KeyPress or KeyRelease event into a key input.
+// The unshifted keysym is used so the reported key does not depend on the modifiers held.
+//
+// Parameters:
+//   - kind: common.KeyInputDown or common.KeyInputUp
+//   - keyEvent: The X11 key event
+//
+// Returns:
+//   - common.KeyInput: The platform-neutral key input
+func keyInputFromEvent(kind common.KeyInputKind, keyEvent *C.XKeyEvent) common.KeyInput {
+	input := common.KeyInput{
+		Kind:      kind,
+		Key:       x11Key(C.XLookupKeysym(keyEvent, 0)),
+		Modifiers: x11Modifiers(keyEvent.state),
+	}
+	if kind == common.KeyInputDown && repeatKeycode != 0 && keyEvent.keycode == repeatKeycode {
+		input.Repeat = true
+	}
+	repeatKeycode = 0
+	return input
+}
+
+// isAutoRepeatRelease reports whether a KeyRelease is the first half of an auto-repeat.
+// X11 reports a held key as pairs of release and press with the same time stamp, the release is dropped
+// and the press that follows it is marked as a repeat.
+//
+// Parameters:
+//   - display: The X11 display the event came from
+//   - keyEvent: The KeyRelease event
+//
+// Returns:
+//   - bool: True if the release is followed by a press of the same key at the same time, false otherwise
+func isAutoRepeatRelease(display *C.Display, keyEvent *C.XKeyEvent) bool {
+	if C.XEventsQueued(display, C.QueuedAfterReading) == 0 {
+		return false
+	}
+	var next C.XEvent
+	C.XPeekEvent(display, &next)
+	nextKey := (*C.XKeyEvent)(unsafe.Pointer(&next))
+	if EventType(&next) != C_KEYPRESS || nextKey.keycode != keyEvent.keycode || nextKey.time != keyEvent.time {
+		return false
+	}
+	repeatKeycode = keyEvent.keycode
+	return true
+}
+
+// x11Key translates an unshifted X11 keysym into a platform-neutral key.
+//
+// Parameters:
+//   - keysym: The keysym of the key
+//
+// Returns:
+//   - event.Key: The matching key, or event.KeyUnknown
+func x11Key(keysym C.KeySym) event.Key {
+	switch {
+	case keysym >= C.XK_a && keysym <= C.XK_z:
+		return event.KeyA + event.Key(keysym-C.XK_a)
+	case keysym >= C.XK_A && keysym <= C.XK_Z:
+		return event.KeyA + event.Key(keysym-C.XK_A)
+	case keysym >= C.XK_0 && keysym <= C.XK_9:
+		return event.Key0 + event.Key(keysym-C.XK_0)
+	case keysym >= C.XK_F1 && keysym <= C.XK_F12:
+		return event.KeyF1 + event.Key(keysym-C.XK_F1)
+	}
+	switch keysym {
+	case C.XK_Up:
+		return event.KeyUp
+	case C.XK_Down:
+		return event.KeyDown
+	case C.XK_Left:
+		return event.KeyLeft
+	case C.XK_Right:
+		return event.KeyRight
+	case C.XK_Page_Up:
+		return event.KeyPageUp
+	case C.XK_Page_Down:
+		return event.KeyPageDown
+	case C.XK_Home:
+		return event.KeyHome
+	case C.XK_End:
+		return event.KeyEnd
+	case C.XK_Escape:
+		return event.KeyEscape
+	case C.XK_Return, C.XK_KP_Enter:
+		return event.KeyEnter
+	case C.XK_Tab, C.XK_ISO_Left_Tab:
+		return event.KeyTab
+	case C.XK_BackSpace:
+		return event.KeyBackspace
+	case C.XK_Delete:
+		return event.KeyDelete
+	case C.XK_Insert:
+		return event.KeyInsert
+	case C.XK_space:
+		return event.KeySpace
+	case C.XK_minus:
+		return event.KeyMinus
+	case C.XK_equal:
+		return event.KeyEqual
+	case C.XK_comma:
+		return event.KeyComma
+	case C.XK_period:
+		return event.KeyPeriod
+	case C.XK_slash:
+		return event.KeySlash
+	case C.XK_semicolon:
+		return event.KeySemicolon
+	case C.XK_apostrophe:
+		return event.KeyApostrophe
+	case C.XK_bracketleft:
+		return event.KeyLeftBracket
+	case C.XK_bracketright:
+		return event.KeyRightBracket
+	case C.XK_backslash:
+		return event.KeyBackslash
+	case C.XK_grave:
+		return event.KeyGrave
+	case C.XK_Shift_L, C.XK_Shift_R:
+		return event.KeyShift
+	case C.XK_Control_L, C.XK_Control_R:
+		return event.KeyCtrl
+	case C.XK_Alt_L, C.XK_Alt_R:
+		return event.KeyAlt
+	case C.XK_Super_L, C.XK_Super_R:
+		return event.KeySuper
+	case C.XK_Caps_Lock:
+		return event.KeyCapsLock
+	}
+	return event.KeyUnknown
+}
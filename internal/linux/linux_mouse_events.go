@@ -0,0 +1,122 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+*/
+import "C"
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+var (
+	mouseCallbackMap = make(map[uintptr]func(common.MouseInput))
+	mouseCallbackMu  sync.Mutex
+)
+
+// RegisterMouseCallback registers a callback function to be called with the raw mouse input of a window.
+// It takes a window handle and a callback function as parameters.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with each mouse input
+func RegisterMouseCallback(hwnd uintptr, cb func(common.MouseInput)) {
+	mouseCallbackMu.Lock()
+	defer mouseCallbackMu.Unlock()
+	mouseCallbackMap[hwnd] = cb
+}
+
+// handleMouse invokes the mouse callback registered for the window handle, if any.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - input: The mouse input to report
+func handleMouse(hwnd uintptr, input common.MouseInput) {
+	mouseCallbackMu.Lock()
+	cb := mouseCallbackMap[hwnd]
+	mouseCallbackMu.Unlock()
+	if cb != nil {
+		cb(input)
+	}
+}
+
+// x11MouseButton translates an X11 button number into a platform-neutral mouse button.
+// X11 numbers the physical buttons 1 (left), 2 (middle), 3 (right), 8 (back) and 9 (forward).
+//
+// Parameters:
+//   - button: The X11 button number
+//
+// Returns:
+//   - event.MouseButton: The matching mouse button, or event.MouseButtonNone for unknown buttons
+func x11MouseButton(button C.uint) event.MouseButton {
+	switch button {
+	case 1:
+		return event.MouseButtonLeft
+	case 2:
+		return event.MouseButtonMiddle
+	case 3:
+		return event.MouseButtonRight
+	case 8:
+		return event.MouseButtonBack
+	case 9:
+		return event.MouseButtonForward
+	}
+	return event.MouseButtonNone
+}
+
+// x11Modifiers translates the modifier bits of an X11 input event state.
+//
+// Parameters:
+//   - state: The state field of an X11 key, button or motion event
+//
+// Returns:
+//   - event.Modifiers: The modifier keys held during the event
+func x11Modifiers(state C.uint) event.Modifiers {
+	var mods event.Modifiers
+	if state&C.ShiftMask != 0 {
+		mods |= event.ModShift
+	}
+	if state&C.ControlMask != 0 {
+		mods |= event.ModCtrl
+	}
+	if state&C.Mod1Mask != 0 {
+		mods |= event.ModAlt
+	}
+	if state&C.Mod4Mask != 0 {
+		mods |= event.ModSuper
+	}
+	return mods
+}
+
+// x11WheelDelta translates a wheel button into a scroll distance in event.WheelDelta units.
+// X11 reports the wheel as buttons 4 (up), 5 (down), 6 (left) and 7 (right), holding shift turns vertical scrolling horizontal.
+//
+// Parameters:
+//   - button: The X11 button number of the wheel event
+//   - shift: Whether the shift key is held
+//
+// Returns:
+//   - dx: The horizontal scroll distance
+//   - dy: The vertical scroll distance
+func x11WheelDelta(button C.uint, shift bool) (dx, dy int32) {
+	switch button {
+	case 4:
+		dy = -event.WheelDelta
+	case 5:
+		dy = event.WheelDelta
+	case 6:
+		dx = -event.WheelDelta
+	case 7:
+		dx = event.WheelDelta
+	}
+	if shift {
+		dx, dy = dy, dx
+	}
+	return dx, dy
+}
@@ -0,0 +1,135 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/keysym.h>
+*/
+import "C"
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+var (
+	scrollViewStateMap   = make(map[uintptr]*common.ScrollViewState)
+	scrollViewStateMapMu sync.Mutex
+	activeScrollViewID   uintptr
+)
+
+// RegisterScrollViewState registers the state of a scroll view so it can receive wheel, pointer and keyboard input.
+//
+// Parameters:
+//   - componentID: The ID of the scroll view component
+//   - state: A pointer to the ScrollViewState shared with the component
+func RegisterScrollViewState(componentID uintptr, state *common.ScrollViewState) {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	scrollViewStateMap[componentID] = state
+}
+
+// GetScrollViewState retrieves the state of a scroll view by its component ID.
+//
+// Parameters:
+//   - componentID: The ID of the scroll view component
+//
+// Returns:
+//   - *common.ScrollViewState: The state of the scroll view, or nil if it is not registered
+func GetScrollViewState(componentID uintptr) *common.ScrollViewState {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	return scrollViewStateMap[componentID]
+}
+
+// FindScrollViewAt finds the innermost scroll view at the given coordinates.
+// When scroll views are nested the one with the smallest bounds wins.
+//
+// Parameters:
+//   - x: The x-coordinate of the point
+//   - y: The y-coordinate of the point
+//
+// Returns:
+//   - componentID: The ID of the scroll view if found, 0 otherwise
+//   - found: A boolean indicating whether a scroll view was found
+func FindScrollViewAt(x, y int32) (componentID uintptr, found bool) {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	var area int64
+	for id, state := range scrollViewStateMap {
+		if !state.HitTest(x, y) {
+			continue
+		}
+		a := int64(state.Bounds.W) * int64(state.Bounds.H)
+		if !found || a < area {
+			componentID, found, area = id, true, a
+		}
+	}
+	return componentID, found
+}
+
+// scrollViewEvents returns the event dispatcher of a scroll view, or nil if it is not registered.
+// The map lock is released before the dispatcher is returned so listeners may re-enter the backend.
+func scrollViewEvents(id uintptr) *event.Dispatcher {
+	state := GetScrollViewState(id)
+	if state == nil {
+		return nil
+	}
+	return state.Events
+}
+
+// activateScrollViewAt makes the innermost scroll view under the pointer the target of the scrolling keys.
+// It is called for presses and wheel turns, the mouse input itself reaches the scroll view through its window.
+//
+// Parameters:
+//   - x: The x-coordinate of the pointer
+//   - y: The y-coordinate of the pointer
+func activateScrollViewAt(x, y int32) {
+	if id, found := FindScrollViewAt(x, y); found {
+		activeScrollViewID = id
+	}
+}
+
+// handleScrollViewKey translates a paging keysym and forwards it to the scroll view that was last interacted with.
+//
+// Parameters:
+//   - keysym: The X11 keysym of the pressed key
+//
+// Returns:
+//   - bool: True if the key was a scrolling key and a scroll view consumed it, false otherwise
+func handleScrollViewKey(keysym C.KeySym) bool {
+	if activeScrollViewID == 0 {
+		return false
+	}
+	var key event.Key
+	switch keysym {
+	case C.XK_Up:
+		key = event.KeyUp
+	case C.XK_Down:
+		key = event.KeyDown
+	case C.XK_Left:
+		key = event.KeyLeft
+	case C.XK_Right:
+		key = event.KeyRight
+	case C.XK_Page_Up:
+		key = event.KeyPageUp
+	case C.XK_Page_Down:
+		key = event.KeyPageDown
+	case C.XK_Home:
+		key = event.KeyHome
+	case C.XK_End:
+		key = event.KeyEnd
+	default:
+		return false
+	}
+	events := scrollViewEvents(activeScrollViewID)
+	if !event.HasListeners[event.KeyDownEvent](events) {
+		return false
+	}
+	event.Publish(events, event.KeyDownEvent{Source: activeScrollViewID, Key: key})
+	return true
+}
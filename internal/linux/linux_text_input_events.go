@@ -13,12 +13,11 @@ package linux
 */
 import "C"
 import (
-	"os/exec"
-
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
 )
 
-func handleTextInputClickCallbacks(id uintptr, found bool, hwnd uintptr, mouseX int32, doubleClick ...bool) {
+func handleTextInputClickCallbacks(id uintptr, found bool, hwnd uintptr, mouseX, mouseY int32, doubleClick ...bool) {
 	isDoubleClick := len(doubleClick) > 0 && doubleClick[0]
 
 	// Suppress further selection updates if a double-click just occurred
@@ -26,287 +25,349 @@ func handleTextInputClickCallbacks(id uintptr, found bool, hwnd uintptr, mouseX
 		return
 	}
 
+	// a click ends any composition, the caret moves or the focus leaves
+	focusInputMethod(hwnd, HLTR.TextInputID, found)
+
 	if found {
 		HLTR.TextInputID = id
 		state := GetTextInputState(id)
 		if state == nil {
 			return
 		}
-		caretPos := getCaretPosForTextInput(id, hwnd, mouseX)
+		caretPos := getCaretPosForTextInput(id, hwnd, mouseX, mouseY)
 		var selStart, selEnd int32
 		if isDoubleClick {
 			HLTR.SuppressSelection = true
-			selStart, selEnd = getWordBounds(state.Value, caretPos)
+			selStart, selEnd = state.WordAt(caretPos)
 		} else {
 			selStart, selEnd = caretPos, caretPos
 		}
 		UpdateTextInputState(id,
 			common.UpdateTIFocused(true),
 			common.UpdateTICaretPos(selEnd),
-			common.UpdateTISelectionStart(selStart),
-			common.UpdateTISelectionEnd(selEnd),
+			common.UpdateTISelection(selStart, selEnd),
 		)
 		HLTR.SelectionStart = selStart
 		HLTR.SelectionEnd = selEnd
 		HLTR.Active = true
-		if cb, ok := state.CbMap["focused"]; ok {
-			cb(true)
-		}
-		if !CT.Active {
-			CT.Start(hwnd, id)
-		}
-		if cb, ok := state.CbMap["caretPos"]; ok {
-			cb(selEnd)
-		}
-		if cb, ok := state.CbMap["selectionStart"]; ok {
-			cb(selStart)
-		}
-		if cb, ok := state.CbMap["selectionEnd"]; ok {
-			cb(selEnd)
-		}
+		CT.Start(hwnd, id)
 	} else {
 		HLTR.TextInputID = 0
 		HLTR.Active = false
 		HLTR.SelectionStart = 0
 		HLTR.SelectionEnd = 0
-		for id, state := range textInputStateMap {
+		for _, id := range textInputIDs() {
 			UpdateTextInputState(id,
 				common.UpdateTIFocused(false),
-				common.UpdateTISelectionStart(0),
-				common.UpdateTISelectionEnd(0),
+				common.UpdateTISelection(0, 0),
 			)
-			if cb, ok := state.CbMap["focused"]; ok {
-				cb(false)
-			}
-			if cb, ok := state.CbMap["selectionStart"]; ok {
-				cb(int32(0))
-			}
-			if cb, ok := state.CbMap["selectionEnd"]; ok {
-				cb(int32(0))
-			}
 		}
 	}
 }
 
-func handleTextInputSelectionCallbacks(id uintptr, start, end int32) {
+// handleTextInputMiddleClick pastes the PRIMARY selection where a text input was clicked with the middle button.
+// The text input takes the focus like on a left click, with the caret at the click position and nothing selected,
+// so the PRIMARY selection is not replaced before it is read.
+//
+// Parameters:
+//   - hwnd: The handle to the window containing the text input
+//   - id: The ID of the clicked text input component
+//   - mouseX: The x-coordinate of the click
+//   - mouseY: The y-coordinate of the click
+func handleTextInputMiddleClick(hwnd, id uintptr, mouseX, mouseY int32) {
+	if GetTextInputState(id) == nil {
+		return
+	}
+	focusInputMethod(hwnd, HLTR.TextInputID, true)
+	caretPos := getCaretPosForTextInput(id, hwnd, mouseX, mouseY)
+	HLTR.TextInputID = id
+	HLTR.Active = false
+	HLTR.SelectionStart = caretPos
+	HLTR.SelectionEnd = caretPos
 	UpdateTextInputState(id,
-		common.UpdateTISelectionStart(start),
-		common.UpdateTISelectionEnd(end),
+		common.UpdateTIFocused(true),
+		common.UpdateTICaretPos(caretPos),
+		common.UpdateTISelection(caretPos, caretPos),
 	)
-	state := GetTextInputState(id)
-	if state != nil {
-		if cb, ok := state.CbMap["selectionStart"]; ok {
-			cb(start)
-		}
-		if cb, ok := state.CbMap["selectionEnd"]; ok {
-			cb(end)
-		}
-	}
+	CT.Start(hwnd, id)
+	handleTextInputPaste(hwnd, id, SelectionPrimary)
 }
 
-// handleTextInputCaretCallbacks handles the caret position callbacks for text input components.
-// It updates the caret position in the text input state and calls the appropriate callback function.
+// FocusTextInput moves keyboard focus to or away from a text input without a mouse click, for example when tabbing.
+// Focusing selects the whole value with the caret at its end, blurring clears the selection.
+// Focusing the text input which already holds the focus keeps its caret and selection.
 //
 // Parameters:
+//   - hwnd: The handle to the window containing the text input
 //   - id: The ID of the text input component
-func handleTextInputCaretCallbacks(id uintptr) {
-	state := GetTextInputState(id)
-	if state != nil {
-		UpdateTextInputState(id, common.UpdateTICaretPos(HLTR.SelectionEnd))
-		if cb, ok := state.CbMap["caretPos"]; ok {
-			cb(HLTR.SelectionEnd)
-		}
-	}
-}
-
-// Copy selected text to clipboard using xclip
-func handleTextInputCopy(id uintptr) {
+//   - focused: True to focus the text input, false to blur it
+func FocusTextInput(hwnd, id uintptr, focused bool) {
 	state := GetTextInputState(id)
 	if state == nil {
 		return
 	}
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
-	}
-	runes := []rune(state.Value)
-	if start < 0 || end > int32(len(runes)) || start == end {
-		return // nothing to copy
-	}
-	text := string(runes[start:end])
-	cmd := exec.Command("xclip", "-selection", "clipboard")
-	in, err := cmd.StdinPipe()
-	if err != nil {
+	if !focused {
+		if HLTR.TextInputID == id {
+			focusInputMethod(hwnd, id, false)
+			HLTR.TextInputID = 0
+			HLTR.Active = false
+			HLTR.SelectionStart = 0
+			HLTR.SelectionEnd = 0
+		}
+		UpdateTextInputState(id,
+			common.UpdateTIFocused(false),
+			common.UpdateTISelection(0, 0),
+		)
 		return
 	}
-	if err := cmd.Start(); err != nil {
+	if HLTR.TextInputID == id {
 		return
 	}
-	in.Write([]byte(text))
-	in.Close()
-	cmd.Wait()
+	end := int32(len([]rune(state.Value)))
+	prevID := HLTR.TextInputID
+	HLTR.TextInputID = id
+	focusInputMethod(hwnd, prevID, true)
+	HLTR.Active = false
+	HLTR.SuppressSelection = false
+	HLTR.SelectionStart = 0
+	HLTR.SelectionEnd = end
+	UpdateTextInputState(id,
+		common.UpdateTIFocused(true),
+		common.UpdateTICaretPos(end),
+		common.UpdateTISelection(0, end),
+	)
+	CT.Start(hwnd, id)
+}
+
+// handleTextInputSelectionCallbacks updates the selection of a text input component.
+// The state publishes a SelectionChangedEvent when the selection actually changed.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - start: The start position of the selection
+//   - end: The end position of the selection
+func handleTextInputSelectionCallbacks(id uintptr, start, end int32) {
+	UpdateTextInputState(id, common.UpdateTISelection(start, end))
+}
+
+// handleTextInputCaretCallbacks handles the caret position callbacks for text input components.
+// It updates the caret position in the text input state, which publishes a CaretMovedEvent when it moved.
+//
+// Parameters:
+//   - id: The ID of the text input component
+func handleTextInputCaretCallbacks(id uintptr) {
+	UpdateTextInputState(id, common.UpdateTICaretPos(HLTR.SelectionEnd))
 }
 
-// Paste clipboard text at caret using xclip
-func handleTextInputPaste(id uintptr) {
+// handleTextInputCommand runs an editing command on a text input.
+// The clipboard commands go through the X11 clipboard selection, every other command is applied by the shared editing model.
+//
+// Parameters:
+//   - hwnd: The handle to the window containing the text input
+//   - id: The ID of the text input component
+//   - cmd: The command bound to the pressed key
+//
+// Returns:
+//   - bool: True if cmd is an editing command, false for textedit.CommandNone
+func handleTextInputCommand(hwnd, id uintptr, cmd textedit.Command) bool {
+	switch cmd {
+	case textedit.CommandNone:
+		return false
+	case textedit.CommandCopy:
+		handleTextInputCopy(id)
+	case textedit.CommandCut:
+		if handleTextInputCopy(id) {
+			editTextInput(id, textedit.EditCut, (*textedit.Buffer).DeleteSelection)
+		}
+	case textedit.CommandPaste:
+		handleTextInputPaste(hwnd, id, SelectionClipboard)
+	case textedit.CommandUndo:
+		UndoTextInput(id)
+	case textedit.CommandRedo:
+		RedoTextInput(id)
+	case textedit.CommandNewline:
+		editTextInput(id, textedit.EditTyping, func(b *textedit.Buffer) bool { return b.Apply(cmd) })
+	case textedit.CommandSelectLeft, textedit.CommandSelectRight, textedit.CommandSelectWordLeft, textedit.CommandSelectWordRight,
+		textedit.CommandSelectHome, textedit.CommandSelectEnd, textedit.CommandSelectUp, textedit.CommandSelectDown,
+		textedit.CommandSelectPageUp, textedit.CommandSelectPageDown, textedit.CommandSelectTextStart, textedit.CommandSelectTextEnd,
+		textedit.CommandSelectAll:
+		editTextInput(id, textedit.EditDelete, func(b *textedit.Buffer) bool { return b.Apply(cmd) })
+		publishPrimarySelection(id)
+	default:
+		editTextInput(id, textedit.EditDelete, func(b *textedit.Buffer) bool { return b.Apply(cmd) })
+	}
+	return true
+}
+
+// editTextInput runs edit on a buffer holding the value and selection of a text input and stores the result in the state,
+// which publishes the value, caret and selection events of the change.
+// An edit which changed the value is recorded in the text input's history.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - kind: How the edit came about, deciding whether it merges with the previous undo step
+//   - edit: The edit to run, reporting whether it changed the value
+//
+// Returns:
+//   - bool: True if the value changed
+func editTextInput(id uintptr, kind textedit.EditKind, edit func(b *textedit.Buffer) bool) bool {
 	state := GetTextInputState(id)
 	if state == nil {
-		return
+		return false
 	}
-	out, err := exec.Command("xclip", "-selection", "clipboard", "-o").Output()
-	if err != nil {
-		return
+	b := textedit.New(state.Value, state.MaxLength)
+	if f := state.Filter; f != nil {
+		b.SetFilter(f.Rune, f.Value)
 	}
-	clipText := string(out)
-	if clipText == "" {
-		return
+	if state.Multiline {
+		b.SetMultiline(state.MaxLines)
+		b.SetLayout(func() *textedit.Layout { return state.Layout(textMeasure(state)) })
 	}
-	runes := []rune(state.Value)
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
+	b.Select(HLTR.SelectionStart, HLTR.SelectionEnd)
+	if HLTR.GoalPos == b.Caret() {
+		b.SetGoal(HLTR.GoalX)
 	}
-	if start < 0 {
-		start = 0
-	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
-	}
-
-	newVal := string(runes[:start]) + clipText + string(runes[end:])
-	if state.MaxLength > 0 && int32(len([]rune(newVal))) > state.MaxLength {
-		allowed := state.MaxLength - int32(len([]rune(string(runes[:start])+string(runes[end:]))))
-		if allowed < 0 {
-			allowed = 0
-		}
-		clipRunes := []rune(clipText)
-		clipText = string(clipRunes[:allowed])
-		newVal = string(runes[:start]) + clipText + string(runes[end:])
+	before := b.Snapshot()
+	changed := edit(b)
+	if changed && state.History != nil {
+		state.History.Record(kind, before, b.Snapshot())
 	}
-
-	newCaret := start + int32(len([]rune(clipText)))
+	HLTR.SelectionStart = b.Anchor()
+	HLTR.SelectionEnd = b.Caret()
+	HLTR.GoalX = b.Goal()
+	HLTR.GoalPos = b.Caret()
 	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
+		common.UpdateTIStateValue(b.Value()),
+		common.UpdateTISelection(b.Anchor(), b.Caret()),
+		common.UpdateTICaretPos(b.Caret()),
 	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	if cb, ok := state.CbMap["value"]; ok {
-		cb(newVal)
-	}
-	if cb, ok := state.CbMap["caretPos"]; ok {
-		cb(newCaret)
-	}
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
+	return changed
+}
+
+// UndoTextInput reverts the latest edit of a text input, restoring its value, caret and selection from before the edit.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//
+// Returns:
+//   - bool: True if there was an edit to undo
+func UndoTextInput(id uintptr) bool {
+	return restoreTextInput(id, (*textedit.History).Undo)
 }
 
-// handleTextInputBackspace removes the selected text or the character before the caret.
-func handleTextInputBackspace(id uintptr) {
+// RedoTextInput reapplies the latest undone edit of a text input, restoring its value, caret and selection from after the edit.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//
+// Returns:
+//   - bool: True if there was an edit to redo
+func RedoTextInput(id uintptr) bool {
+	return restoreTextInput(id, (*textedit.History).Redo)
+}
+
+// restoreTextInput moves a text input's history one step and stores the snapshot it returns in the state.
+func restoreTextInput(id uintptr, step func(h *textedit.History) (textedit.Snapshot, bool)) bool {
 	state := GetTextInputState(id)
-	if state == nil {
-		return
+	if state == nil || state.History == nil {
+		return false
 	}
-	runes := []rune(state.Value)
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
+	snap, ok := step(state.History)
+	if !ok {
+		return false
 	}
-	if start < 0 {
-		start = 0
+	if HLTR.TextInputID == id {
+		HLTR.SelectionStart = snap.Anchor
+		HLTR.SelectionEnd = snap.Caret
 	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
-	}
-
-	var newVal string
-	var newCaret int32
-	if start != end {
-		// Remove selection
-		newVal = string(runes[:start]) + string(runes[end:])
-		newCaret = start
-	} else if start > 0 {
-		// Remove character before caret
-		newVal = string(runes[:start-1]) + string(runes[start:])
-		newCaret = start - 1
-	} else {
-		// Nothing to delete
-		return
-	}
-
 	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
+		common.UpdateTIStateValue(snap.Value),
+		common.UpdateTISelection(snap.Anchor, snap.Caret),
+		common.UpdateTICaretPos(snap.Caret),
 	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	if cb, ok := state.CbMap["value"]; ok {
-		cb(newVal)
+	return true
+}
+
+// handleTextInputText inserts typed text at the caret of a text input, replacing the selection.
+// The text is a single character for most keys and whatever an input method committed otherwise.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - text: The typed text, control characters are ignored
+func handleTextInputText(id uintptr, text string) {
+	editTextInput(id, textedit.EditTyping, func(b *textedit.Buffer) bool { return b.Insert(text) })
+}
+
+// handleTextInputCopy puts the selected text of a text input on the clipboard, masked text inputs are never copied from.
+// Reports whether the selection was copied, cutting only removes it then.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//
+// Returns:
+//   - bool: True if the selected text is on the clipboard
+func handleTextInputCopy(id uintptr) bool {
+	state := GetTextInputState(id)
+	if state == nil || state.Mask != 0 {
+		return false
 	}
-	if cb, ok := state.CbMap["caretPos"]; ok {
-		cb(newCaret)
+	b := textedit.New(state.Value, 0)
+	b.Select(HLTR.SelectionStart, HLTR.SelectionEnd)
+	if !b.HasSelection() {
+		return false // nothing to copy
 	}
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
+	return SetSelectionText(SelectionClipboard, b.SelectedText()) == nil
 }
 
-// handleTextInputDelete removes the selected text or the character at the caret.
-func handleTextInputDelete(id uintptr) {
+// publishPrimarySelection makes the selected text of a text input the PRIMARY selection, which X11 applications paste
+// with a middle click. It is called once the user finished selecting with the pointer or the keyboard, only from the
+// pointer and keyboard handlers. Selections made by the application, such as the select-all on focus or a selection
+// set through UpdateTextInputState, don't reach it and leave the PRIMARY selection as it was. Masked text inputs never
+// publish their value, and an empty selection leaves the PRIMARY selection to its current owner.
+//
+// Parameters:
+//   - id: The ID of the text input component
+func publishPrimarySelection(id uintptr) {
 	state := GetTextInputState(id)
-	if state == nil {
+	if state == nil || state.Mask != 0 {
 		return
 	}
-	runes := []rune(state.Value)
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
-	}
-	if start < 0 {
-		start = 0
-	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
-	}
-
-	var newVal string
-	var newCaret int32
-	if start != end {
-		// Remove selection
-		newVal = string(runes[:start]) + string(runes[end:])
-		newCaret = start
-	} else if end < int32(len(runes)) {
-		// Remove character at caret
-		newVal = string(runes[:end]) + string(runes[end+1:])
-		newCaret = end
-	} else {
-		// Nothing to delete
+	b := textedit.New(state.Value, 0)
+	b.Select(HLTR.SelectionStart, HLTR.SelectionEnd)
+	if !b.HasSelection() {
 		return
 	}
+	_ = SetSelectionText(SelectionPrimary, b.SelectedText())
+}
 
-	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
-	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	if cb, ok := state.CbMap["value"]; ok {
-		cb(newVal)
-	}
-	if cb, ok := state.CbMap["caretPos"]; ok {
-		cb(newCaret)
-	}
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
+// handleTextInputPaste inserts the text of a selection at the caret of a text input, replacing the selection.
+// The text is read off the event loop, since its owner may take a while to hand it over, and inserted once it
+// arrives unless the focus moved on in the meantime.
+//
+// Parameters:
+//   - hwnd: The handle to the window containing the text input
+//   - id: The ID of the text input component
+//   - sel: The selection to paste, SelectionClipboard for the paste command
+func handleTextInputPaste(hwnd, id uintptr, sel Selection) {
+	go func() {
+		text, err := GetSelectionText(sel)
+		if err != nil || text == "" {
+			return
+		}
+		PostInvoke(hwnd, func() {
+			if HLTR.TextInputID != id {
+				return
+			}
+			editTextInput(id, textedit.EditPaste, func(b *textedit.Buffer) bool { return b.Insert(text) })
+		})
+	}()
 }
 
-func updateTextInputSelection(id uintptr, hwnd uintptr, mouseX int32, event string) {
+func updateTextInputSelection(id uintptr, hwnd uintptr, mouseX, mouseY int32, event string) {
 	if HLTR.SuppressSelection {
 		return
 	}
-	caret := getCaretPosForTextInput(id, hwnd, mouseX)
+	caret := getCaretPosForTextInput(id, hwnd, mouseX, mouseY)
 	switch event {
 	case "start":
 		HLTR.TextInputID = id
@@ -315,7 +376,7 @@ func updateTextInputSelection(id uintptr, hwnd uintptr, mouseX int32, event stri
 		HLTR.SelectionEnd = caret
 	case "update":
 		if HLTR.Active && HLTR.TextInputID == id {
-			HLTR.SelectionEnd = caret
+			HLTR.SelectionEnd = dragCaret(id, caret, mouseX)
 		}
 	case "end":
 		if HLTR.Active && HLTR.TextInputID == id {
@@ -326,11 +387,14 @@ func updateTextInputSelection(id uintptr, hwnd uintptr, mouseX int32, event stri
 	handleTextInputSelectionCallbacks(id, HLTR.SelectionStart, HLTR.SelectionEnd)
 }
 
-func getCaretPosForTextInput(id uintptr, hwnd uintptr, mouseX int32) int32 {
+func getCaretPosForTextInput(id uintptr, hwnd uintptr, mouseX, mouseY int32) int32 {
 	state := GetTextInputState(id)
 	if state == nil {
 		return 0
 	}
+	if state.Multiline {
+		return state.PosAt(mouseX, mouseY, textMeasure(state))
+	}
 	rect := [4]int32{
 		state.Bounds.X,
 		state.Bounds.Y,
@@ -338,7 +402,7 @@ func getCaretPosForTextInput(id uintptr, hwnd uintptr, mouseX int32) int32 {
 		state.Bounds.Y + state.Bounds.Height,
 	}
 	fontInfo := state.Font
-	text := state.Value
+	text := state.DisplayText()
 	// You may need to implement or adapt a font measurement function for Linux/X11
 	fontName := fontInfo.Name
 	fontSize := fontInfo.Size
@@ -347,7 +411,40 @@ func getCaretPosForTextInput(id uintptr, hwnd uintptr, mouseX int32) int32 {
 	window := C_Window(hwnd)
 	// Use the window as the drawable for measurement
 	padding := int32(4)
-	return caretPosFromClickLinux(display, window, fontName, fontSize, text, rect[0], mouseX, padding)
+	return caretPosFromClickLinux(display, window, fontName, fontSize, text, rect[0]-state.Scroll.X, mouseX, padding)
+}
+
+// dragCaret moves the caret of a drag selection a character past the visible text while the pointer is beyond the
+// left or right edge of a single-line text input, which the text input then scrolls into view.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - caret: The position under the pointer
+//   - mouseX: The X coordinate of the pointer
+//
+// Returns:
+//   - int32: The new caret position
+func dragCaret(id uintptr, caret, mouseX int32) int32 {
+	state := GetTextInputState(id)
+	if state == nil || state.Multiline {
+		return caret
+	}
+	return min(max(caret+state.EdgeStep(mouseX), 0), int32(len([]rune(state.Value))))
+}
+
+// textMeasure returns a function measuring text in the font of a text input, which lays out the lines of text areas.
+//
+// Parameters:
+//   - state: The state of the text input
+//
+// Returns:
+//   - func(string) int32: The width of a piece of text in pixels
+func textMeasure(state *common.TextInputState) func(text string) int32 {
+	name, size := state.Font.Name, int(state.Font.Size)
+	return func(text string) int32 {
+		w, _ := MeasureText(name, size, text)
+		return int32(w)
+	}
 }
 
 func caretPosFromClickLinux(display *C_Display, drawable C_Drawable, fontName string, fontSize int32, text string, inputX, clickX, padding int32) int32 {
@@ -377,39 +474,3 @@ func caretPosFromClickLinux(display *C_Display, drawable C_Drawable, fontName st
 	}
 	return int32(low)
 }
-
-// getWordBounds calculates the start and end positions of a word in the given text.
-func getWordBounds(text string, caret int32) (int32, int32) {
-	runes := []rune(text)
-	n := int32(len(runes))
-	if n == 0 || caret < 0 || caret > n {
-		return 0, 0
-	}
-	// If caret is at the end, move back one to select the last word
-	if caret == n {
-		caret--
-	}
-	// If caret is on a space, move left to the nearest non-space
-	for caret > 0 && isSeparator(runes[caret]) {
-		caret--
-	}
-	// If still on a space, no word to select
-	if isSeparator(runes[caret]) {
-		return caret, caret
-	}
-	// Find word start
-	start := caret
-	for start > 0 && !isSeparator(runes[start-1]) {
-		start--
-	}
-	// Find word end
-	end := caret + 1
-	for end < n && !isSeparator(runes[end]) {
-		end++
-	}
-	return start, end
-}
-
-func isSeparator(ch rune) bool {
-	return ch == ' ' || ch == '/' || ch == '\\' || ch == '.'
-}
@@ -0,0 +1,105 @@
+//go:build linux
+// +build linux
+
+package linux
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+*/
+import "C"
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+)
+
+// tooltipWindow is the override-redirect popup showing the tooltip of a window.
+// Its content is painted once into a pixmap set as the window's background, so the server repaints it without Expose handling.
+// Unlike the selector overlay, a single full-screen window on its own display and thread, it lives on the owner's display and loop.
+type tooltipWindow struct {
+	window C.Window
+	pixmap C.Pixmap
+}
+
+var (
+	tooltipMap = make(map[uintptr]*tooltipWindow)
+	tooltipMu  sync.Mutex
+)
+
+// ShowTooltip shows a tooltip for the window in an override-redirect popup, replacing the tooltip the window already shows.
+// The popup is placed near the pointer and kept within the screen, see common.PlaceTooltip.
+//
+// Parameters:
+//   - hwnd: The handle to the window the tooltip belongs to
+//   - x: The x-coordinate of the pointer in window coordinates
+//   - y: The y-coordinate of the pointer in window coordinates
+//   - tip: The text and colors of the tooltip
+func ShowTooltip(hwnd uintptr, x, y int32, tip common.Tooltip) {
+	HideTooltip(hwnd)
+	display := GetDisplay(hwnd)
+	if display == nil || tip.Text == "" {
+		return
+	}
+	screen := C.XDefaultScreen(display)
+	root := C.XRootWindow(display, screen)
+
+	var rootX, rootY C.int
+	var child C.Window
+	C.XTranslateCoordinates(display, C.Window(hwnd), root, C.int(x), C.int(y), &rootX, &rootY, &child)
+
+	textW, textH := MeasureText(tip.Font, int(tip.FontSize), tip.Text)
+	w := int32(textW) + 2*tip.Padding
+	h := int32(textH) + 2*tip.Padding
+	screenW, screenH := getScreenSize(display, screen)
+	px, py := common.PlaceTooltip(int32(rootX), int32(rootY), w, h, int32(screenW), int32(screenH))
+
+	var attrs C.XSetWindowAttributes
+	attrs.override_redirect = 1
+	attrs.save_under = 1
+	win := C.XCreateWindow(
+		display, root,
+		C.int(px), C.int(py), C.uint(w), C.uint(h),
+		0,
+		C.CopyFromParent,
+		C.InputOutput,
+		nil,
+		C.CWOverrideRedirect|C.CWSaveUnder,
+		&attrs,
+	)
+
+	depth := C.uint(C.XDefaultDepth(display, screen))
+	pixmap := C.XCreatePixmap(display, C.Drawable(win), C.uint(w), C.uint(h), depth)
+	drawable := C.Drawable(pixmap)
+	XFillRect(display, drawable, 0, 0, int(w), int(h), tip.Border)
+	XFillRect(display, drawable, 1, 1, int(w)-2, int(h)-2, tip.Background)
+	XDrawTextRect(display, drawable, int(tip.Padding), int(tip.Padding), textW, textH, tip.Font, int(tip.FontSize), tip.Text, tip.Color,
+		ALIGN_LEFT|ALIGN_VCENTER|ALIGN_SINGLELINE)
+
+	C.XSetWindowBackgroundPixmap(display, win, pixmap)
+	C.XMapRaised(display, win)
+	C.XFlush(display)
+
+	tooltipMu.Lock()
+	tooltipMap[hwnd] = &tooltipWindow{window: win, pixmap: pixmap}
+	tooltipMu.Unlock()
+}
+
+// HideTooltip hides the tooltip of the window, if it shows one.
+//
+// Parameters:
+//   - hwnd: The handle to the window the tooltip belongs to
+func HideTooltip(hwnd uintptr) {
+	tooltipMu.Lock()
+	tip := tooltipMap[hwnd]
+	delete(tooltipMap, hwnd)
+	tooltipMu.Unlock()
+
+	display := GetDisplay(hwnd)
+	if tip == nil || display == nil {
+		return
+	}
+	C.XDestroyWindow(display, tip.window)
+	C.XFreePixmap(display, tip.pixmap)
+	C.XFlush(display)
+}
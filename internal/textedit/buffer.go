@@ -0,0 +1,462 @@
+// Package textedit is the platform-neutral editing model behind text inputs.
+// A Buffer owns the text, the selection and the caret and enforces the maximum length, so the backends only translate
+// their native key presses into commands and copy the result back into the text input's state.
+package textedit
+
+// Buffer is the editable value of a text input together with its selection.
+//
+// The selection runs from the anchor, the end that stays put while Shift extends the selection, to the caret.
+// Positions count characters, not bytes, and always lie between 0 and Len.
+type Buffer struct {
+	runes     []rune
+	anchor    int32
+	caret     int32
+	maxLength int32
+	multiline bool
+	maxLines  int32
+	layout    *Layout
+	layoutFn  func() *Layout
+	// accept and valid filter inserted text, see SetFilter.
+	accept func(ch rune) bool
+	valid  func(value string) bool
+	// goal is the horizontal offset moving the caret up and down aims for, -1 when it follows the caret.
+	goal int32
+}
+
+// New creates a buffer holding value with the caret at its end.
+// Control characters in value are kept, only text inserted later is filtered.
+//
+// Parameters:
+//   - value: The initial text.
+//   - maxLength: The maximum number of characters, 0 for no limit.
+//
+// Returns:
+//   - *Buffer: The new buffer.
+func New(value string, maxLength int32) *Buffer {
+	b := &Buffer{runes: []rune(value), maxLength: maxLength, goal: -1}
+	b.anchor, b.caret = b.Len(), b.Len()
+	return b
+}
+
+// Value returns the text of the buffer.
+//
+// Returns:
+//   - string: The text.
+func (b *Buffer) Value() string {
+	return string(b.runes)
+}
+
+// Len returns the number of characters in the buffer.
+//
+// Returns:
+//   - int32: The length of the text in characters.
+func (b *Buffer) Len() int32 {
+	return int32(len(b.runes))
+}
+
+// SetMultiline lets the buffer hold line breaks, as the value of a text area does.
+//
+// Parameters:
+//   - maxLines: The maximum number of lines, 0 for no limit.
+func (b *Buffer) SetMultiline(maxLines int32) {
+	b.multiline = true
+	b.maxLines = maxLines
+}
+
+// SetLayout sets the visual lines moving the caret up and down, by pages and to the start or end of a line follows.
+// Laying out text is costly, so the layout is only asked for the first time a move needs it.
+// Without a layout the whole value is a single line.
+//
+// Parameters:
+//   - layout: Returns the layout of the current value.
+func (b *Buffer) SetLayout(layout func() *Layout) {
+	b.layout, b.layoutFn = nil, layout
+}
+
+// SetFilter limits what Insert accepts. Characters accept rejects are dropped and the rest still inserted,
+// an insertion whose resulting value valid rejects is dropped as a whole.
+//
+// Parameters:
+//   - accept: Reports whether a single character may be inserted, nil for any.
+//   - valid: Reports whether the value an insertion results in is acceptable, nil for any.
+func (b *Buffer) SetFilter(accept func(ch rune) bool, valid func(value string) bool) {
+	b.accept, b.valid = accept, valid
+}
+
+// Goal returns the horizontal offset moving the caret up and down aims for, so the caret keeps its column across short lines.
+//
+// Returns:
+//   - int32: The offset in pixels, -1 when the caret was moved or edited otherwise since.
+func (b *Buffer) Goal() int32 {
+	return b.goal
+}
+
+// SetGoal restores the horizontal offset of an earlier vertical move, see Goal.
+//
+// Parameters:
+//   - goal: The offset in pixels, -1 for none.
+func (b *Buffer) SetGoal(goal int32) {
+	b.goal = goal
+}
+
+// MaxLength returns the maximum number of characters the buffer accepts.
+//
+// Returns:
+//   - int32: The maximum length, 0 for no limit.
+func (b *Buffer) MaxLength() int32 {
+	return b.maxLength
+}
+
+// Anchor returns the end of the selection which stays put while the selection is extended.
+//
+// Returns:
+//   - int32: The anchor position.
+func (b *Buffer) Anchor() int32 {
+	return b.anchor
+}
+
+// Caret returns the caret position, the moving end of the selection.
+//
+// Returns:
+//   - int32: The caret position.
+func (b *Buffer) Caret() int32 {
+	return b.caret
+}
+
+// Selection returns the selected range ordered from start to end, which is empty when nothing is selected.
+//
+// Returns:
+//   - int32: The start of the selection.
+//   - int32: The end of the selection.
+func (b *Buffer) Selection() (int32, int32) {
+	return min(b.anchor, b.caret), max(b.anchor, b.caret)
+}
+
+// HasSelection reports whether any text is selected.
+//
+// Returns:
+//   - bool: True if the selection is not empty.
+func (b *Buffer) HasSelection() bool {
+	return b.anchor != b.caret
+}
+
+// SelectedText returns the selected text.
+//
+// Returns:
+//   - string: The selected text, empty when nothing is selected.
+func (b *Buffer) SelectedText() string {
+	start, end := b.Selection()
+	return string(b.runes[start:end])
+}
+
+// Select sets the selection, clamping both ends to the text.
+// Passing the same position twice places the caret without selecting anything.
+//
+// Parameters:
+//   - anchor: The end of the selection that stays put.
+//   - caret: The caret position.
+func (b *Buffer) Select(anchor, caret int32) {
+	b.anchor, b.caret = b.clamp(anchor), b.clamp(caret)
+	b.goal = -1
+}
+
+// SelectAll selects the whole text with the caret at its end.
+func (b *Buffer) SelectAll() {
+	b.anchor, b.caret = 0, b.Len()
+	b.goal = -1
+}
+
+// SelectWordAt selects the word at pos, as double-clicking does.
+//
+// Parameters:
+//   - pos: The position inside or directly after the word.
+func (b *Buffer) SelectWordAt(pos int32) {
+	b.anchor, b.caret = wordAt(b.runes, b.clamp(pos))
+	b.goal = -1
+}
+
+// MoveTo moves the caret to pos, extending the selection from the anchor when extend is set and collapsing it otherwise.
+//
+// Parameters:
+//   - pos: The new caret position, clamped to the text.
+//   - extend: True to keep the anchor and extend the selection.
+func (b *Buffer) MoveTo(pos int32, extend bool) {
+	b.caret = b.clamp(pos)
+	if !extend {
+		b.anchor = b.caret
+	}
+	b.goal = -1
+}
+
+// MoveLines moves the caret up, for negative lines, or down by a number of visual lines, keeping its horizontal offset
+// across shorter lines. Moving past the first or last line goes to the start or end of the value.
+// Without a layout nothing happens.
+//
+// Parameters:
+//   - lines: The number of lines to move by.
+//   - extend: True to extend the selection.
+func (b *Buffer) MoveLines(lines int, extend bool) {
+	layout := b.lines()
+	if layout == nil || lines == 0 {
+		return
+	}
+	goal := b.goal
+	if goal < 0 {
+		goal = layout.X(b.caret)
+	}
+	target := layout.LineOf(b.caret) + lines
+	switch {
+	case target < 0:
+		b.MoveTo(0, extend)
+	case target >= len(layout.Lines):
+		b.MoveTo(b.Len(), extend)
+	default:
+		b.MoveTo(layout.PosAt(target, goal), extend)
+	}
+	b.goal = goal
+}
+
+// MoveLineStart moves the caret to the start of its visual line, or of the value without a layout.
+//
+// Parameters:
+//   - extend: True to extend the selection.
+func (b *Buffer) MoveLineStart(extend bool) {
+	layout := b.lines()
+	if layout == nil {
+		b.MoveTo(0, extend)
+		return
+	}
+	b.MoveTo(layout.Lines[layout.LineOf(b.caret)].Start, extend)
+}
+
+// MoveLineEnd moves the caret to the end of its visual line, or of the value without a layout.
+// On a wrapped line the caret stops before the wrap so it stays on the line.
+//
+// Parameters:
+//   - extend: True to extend the selection.
+func (b *Buffer) MoveLineEnd(extend bool) {
+	layout := b.lines()
+	if layout == nil {
+		b.MoveTo(b.Len(), extend)
+		return
+	}
+	ln := layout.Lines[layout.LineOf(b.caret)]
+	end := ln.End
+	if ln.Wrapped && end > ln.Start {
+		end--
+	}
+	b.MoveTo(end, extend)
+}
+
+// MoveLeft moves the caret one character or, with word set, one word to the left.
+// Without extend and word, a selection collapses onto its start instead.
+//
+// Parameters:
+//   - word: True to move to the start of the previous word.
+//   - extend: True to extend the selection.
+func (b *Buffer) MoveLeft(word, extend bool) {
+	switch {
+	case word:
+		b.MoveTo(wordLeft(b.runes, b.caret), extend)
+	case !extend && b.HasSelection():
+		start, _ := b.Selection()
+		b.MoveTo(start, false)
+	default:
+		b.MoveTo(b.caret-1, extend)
+	}
+}
+
+// MoveRight moves the caret one character or, with word set, one word to the right.
+// Without extend and word, a selection collapses onto its end instead.
+//
+// Parameters:
+//   - word: True to move to the start of the next word.
+//   - extend: True to extend the selection.
+func (b *Buffer) MoveRight(word, extend bool) {
+	switch {
+	case word:
+		b.MoveTo(wordRight(b.runes, b.caret), extend)
+	case !extend && b.HasSelection():
+		_, end := b.Selection()
+		b.MoveTo(end, false)
+	default:
+		b.MoveTo(b.caret+1, extend)
+	}
+}
+
+// Insert replaces the selection with text, leaving the caret after the inserted text.
+// Control characters are dropped, except for line breaks in a multiline buffer which are normalized to "\n",
+// and so are characters the filter rejects.
+// Text beyond the maximum length or the maximum number of lines is cut off.
+//
+// Parameters:
+//   - text: The text to insert.
+//
+// Returns:
+//   - bool: True if the value changed.
+func (b *Buffer) Insert(text string) bool {
+	start, end := b.Selection()
+	breaks := int32(0)
+	if b.multiline && b.maxLines > 0 {
+		// the line breaks outside of the selection are kept, each one inserted starts another line
+		breaks = countBreaks(b.runes) - countBreaks(b.runes[start:end])
+	}
+	ins := make([]rune, 0, len(text))
+	prev := rune(0)
+scan:
+	for _, ch := range text {
+		switch {
+		case b.multiline && ch == '\n' && prev == '\r':
+			// the break was added for the carriage return already
+		case b.multiline && (ch == '\n' || ch == '\r'):
+			if b.maxLines > 0 && breaks+1 >= b.maxLines {
+				break scan
+			}
+			breaks++
+			ins = append(ins, '\n')
+		case !isControl(ch):
+			ins = append(ins, ch)
+		}
+		prev = ch
+	}
+	if len(ins) > 0 && b.accept != nil {
+		if ins = b.filter(ins); len(ins) == 0 {
+			// nothing typed or pasted made it through, leave the selection alone
+			return false
+		}
+	}
+	if b.maxLength > 0 {
+		room := b.maxLength - (b.Len() - (end - start))
+		if room < 0 {
+			room = 0
+		}
+		if int32(len(ins)) > room {
+			ins = ins[:room]
+		}
+	}
+	if len(ins) == 0 && start == end {
+		return false
+	}
+	if b.valid != nil && !b.valid(string(b.spliced(start, end, ins))) {
+		// the value the insertion results in is checked once, after cutting it to the maximum length
+		return false
+	}
+	b.replace(start, end, ins)
+	return true
+}
+
+// filter returns the characters of ins which the filter accepts.
+func (b *Buffer) filter(ins []rune) []rune {
+	kept := ins[:0]
+	for _, ch := range ins {
+		if b.accept(ch) {
+			kept = append(kept, ch)
+		}
+	}
+	return kept
+}
+
+// DeleteSelection removes the selected text.
+//
+// Returns:
+//   - bool: True if anything was selected and removed.
+func (b *Buffer) DeleteSelection() bool {
+	if !b.HasSelection() {
+		return false
+	}
+	start, end := b.Selection()
+	b.replace(start, end, nil)
+	return true
+}
+
+// DeleteBackward removes the selection, or else the character or, with word set, the word before the caret, as Backspace does.
+//
+// Parameters:
+//   - word: True to remove up to the start of the previous word.
+//
+// Returns:
+//   - bool: True if the value changed.
+func (b *Buffer) DeleteBackward(word bool) bool {
+	if b.DeleteSelection() {
+		return true
+	}
+	start := b.caret - 1
+	if word {
+		start = wordLeft(b.runes, b.caret)
+	}
+	if start < 0 || start == b.caret {
+		return false
+	}
+	b.replace(start, b.caret, nil)
+	return true
+}
+
+// DeleteForward removes the selection, or else the character or, with word set, the word after the caret, as Delete does.
+//
+// Parameters:
+//   - word: True to remove up to the start of the next word.
+//
+// Returns:
+//   - bool: True if the value changed.
+func (b *Buffer) DeleteForward(word bool) bool {
+	if b.DeleteSelection() {
+		return true
+	}
+	end := b.caret + 1
+	if word {
+		end = wordRight(b.runes, b.caret)
+	}
+	if end > b.Len() || end == b.caret {
+		return false
+	}
+	b.replace(b.caret, end, nil)
+	return true
+}
+
+// spliced returns the text with the characters between start and end swapped for ins, leaving the buffer alone.
+func (b *Buffer) spliced(start, end int32, ins []rune) []rune {
+	runes := make([]rune, 0, int(b.Len()-(end-start))+len(ins))
+	runes = append(runes, b.runes[:start]...)
+	runes = append(runes, ins...)
+	return append(runes, b.runes[end:]...)
+}
+
+// replace swaps the characters between start and end for ins and collapses the selection after them.
+func (b *Buffer) replace(start, end int32, ins []rune) {
+	b.runes = b.spliced(start, end, ins)
+	b.anchor = start + int32(len(ins))
+	b.caret = b.anchor
+	b.goal = -1
+	// the layout was made for the old value
+	b.layout, b.layoutFn = nil, nil
+}
+
+// lines returns the layout of the buffer, asking for it on first use, or nil if there is none.
+func (b *Buffer) lines() *Layout {
+	if b.layout == nil && b.layoutFn != nil {
+		b.layout = b.layoutFn()
+		b.layoutFn = nil
+	}
+	return b.layout
+}
+
+// clamp limits a position to the text.
+func (b *Buffer) clamp(pos int32) int32 {
+	return min(max(pos, 0), b.Len())
+}
+
+// countBreaks returns the number of line breaks in runes.
+func countBreaks(runes []rune) int32 {
+	var n int32
+	for _, ch := range runes {
+		if ch == '\n' {
+			n++
+		}
+	}
+	return n
+}
+
+// isControl reports whether ch is a control character, which typing and pasting never insert.
+func isControl(ch rune) bool {
+	return ch < 32 || ch == 127
+}
@@ -0,0 +1,219 @@
+package textedit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		maxLength   int32
+		anchor      int32
+		caret       int32
+		text        string
+		want        string
+		wantCaret   int32
+		wantChanged bool
+	}{
+		{name: "append", value: "abc", anchor: 3, caret: 3, text: "de", want: "abcde", wantCaret: 5, wantChanged: true},
+		{name: "insert in the middle", value: "ac", anchor: 1, caret: 1, text: "b", want: "abc", wantCaret: 2, wantChanged: true},
+		{name: "replace selection", value: "hello", anchor: 1, caret: 4, text: "ipp", want: "hippo", wantCaret: 4, wantChanged: true},
+		{name: "control characters dropped", value: "", text: "a\tb\x7f\r\nc", want: "abc", wantCaret: 3, wantChanged: true},
+		{name: "only control characters", value: "ab", anchor: 2, caret: 2, text: "\x1b", want: "ab", wantCaret: 2},
+		{name: "multi-byte runes", value: "日本", anchor: 1, caret: 1, text: "ä", want: "日ä本", wantCaret: 2, wantChanged: true},
+		{name: "truncated to max length", value: "abc", maxLength: 5, anchor: 3, caret: 3, text: "defg", want: "abcde", wantCaret: 5, wantChanged: true},
+		{name: "truncation counts the replaced selection", value: "abcde", maxLength: 5, anchor: 1, caret: 3, text: "xyz", want: "axyde", wantCaret: 3, wantChanged: true},
+		{name: "full buffer", value: "abcde", maxLength: 5, anchor: 5, caret: 5, text: "f", want: "abcde", wantCaret: 5},
+		{name: "over max length still deletes the selection", value: "abcde", maxLength: 3, anchor: 0, caret: 2, text: "xyz", want: "cde", wantCaret: 0, wantChanged: true},
+		{name: "max length in runes", value: "日本", maxLength: 3, anchor: 2, caret: 2, text: "語です", want: "日本語", wantCaret: 3, wantChanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, tt.maxLength)
+			b.Select(tt.anchor, tt.caret)
+			changed := b.Insert(tt.text)
+			if changed != tt.wantChanged {
+				t.Errorf("Insert(%q) = %v, want %v", tt.text, changed, tt.wantChanged)
+			}
+			if got := b.Value(); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+			if b.Caret() != tt.wantCaret || b.Anchor() != tt.wantCaret {
+				t.Errorf("selection = %d..%d, want the caret collapsed at %d", b.Anchor(), b.Caret(), tt.wantCaret)
+			}
+		})
+	}
+}
+
+func TestInsertFilter(t *testing.T) {
+	digit := func(ch rune) bool { return ch >= '0' && ch <= '9' }
+	noDash := func(value string) bool { return !strings.HasSuffix(value, "-") }
+	tests := []struct {
+		name        string
+		value       string
+		maxLength   int32
+		accept      func(ch rune) bool
+		valid       func(value string) bool
+		text        string
+		want        string
+		wantChanged bool
+	}{
+		{name: "rejected characters dropped", value: "1", accept: digit, text: "2a3", want: "123", wantChanged: true},
+		{name: "every character rejected", value: "1", accept: digit, text: "ab", want: "1"},
+		{name: "valid value", value: "1", valid: noDash, text: "-2", want: "1-2", wantChanged: true},
+		{name: "invalid value rejected as a whole", value: "1", valid: noDash, text: "2-", want: "1"},
+		{name: "validated after truncation", value: "1", maxLength: 3, valid: noDash, text: "2-3", want: "1"},
+		{name: "truncation making the value valid", value: "1", maxLength: 2, valid: noDash, text: "2-", want: "12", wantChanged: true},
+		{name: "both filters", value: "", accept: func(ch rune) bool { return ch != ' ' }, valid: noDash, text: "1 - 2", want: "1-2", wantChanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, tt.maxLength)
+			b.SetFilter(tt.accept, tt.valid)
+			if changed := b.Insert(tt.text); changed != tt.wantChanged {
+				t.Errorf("Insert(%q) = %v, want %v", tt.text, changed, tt.wantChanged)
+			}
+			if got := b.Value(); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertFilterValidatesOnce(t *testing.T) {
+	calls := 0
+	b := New("", 0)
+	b.SetFilter(nil, func(string) bool { calls++; return true })
+	b.Insert(strings.Repeat("x", 10000))
+	if calls != 1 {
+		t.Errorf("valid called %d times for a single paste, want 1", calls)
+	}
+}
+
+func TestInsertMultiline(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		maxLines int32
+		text     string
+		want     string
+	}{
+		{name: "line breaks normalized", text: "a\r\nb\rc\nd", want: "a\nb\nc\nd"},
+		{name: "cut at max lines", maxLines: 2, text: "a\nb\nc", want: "a\nb"},
+		{name: "existing lines count", value: "x\n", maxLines: 2, text: "a\nb", want: "x\na"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, 0)
+			b.SetMultiline(tt.maxLines)
+			b.Insert(tt.text)
+			if got := b.Value(); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelection(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		anchor     int32
+		caret      int32
+		move       func(b *Buffer)
+		wantAnchor int32
+		wantCaret  int32
+	}{
+		{name: "extend right", value: "hello", move: func(b *Buffer) { b.MoveRight(false, true); b.MoveRight(false, true) }, wantAnchor: 0, wantCaret: 2},
+		{name: "extend left", value: "hello", anchor: 5, caret: 5, move: func(b *Buffer) { b.MoveLeft(false, true) }, wantAnchor: 5, wantCaret: 4},
+		{name: "extend across the anchor", value: "hello", anchor: 2, caret: 3, move: func(b *Buffer) { b.MoveLeft(false, true); b.MoveLeft(false, true) }, wantAnchor: 2, wantCaret: 1},
+		{name: "extend by word", value: "hello world", move: func(b *Buffer) { b.MoveRight(true, true) }, wantAnchor: 0, wantCaret: 6},
+		{name: "right collapses onto the end", value: "hello", anchor: 4, caret: 1, move: func(b *Buffer) { b.MoveRight(false, false) }, wantAnchor: 4, wantCaret: 4},
+		{name: "left collapses onto the start", value: "hello", anchor: 1, caret: 4, move: func(b *Buffer) { b.MoveLeft(false, false) }, wantAnchor: 1, wantCaret: 1},
+		{name: "move without selection", value: "hello", anchor: 2, caret: 2, move: func(b *Buffer) { b.MoveRight(false, false) }, wantAnchor: 3, wantCaret: 3},
+		{name: "move stops at the start", value: "hello", move: func(b *Buffer) { b.MoveLeft(false, false) }, wantAnchor: 0, wantCaret: 0},
+		{name: "move stops at the end", value: "hello", anchor: 5, caret: 5, move: func(b *Buffer) { b.MoveRight(false, true) }, wantAnchor: 5, wantCaret: 5},
+		{name: "select clamps", value: "hello", move: func(b *Buffer) { b.Select(-3, 42) }, wantAnchor: 0, wantCaret: 5},
+		{name: "select all", value: "hello", anchor: 2, caret: 2, move: (*Buffer).SelectAll, wantAnchor: 0, wantCaret: 5},
+		{name: "select word", value: "hello world", move: func(b *Buffer) { b.SelectWordAt(8) }, wantAnchor: 6, wantCaret: 11},
+		{name: "move to collapses", value: "hello", anchor: 0, caret: 5, move: func(b *Buffer) { b.MoveTo(3, false) }, wantAnchor: 3, wantCaret: 3},
+		{name: "move to extends", value: "hello", anchor: 1, caret: 1, move: func(b *Buffer) { b.MoveTo(3, true) }, wantAnchor: 1, wantCaret: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, 0)
+			b.Select(tt.anchor, tt.caret)
+			tt.move(b)
+			if b.Anchor() != tt.wantAnchor || b.Caret() != tt.wantCaret {
+				t.Errorf("selection = %d..%d, want %d..%d", b.Anchor(), b.Caret(), tt.wantAnchor, tt.wantCaret)
+			}
+			if got, want := b.HasSelection(), tt.wantAnchor != tt.wantCaret; got != want {
+				t.Errorf("HasSelection() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSelectedText(t *testing.T) {
+	b := New("héllo wörld", 0)
+	b.Select(10, 6)
+	if got := b.SelectedText(); got != "wörl" {
+		t.Errorf("SelectedText() = %q, want %q", got, "wörl")
+	}
+	if start, end := b.Selection(); start != 6 || end != 10 {
+		t.Errorf("Selection() = %d, %d, want 6, 10", start, end)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		anchor      int32
+		caret       int32
+		forward     bool
+		word        bool
+		want        string
+		wantCaret   int32
+		wantChanged bool
+	}{
+		{name: "backward at the start", value: "abc", want: "abc"},
+		{name: "backward at the end", value: "abc", anchor: 3, caret: 3, want: "ab", wantCaret: 2, wantChanged: true},
+		{name: "backward in the middle", value: "abc", anchor: 2, caret: 2, want: "ac", wantCaret: 1, wantChanged: true},
+		{name: "backward multi-byte", value: "日本語", anchor: 2, caret: 2, want: "日語", wantCaret: 1, wantChanged: true},
+		{name: "backward word", value: "hello world", anchor: 11, caret: 11, word: true, want: "hello ", wantCaret: 6, wantChanged: true},
+		{name: "backward word over separators", value: "hello   ", anchor: 8, caret: 8, word: true, want: "", wantCaret: 0, wantChanged: true},
+		{name: "backward word at the start", value: "hello", word: true, want: "hello"},
+		{name: "backward selection", value: "hello", anchor: 1, caret: 4, want: "ho", wantCaret: 1, wantChanged: true},
+		{name: "forward at the end", value: "abc", anchor: 3, caret: 3, forward: true, want: "abc", wantCaret: 3},
+		{name: "forward at the start", value: "abc", forward: true, want: "bc", wantCaret: 0, wantChanged: true},
+		{name: "forward multi-byte", value: "日本語", anchor: 1, caret: 1, forward: true, want: "日語", wantCaret: 1, wantChanged: true},
+		{name: "forward word", value: "hello world", forward: true, word: true, want: "world", wantCaret: 0, wantChanged: true},
+		{name: "forward word at the end", value: "hello", anchor: 5, caret: 5, forward: true, word: true, want: "hello", wantCaret: 5},
+		{name: "forward selection", value: "hello", anchor: 4, caret: 1, forward: true, word: true, want: "ho", wantCaret: 1, wantChanged: true},
+		{name: "empty buffer", value: "", forward: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, 0)
+			b.Select(tt.anchor, tt.caret)
+			var changed bool
+			if tt.forward {
+				changed = b.DeleteForward(tt.word)
+			} else {
+				changed = b.DeleteBackward(tt.word)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got := b.Value(); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+			if b.Caret() != tt.wantCaret || b.HasSelection() {
+				t.Errorf("selection = %d..%d, want the caret collapsed at %d", b.Anchor(), b.Caret(), tt.wantCaret)
+			}
+		})
+	}
+}
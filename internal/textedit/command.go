@@ -0,0 +1,222 @@
+package textedit
+
+import "github.com/Carmen-Shannon/gooey/event"
+
+// Command is an editing action triggered by a key press in a text input.
+type Command int
+
+const (
+	CommandNone Command = iota
+	CommandMoveLeft
+	CommandMoveRight
+	CommandMoveWordLeft
+	CommandMoveWordRight
+	CommandMoveHome
+	CommandMoveEnd
+	CommandSelectLeft
+	CommandSelectRight
+	CommandSelectWordLeft
+	CommandSelectWordRight
+	CommandSelectHome
+	CommandSelectEnd
+	// the line and page moves follow the visual lines of the buffer's layout, Home and End move within a line when it has one
+	CommandMoveUp
+	CommandMoveDown
+	CommandMovePageUp
+	CommandMovePageDown
+	CommandMoveTextStart
+	CommandMoveTextEnd
+	CommandSelectUp
+	CommandSelectDown
+	CommandSelectPageUp
+	CommandSelectPageDown
+	CommandSelectTextStart
+	CommandSelectTextEnd
+	CommandSelectAll
+	// CommandNewline breaks the line in a multiline buffer and does nothing otherwise.
+	CommandNewline
+	CommandDeleteBackward
+	CommandDeleteForward
+	CommandDeleteWordBackward
+	CommandDeleteWordForward
+	// CommandCopy, CommandCut and CommandPaste need the clipboard, which the backend owns, Apply leaves them alone.
+	CommandCopy
+	CommandCut
+	CommandPaste
+	// CommandUndo and CommandRedo need the text input's history, Apply leaves them alone.
+	CommandUndo
+	CommandRedo
+)
+
+// CommandForKey returns the editing command bound to a key press.
+// Ctrl, or Super, turns moves and deletions into word-wise ones and selects the clipboard commands, Shift extends the selection.
+// Shift+Delete, Ctrl+Insert and Shift+Insert are the classic cut, copy and paste keys.
+// Ctrl+Home and Ctrl+End go to the start and end of the whole text, Enter breaks the line.
+// Ctrl+Z undoes, Ctrl+Y and Ctrl+Shift+Z redo.
+//
+// Parameters:
+//   - key: The pressed key.
+//   - mods: The modifiers held while the key was pressed.
+//
+// Returns:
+//   - Command: The bound command, or CommandNone if the key is not an editing key.
+func CommandForKey(key event.Key, mods event.Modifiers) Command {
+	if mods.Has(event.ModAlt) {
+		return CommandNone
+	}
+	ctrl := mods.Has(event.ModCtrl) || mods.Has(event.ModSuper)
+	shift := mods.Has(event.ModShift)
+
+	pick := func(move, sel Command) Command {
+		if shift {
+			return sel
+		}
+		return move
+	}
+	switch key {
+	case event.KeyLeft:
+		if ctrl {
+			return pick(CommandMoveWordLeft, CommandSelectWordLeft)
+		}
+		return pick(CommandMoveLeft, CommandSelectLeft)
+	case event.KeyRight:
+		if ctrl {
+			return pick(CommandMoveWordRight, CommandSelectWordRight)
+		}
+		return pick(CommandMoveRight, CommandSelectRight)
+	case event.KeyUp:
+		if !ctrl {
+			return pick(CommandMoveUp, CommandSelectUp)
+		}
+	case event.KeyDown:
+		if !ctrl {
+			return pick(CommandMoveDown, CommandSelectDown)
+		}
+	case event.KeyPageUp:
+		if !ctrl {
+			return pick(CommandMovePageUp, CommandSelectPageUp)
+		}
+	case event.KeyPageDown:
+		if !ctrl {
+			return pick(CommandMovePageDown, CommandSelectPageDown)
+		}
+	case event.KeyHome:
+		if ctrl {
+			return pick(CommandMoveTextStart, CommandSelectTextStart)
+		}
+		return pick(CommandMoveHome, CommandSelectHome)
+	case event.KeyEnd:
+		if ctrl {
+			return pick(CommandMoveTextEnd, CommandSelectTextEnd)
+		}
+		return pick(CommandMoveEnd, CommandSelectEnd)
+	case event.KeyEnter:
+		if !ctrl {
+			return CommandNewline
+		}
+	case event.KeyBackspace:
+		if ctrl {
+			return CommandDeleteWordBackward
+		}
+		return CommandDeleteBackward
+	case event.KeyDelete:
+		switch {
+		case shift && !ctrl:
+			return CommandCut
+		case ctrl:
+			return CommandDeleteWordForward
+		}
+		return CommandDeleteForward
+	case event.KeyInsert:
+		switch {
+		case ctrl && !shift:
+			return CommandCopy
+		case shift && !ctrl:
+			return CommandPaste
+		}
+	case event.KeyA:
+		if ctrl && !shift {
+			return CommandSelectAll
+		}
+	case event.KeyC:
+		if ctrl && !shift {
+			return CommandCopy
+		}
+	case event.KeyX:
+		if ctrl && !shift {
+			return CommandCut
+		}
+	case event.KeyV:
+		if ctrl && !shift {
+			return CommandPaste
+		}
+	case event.KeyZ:
+		switch {
+		case ctrl && shift:
+			return CommandRedo
+		case ctrl:
+			return CommandUndo
+		}
+	case event.KeyY:
+		if ctrl && !shift {
+			return CommandRedo
+		}
+	}
+	return CommandNone
+}
+
+// Apply runs an editing command on the buffer.
+// The clipboard and history commands are not applied, the backend reads or writes the clipboard and calls SelectedText,
+// DeleteSelection or Insert itself, and undoes or redoes through the text input's History.
+//
+// Parameters:
+//   - cmd: The command to run.
+//
+// Returns:
+//   - bool: True if the value changed.
+func (b *Buffer) Apply(cmd Command) bool {
+	switch cmd {
+	case CommandMoveLeft, CommandSelectLeft:
+		b.MoveLeft(false, cmd == CommandSelectLeft)
+	case CommandMoveRight, CommandSelectRight:
+		b.MoveRight(false, cmd == CommandSelectRight)
+	case CommandMoveWordLeft, CommandSelectWordLeft:
+		b.MoveLeft(true, cmd == CommandSelectWordLeft)
+	case CommandMoveWordRight, CommandSelectWordRight:
+		b.MoveRight(true, cmd == CommandSelectWordRight)
+	case CommandMoveHome, CommandSelectHome:
+		b.MoveLineStart(cmd == CommandSelectHome)
+	case CommandMoveEnd, CommandSelectEnd:
+		b.MoveLineEnd(cmd == CommandSelectEnd)
+	case CommandMoveUp, CommandSelectUp:
+		b.MoveLines(-1, cmd == CommandSelectUp)
+	case CommandMoveDown, CommandSelectDown:
+		b.MoveLines(1, cmd == CommandSelectDown)
+	case CommandMovePageUp, CommandSelectPageUp:
+		b.MoveLines(-b.pageLines(), cmd == CommandSelectPageUp)
+	case CommandMovePageDown, CommandSelectPageDown:
+		b.MoveLines(b.pageLines(), cmd == CommandSelectPageDown)
+	case CommandMoveTextStart, CommandSelectTextStart:
+		b.MoveTo(0, cmd == CommandSelectTextStart)
+	case CommandMoveTextEnd, CommandSelectTextEnd:
+		b.MoveTo(b.Len(), cmd == CommandSelectTextEnd)
+	case CommandSelectAll:
+		b.SelectAll()
+	case CommandNewline:
+		return b.multiline && b.Insert("\n")
+	case CommandDeleteBackward, CommandDeleteWordBackward:
+		return b.DeleteBackward(cmd == CommandDeleteWordBackward)
+	case CommandDeleteForward, CommandDeleteWordForward:
+		return b.DeleteForward(cmd == CommandDeleteWordForward)
+	}
+	return false
+}
+
+// pageLines returns the number of lines a page move goes by.
+func (b *Buffer) pageLines() int {
+	layout := b.lines()
+	if layout == nil || layout.PageLines < 1 {
+		return 1
+	}
+	return layout.PageLines
+}
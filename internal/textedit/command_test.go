@@ -0,0 +1,146 @@
+package textedit
+
+import (
+	"testing"
+
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+func TestCommandForKey(t *testing.T) {
+	ctrl, shift := event.ModCtrl, event.ModShift
+	tests := []struct {
+		name string
+		key  event.Key
+		mods event.Modifiers
+		want Command
+	}{
+		{name: "left", key: event.KeyLeft, want: CommandMoveLeft},
+		{name: "shift left", key: event.KeyLeft, mods: shift, want: CommandSelectLeft},
+		{name: "ctrl left", key: event.KeyLeft, mods: ctrl, want: CommandMoveWordLeft},
+		{name: "ctrl shift left", key: event.KeyLeft, mods: ctrl | shift, want: CommandSelectWordLeft},
+		{name: "super right", key: event.KeyRight, mods: event.ModSuper, want: CommandMoveWordRight},
+		{name: "ctrl shift right", key: event.KeyRight, mods: ctrl | shift, want: CommandSelectWordRight},
+		{name: "up", key: event.KeyUp, want: CommandMoveUp},
+		{name: "shift down", key: event.KeyDown, mods: shift, want: CommandSelectDown},
+		{name: "ctrl up", key: event.KeyUp, mods: ctrl, want: CommandNone},
+		{name: "page down", key: event.KeyPageDown, want: CommandMovePageDown},
+		{name: "shift page up", key: event.KeyPageUp, mods: shift, want: CommandSelectPageUp},
+		{name: "home", key: event.KeyHome, want: CommandMoveHome},
+		{name: "shift end", key: event.KeyEnd, mods: shift, want: CommandSelectEnd},
+		{name: "ctrl home", key: event.KeyHome, mods: ctrl, want: CommandMoveTextStart},
+		{name: "ctrl shift end", key: event.KeyEnd, mods: ctrl | shift, want: CommandSelectTextEnd},
+		{name: "enter", key: event.KeyEnter, want: CommandNewline},
+		{name: "ctrl enter", key: event.KeyEnter, mods: ctrl, want: CommandNone},
+		{name: "backspace", key: event.KeyBackspace, want: CommandDeleteBackward},
+		{name: "ctrl backspace", key: event.KeyBackspace, mods: ctrl, want: CommandDeleteWordBackward},
+		{name: "delete", key: event.KeyDelete, want: CommandDeleteForward},
+		{name: "ctrl delete", key: event.KeyDelete, mods: ctrl, want: CommandDeleteWordForward},
+		{name: "shift delete", key: event.KeyDelete, mods: shift, want: CommandCut},
+		{name: "ctrl insert", key: event.KeyInsert, mods: ctrl, want: CommandCopy},
+		{name: "shift insert", key: event.KeyInsert, mods: shift, want: CommandPaste},
+		{name: "insert", key: event.KeyInsert, want: CommandNone},
+		{name: "ctrl a", key: event.KeyA, mods: ctrl, want: CommandSelectAll},
+		{name: "ctrl c", key: event.KeyC, mods: ctrl, want: CommandCopy},
+		{name: "ctrl x", key: event.KeyX, mods: ctrl, want: CommandCut},
+		{name: "ctrl v", key: event.KeyV, mods: ctrl, want: CommandPaste},
+		{name: "super v", key: event.KeyV, mods: event.ModSuper, want: CommandPaste},
+		{name: "ctrl shift v", key: event.KeyV, mods: ctrl | shift, want: CommandNone},
+		{name: "ctrl z", key: event.KeyZ, mods: ctrl, want: CommandUndo},
+		{name: "ctrl shift z", key: event.KeyZ, mods: ctrl | shift, want: CommandRedo},
+		{name: "ctrl y", key: event.KeyY, mods: ctrl, want: CommandRedo},
+		{name: "plain letter", key: event.KeyA, want: CommandNone},
+		{name: "alt left", key: event.KeyLeft, mods: event.ModAlt, want: CommandNone},
+		{name: "ctrl alt v", key: event.KeyV, mods: ctrl | event.ModAlt, want: CommandNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommandForKey(tt.key, tt.mods); got != tt.want {
+				t.Errorf("CommandForKey(%v, %v) = %v, want %v", tt.key, tt.mods, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		multiline   bool
+		anchor      int32
+		caret       int32
+		cmd         Command
+		want        string
+		wantAnchor  int32
+		wantCaret   int32
+		wantChanged bool
+	}{
+		{name: "move left", value: "abc", anchor: 2, caret: 2, cmd: CommandMoveLeft, want: "abc", wantAnchor: 1, wantCaret: 1},
+		{name: "select right", value: "abc", anchor: 1, caret: 1, cmd: CommandSelectRight, want: "abc", wantAnchor: 1, wantCaret: 2},
+		{name: "move word right", value: "ab cd", cmd: CommandMoveWordRight, want: "ab cd", wantAnchor: 3, wantCaret: 3},
+		{name: "select word left", value: "ab cd", anchor: 5, caret: 5, cmd: CommandSelectWordLeft, want: "ab cd", wantAnchor: 5, wantCaret: 3},
+		{name: "home without layout", value: "abc", anchor: 2, caret: 2, cmd: CommandMoveHome, want: "abc", wantAnchor: 0, wantCaret: 0},
+		{name: "select end without layout", value: "abc", anchor: 1, caret: 1, cmd: CommandSelectEnd, want: "abc", wantAnchor: 1, wantCaret: 3},
+		{name: "up without layout", value: "abc", anchor: 2, caret: 2, cmd: CommandMoveUp, want: "abc", wantAnchor: 2, wantCaret: 2},
+		{name: "text start", value: "abc", anchor: 1, caret: 3, cmd: CommandMoveTextStart, want: "abc", wantAnchor: 0, wantCaret: 0},
+		{name: "select text end", value: "abc", anchor: 1, caret: 1, cmd: CommandSelectTextEnd, want: "abc", wantAnchor: 1, wantCaret: 3},
+		{name: "select all", value: "abc", anchor: 1, caret: 1, cmd: CommandSelectAll, want: "abc", wantAnchor: 0, wantCaret: 3},
+		{name: "newline single line", value: "abc", anchor: 1, caret: 1, cmd: CommandNewline, want: "abc", wantAnchor: 1, wantCaret: 1},
+		{name: "newline multiline", value: "abc", multiline: true, anchor: 1, caret: 1, cmd: CommandNewline, want: "a\nbc", wantAnchor: 2, wantCaret: 2, wantChanged: true},
+		{name: "delete backward", value: "abc", anchor: 1, caret: 1, cmd: CommandDeleteBackward, want: "bc", wantAnchor: 0, wantCaret: 0, wantChanged: true},
+		{name: "delete word forward", value: "ab cd", cmd: CommandDeleteWordForward, want: "cd", wantAnchor: 0, wantCaret: 0, wantChanged: true},
+		{name: "copy is left to the backend", value: "abc", anchor: 0, caret: 3, cmd: CommandCopy, want: "abc", wantAnchor: 0, wantCaret: 3},
+		{name: "cut is left to the backend", value: "abc", anchor: 0, caret: 3, cmd: CommandCut, want: "abc", wantAnchor: 0, wantCaret: 3},
+		{name: "undo is left to the backend", value: "abc", anchor: 3, caret: 3, cmd: CommandUndo, want: "abc", wantAnchor: 3, wantCaret: 3},
+		{name: "none", value: "abc", anchor: 1, caret: 2, cmd: CommandNone, want: "abc", wantAnchor: 1, wantCaret: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.value, 0)
+			if tt.multiline {
+				b.SetMultiline(0)
+			}
+			b.Select(tt.anchor, tt.caret)
+			if changed := b.Apply(tt.cmd); changed != tt.wantChanged {
+				t.Errorf("Apply(%v) = %v, want %v", tt.cmd, changed, tt.wantChanged)
+			}
+			if got := b.Value(); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+			if b.Anchor() != tt.wantAnchor || b.Caret() != tt.wantCaret {
+				t.Errorf("selection = %d..%d, want %d..%d", b.Anchor(), b.Caret(), tt.wantAnchor, tt.wantCaret)
+			}
+		})
+	}
+}
+
+func TestApplyLayout(t *testing.T) {
+	// each character is 10 pixels wide, the text breaks into the lines "abc", "de" and "fgh"
+	measure := func(text string) int32 { return 10 * int32(len([]rune(text))) }
+	tests := []struct {
+		name      string
+		caret     int32
+		cmds      []Command
+		wantCaret int32
+	}{
+		{name: "down keeps the column", caret: 1, cmds: []Command{CommandMoveDown}, wantCaret: 5},
+		{name: "down across a short line", caret: 3, cmds: []Command{CommandMoveDown, CommandMoveDown}, wantCaret: 10},
+		{name: "up from the first line", caret: 2, cmds: []Command{CommandMoveUp}, wantCaret: 0},
+		{name: "down from the last line", caret: 8, cmds: []Command{CommandMoveDown}, wantCaret: 10},
+		{name: "home", caret: 6, cmds: []Command{CommandMoveHome}, wantCaret: 4},
+		{name: "end", caret: 4, cmds: []Command{CommandMoveEnd}, wantCaret: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New("abc\nde\nfgh", 0)
+			b.SetMultiline(0)
+			b.MoveTo(tt.caret, false)
+			for _, cmd := range tt.cmds {
+				b.SetLayout(func() *Layout { return NewLayout(b.Value(), 0, measure) })
+				b.Apply(cmd)
+			}
+			if b.Caret() != tt.wantCaret {
+				t.Errorf("caret = %d, want %d", b.Caret(), tt.wantCaret)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package textedit
+
+import "sync"
+
+// DefaultHistoryDepth is the number of undo steps a text input keeps unless configured otherwise.
+const DefaultHistoryDepth = 100
+
+// EditKind tells the history how an edit came about, which decides whether it merges with the previous one.
+type EditKind int
+
+const (
+	// EditTyping is a typed character, consecutive typing forms a single undo step.
+	EditTyping EditKind = iota
+	// EditDelete is a Backspace or Delete, each one its own undo step.
+	EditDelete
+	// EditCut removes the selection into the clipboard.
+	EditCut
+	// EditPaste inserts the clipboard.
+	EditPaste
+)
+
+// Snapshot is the value and selection of a buffer at one point in time.
+type Snapshot struct {
+	Value  string
+	Anchor int32
+	Caret  int32
+}
+
+// Snapshot returns the current value and selection of the buffer.
+//
+// Returns:
+//   - Snapshot: The state of the buffer.
+func (b *Buffer) Snapshot() Snapshot {
+	return Snapshot{Value: b.Value(), Anchor: b.anchor, Caret: b.caret}
+}
+
+// Restore replaces the value and selection of the buffer with a snapshot.
+//
+// Parameters:
+//   - s: The snapshot to restore.
+func (b *Buffer) Restore(s Snapshot) {
+	b.runes = []rune(s.Value)
+	b.layout, b.layoutFn = nil, nil
+	b.Select(s.Anchor, s.Caret)
+}
+
+// historyStep is one undoable edit, holding the buffer as it was before and after the edit.
+type historyStep struct {
+	kind   EditKind
+	before Snapshot
+	after  Snapshot
+}
+
+// History is the undo and redo stack of a text input.
+// Undoing restores the value together with the caret and selection the text input had before the edit,
+// redoing the ones it had after it. It is safe for concurrent use.
+type History struct {
+	mu    sync.Mutex
+	depth int
+	undo  []historyStep
+	redo  []historyStep
+	// sealed stops the next typing from merging into the step on top of the undo stack.
+	sealed bool
+}
+
+// NewHistory creates an empty history.
+//
+// Parameters:
+//   - depth: The maximum number of undo steps kept, the oldest ones are dropped first. 0 or less disables the history.
+//
+// Returns:
+//   - *History: The new history.
+func NewHistory(depth int) *History {
+	return &History{depth: depth}
+}
+
+// Record adds an edit to the history and clears the redo stack.
+// Typing merges into the previous step when that was typing as well and the caret did not move in between.
+// Edits which left the value unchanged are ignored.
+//
+// Parameters:
+//   - kind: How the edit came about.
+//   - before: The buffer before the edit.
+//   - after: The buffer after the edit.
+func (h *History) Record(kind EditKind, before, after Snapshot) {
+	if before.Value == after.Value {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.depth <= 0 {
+		return
+	}
+	h.redo = nil
+	if n := len(h.undo); n > 0 && !h.sealed && kind == EditTyping {
+		if last := &h.undo[n-1]; last.kind == EditTyping && last.after == before {
+			last.after = after
+			return
+		}
+	}
+	h.sealed = false
+	h.undo = append(h.undo, historyStep{kind: kind, before: before, after: after})
+	if over := len(h.undo) - h.depth; over > 0 {
+		h.undo = h.undo[over:]
+	}
+}
+
+// Undo takes the latest step off the undo stack and moves it to the redo stack.
+//
+// Returns:
+//   - Snapshot: The buffer as it was before the undone edit.
+//   - bool: True if there was anything to undo.
+func (h *History) Undo() (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := len(h.undo)
+	if n == 0 {
+		return Snapshot{}, false
+	}
+	step := h.undo[n-1]
+	h.undo = h.undo[:n-1]
+	h.redo = append(h.redo, step)
+	h.sealed = true
+	return step.before, true
+}
+
+// Redo takes the latest undone step off the redo stack and moves it back to the undo stack.
+//
+// Returns:
+//   - Snapshot: The buffer as it was after the redone edit.
+//   - bool: True if there was anything to redo.
+func (h *History) Redo() (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := len(h.redo)
+	if n == 0 {
+		return Snapshot{}, false
+	}
+	step := h.redo[n-1]
+	h.redo = h.redo[:n-1]
+	h.undo = append(h.undo, step)
+	h.sealed = true
+	return step.after, true
+}
+
+// CanUndo reports whether there is a step to undo.
+//
+// Returns:
+//   - bool: True if Undo would change the buffer.
+func (h *History) CanUndo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.undo) > 0
+}
+
+// CanRedo reports whether there is an undone step to redo.
+//
+// Returns:
+//   - bool: True if Redo would change the buffer.
+func (h *History) CanRedo() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.redo) > 0
+}
+
+// Clear drops all undo and redo steps.
+func (h *History) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.undo, h.redo = nil, nil
+	h.sealed = false
+}
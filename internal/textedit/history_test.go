@@ -0,0 +1,149 @@
+package textedit
+
+import "testing"
+
+// typed records an edit of kind turning the value before into after, with the caret at the end of each.
+func typed(h *History, kind EditKind, before, after string) {
+	h.Record(kind, snap(before), snap(after))
+}
+
+// snap returns a snapshot of value with the caret at its end.
+func snap(value string) Snapshot {
+	n := int32(len([]rune(value)))
+	return Snapshot{Value: value, Anchor: n, Caret: n}
+}
+
+func TestHistoryUndoRedo(t *testing.T) {
+	tests := []struct {
+		name string
+		// edits is the sequence of values the buffer goes through, each step recorded with its kind
+		edits    []string
+		kinds    []EditKind
+		wantUndo []string
+	}{
+		{name: "typing merges", edits: []string{"", "a", "ab", "abc"}, kinds: []EditKind{EditTyping, EditTyping, EditTyping}, wantUndo: []string{""}},
+		{name: "deletes stay apart", edits: []string{"abc", "ab", "a"}, kinds: []EditKind{EditDelete, EditDelete}, wantUndo: []string{"ab", "abc"}},
+		{name: "delete seals typing", edits: []string{"", "a", "ab", "a", "ax"}, kinds: []EditKind{EditTyping, EditTyping, EditDelete, EditTyping}, wantUndo: []string{"a", "ab", ""}},
+		{name: "paste stays apart", edits: []string{"", "a", "a pasted", "a pastedb"}, kinds: []EditKind{EditTyping, EditPaste, EditTyping}, wantUndo: []string{"a pasted", "a", ""}},
+		{name: "cut stays apart", edits: []string{"", "ab", ""}, kinds: []EditKind{EditTyping, EditCut}, wantUndo: []string{"ab", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistory(DefaultHistoryDepth)
+			for i, kind := range tt.kinds {
+				typed(h, kind, tt.edits[i], tt.edits[i+1])
+			}
+			for _, want := range tt.wantUndo {
+				s, ok := h.Undo()
+				if !ok || s.Value != want {
+					t.Fatalf("Undo() = %q, %v, want %q, true", s.Value, ok, want)
+				}
+			}
+			if h.CanUndo() {
+				t.Errorf("CanUndo() = true after undoing every step")
+			}
+			if _, ok := h.Undo(); ok {
+				t.Errorf("Undo() on an empty history reported a step")
+			}
+			// redoing walks the same steps forwards again, ending at the last value
+			var last Snapshot
+			for range tt.wantUndo {
+				s, ok := h.Redo()
+				if !ok {
+					t.Fatalf("Redo() reported no step")
+				}
+				last = s
+			}
+			if want := tt.edits[len(tt.edits)-1]; last.Value != want {
+				t.Errorf("value after redoing everything = %q, want %q", last.Value, want)
+			}
+			if h.CanRedo() {
+				t.Errorf("CanRedo() = true after redoing every step")
+			}
+		})
+	}
+}
+
+func TestHistoryTypingAfterCaretMove(t *testing.T) {
+	h := NewHistory(DefaultHistoryDepth)
+	typed(h, EditTyping, "", "ab")
+	// the caret moved to the start before typing again, which begins a new step
+	h.Record(EditTyping, Snapshot{Value: "ab"}, Snapshot{Value: "xab", Anchor: 1, Caret: 1})
+	if s, _ := h.Undo(); s.Value != "ab" || s.Caret != 0 {
+		t.Errorf("Undo() = %q with the caret at %d, want %q with the caret at 0", s.Value, s.Caret, "ab")
+	}
+}
+
+func TestHistoryTypingAfterUndo(t *testing.T) {
+	h := NewHistory(DefaultHistoryDepth)
+	typed(h, EditTyping, "", "a")
+	typed(h, EditTyping, "a", "ab")
+	h.Undo()
+	h.Redo()
+	// the redone step is sealed, typing on does not merge into it
+	typed(h, EditTyping, "ab", "abc")
+	if s, _ := h.Undo(); s.Value != "ab" {
+		t.Errorf("Undo() = %q, want %q", s.Value, "ab")
+	}
+}
+
+func TestHistoryRecordClearsRedo(t *testing.T) {
+	h := NewHistory(DefaultHistoryDepth)
+	typed(h, EditTyping, "", "a")
+	h.Undo()
+	typed(h, EditPaste, "", "b")
+	if h.CanRedo() {
+		t.Errorf("CanRedo() = true after a new edit")
+	}
+}
+
+func TestHistoryIgnoresUnchangedValue(t *testing.T) {
+	h := NewHistory(DefaultHistoryDepth)
+	h.Record(EditTyping, snap("a"), Snapshot{Value: "a"})
+	if h.CanUndo() {
+		t.Errorf("CanUndo() = true after an edit which left the value unchanged")
+	}
+}
+
+func TestHistoryDepth(t *testing.T) {
+	h := NewHistory(2)
+	typed(h, EditDelete, "abc", "ab")
+	typed(h, EditDelete, "ab", "a")
+	typed(h, EditDelete, "a", "")
+	for _, want := range []string{"a", "ab"} {
+		if s, ok := h.Undo(); !ok || s.Value != want {
+			t.Fatalf("Undo() = %q, %v, want %q, true", s.Value, ok, want)
+		}
+	}
+	if h.CanUndo() {
+		t.Errorf("CanUndo() = true past the depth of the history")
+	}
+
+	disabled := NewHistory(0)
+	typed(disabled, EditTyping, "", "a")
+	if disabled.CanUndo() {
+		t.Errorf("CanUndo() = true with the history disabled")
+	}
+}
+
+func TestHistoryClear(t *testing.T) {
+	h := NewHistory(DefaultHistoryDepth)
+	typed(h, EditTyping, "", "a")
+	typed(h, EditDelete, "a", "")
+	h.Undo()
+	h.Clear()
+	if h.CanUndo() || h.CanRedo() {
+		t.Errorf("CanUndo() = %v, CanRedo() = %v after Clear, want false, false", h.CanUndo(), h.CanRedo())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	b := New("hello", 0)
+	b.Select(1, 3)
+	s := b.Snapshot()
+	b.Insert("xyz")
+	b.Restore(s)
+	if b.Value() != "hello" || b.Anchor() != 1 || b.Caret() != 3 {
+		t.Errorf("restored %q with selection %d..%d, want %q with 1..3", b.Value(), b.Anchor(), b.Caret(), "hello")
+	}
+}
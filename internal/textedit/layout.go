@@ -0,0 +1,164 @@
+package textedit
+
+// Line is one visual line of a Layout, the characters from Start up to End.
+// End stops before the line break, or at the first character of the next line when the line was wrapped.
+type Line struct {
+	Start   int32
+	End     int32
+	Wrapped bool
+}
+
+// Layout splits a text into visual lines at its line breaks and, when wrapping, wherever a line gets too wide.
+// Widths come from a measure function so the layout matches whatever font the text is drawn with.
+type Layout struct {
+	Lines []Line
+	// PageLines is the number of lines Page Up and Page Down move by, 1 when unset.
+	PageLines int
+	runes     []rune
+	measure   func(text string) int32
+}
+
+// NewLayout lays out text into lines.
+// Lines wrap after the last separator that still fits into width, a word wider than width is broken between characters.
+//
+// Parameters:
+//   - text: The text to lay out.
+//   - width: The width lines wrap at, 0 or less to only break lines at line breaks.
+//   - measure: Returns the width of a piece of text in pixels.
+//
+// Returns:
+//   - *Layout: The laid out text, always holding at least one line.
+func NewLayout(text string, width int32, measure func(text string) int32) *Layout {
+	l := &Layout{runes: []rune(text), measure: measure}
+	n := int32(len(l.runes))
+	start := int32(0)
+	for i := int32(0); i <= n; i++ {
+		if i < n && l.runes[i] != '\n' {
+			continue
+		}
+		l.wrap(start, i, width)
+		start = i + 1
+	}
+	return l
+}
+
+// wrap adds the lines of the text between start and end, which holds no line break.
+func (l *Layout) wrap(start, end, width int32) {
+	for width > 0 && start < end {
+		fit, lastBreak := start, int32(-1)
+		for fit < end && l.measure(string(l.runes[start:fit+1])) <= width {
+			fit++
+			if isSeparator(l.runes[fit-1]) {
+				lastBreak = fit
+			}
+		}
+		if fit == end {
+			break
+		}
+		switch {
+		case lastBreak > start:
+			fit = lastBreak
+		case fit == start:
+			fit = start + 1
+		}
+		l.Lines = append(l.Lines, Line{Start: start, End: fit, Wrapped: true})
+		start = fit
+	}
+	l.Lines = append(l.Lines, Line{Start: start, End: end})
+}
+
+// LineOf returns the index of the line holding pos.
+// A position where a line was wrapped belongs to the line after the wrap.
+//
+// Parameters:
+//   - pos: The position in characters.
+//
+// Returns:
+//   - int: The index of the line.
+func (l *Layout) LineOf(pos int32) int {
+	line := 0
+	for i, ln := range l.Lines {
+		if ln.Start > pos {
+			break
+		}
+		line = i
+	}
+	return line
+}
+
+// X returns the horizontal offset of pos from the start of its line.
+//
+// Parameters:
+//   - pos: The position in characters.
+//
+// Returns:
+//   - int32: The offset in pixels.
+func (l *Layout) X(pos int32) int32 {
+	return l.LineX(l.LineOf(pos), pos)
+}
+
+// LineX returns the horizontal offset of pos from the start of a given line, clamping pos to the line.
+// Unlike X it measures the end of a wrapped line on that line rather than on the next one.
+//
+// Parameters:
+//   - line: The index of the line.
+//   - pos: The position in characters.
+//
+// Returns:
+//   - int32: The offset in pixels.
+func (l *Layout) LineX(line int, pos int32) int32 {
+	ln := l.Lines[line]
+	pos = min(max(pos, ln.Start), ln.End)
+	return l.measure(string(l.runes[ln.Start:pos]))
+}
+
+// PosAt returns the position on a line closest to a horizontal offset, as clicking there or moving the caret up or down would.
+// Lines outside of the layout are clamped to the first and last line.
+//
+// Parameters:
+//   - line: The index of the line.
+//   - x: The offset from the start of the line in pixels.
+//
+// Returns:
+//   - int32: The position in characters.
+func (l *Layout) PosAt(line int, x int32) int32 {
+	ln := l.Lines[min(max(line, 0), len(l.Lines)-1)]
+	last := ln.End
+	if ln.Wrapped && last > ln.Start {
+		// the end of a wrapped line is the start of the next one
+		last--
+	}
+	prev := int32(0)
+	for pos := ln.Start; pos < last; pos++ {
+		w := l.measure(string(l.runes[ln.Start : pos+1]))
+		if x < (prev+w)/2 {
+			return pos
+		}
+		prev = w
+	}
+	return last
+}
+
+// Width returns the width of the widest line.
+//
+// Returns:
+//   - int32: The width in pixels.
+func (l *Layout) Width() int32 {
+	var width int32
+	for _, ln := range l.Lines {
+		width = max(width, l.measure(string(l.runes[ln.Start:ln.End])))
+	}
+	return width
+}
+
+// Text returns the characters of a line.
+//
+// Parameters:
+//   - line: The index of the line.
+//
+// Returns:
+//   - string: The text of the line without its line break.
+func (l *Layout) Text(line int) string {
+	ln := l.Lines[line]
+	return string(l.runes[ln.Start:ln.End])
+}
@@ -0,0 +1,73 @@
+package textedit
+
+// WordAt returns the bounds of the word at pos in text, which double-clicking selects.
+// A position directly after a word or on the separators following it belongs to that word.
+// When no word precedes pos the empty range at the first separator is returned.
+//
+// Parameters:
+//   - text: The text to search.
+//   - pos: The position in characters.
+//
+// Returns:
+//   - int32: The start of the word.
+//   - int32: The end of the word.
+func WordAt(text string, pos int32) (int32, int32) {
+	runes := []rune(text)
+	if pos < 0 || pos > int32(len(runes)) {
+		return 0, 0
+	}
+	return wordAt(runes, pos)
+}
+
+func wordAt(runes []rune, pos int32) (int32, int32) {
+	n := int32(len(runes))
+	if n == 0 {
+		return 0, 0
+	}
+	if pos == n {
+		pos--
+	}
+	for pos > 0 && isSeparator(runes[pos]) {
+		pos--
+	}
+	if isSeparator(runes[pos]) {
+		return pos, pos
+	}
+	start := pos
+	for start > 0 && !isSeparator(runes[start-1]) {
+		start--
+	}
+	end := pos + 1
+	for end < n && !isSeparator(runes[end]) {
+		end++
+	}
+	return start, end
+}
+
+// wordLeft returns the start of the word before pos, skipping any separators directly before it.
+func wordLeft(runes []rune, pos int32) int32 {
+	for pos > 0 && isSeparator(runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !isSeparator(runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordRight returns the start of the word after pos, skipping the rest of the word at pos and the separators after it.
+func wordRight(runes []rune, pos int32) int32 {
+	n := int32(len(runes))
+	for pos < n && !isSeparator(runes[pos]) {
+		pos++
+	}
+	for pos < n && isSeparator(runes[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// isSeparator reports whether ch separates words.
+func isSeparator(ch rune) bool {
+	return ch == ' ' || ch == '/' || ch == '\\' || ch == '.'
+}
@@ -0,0 +1,73 @@
+package textedit
+
+import "testing"
+
+func TestWordAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		pos       int32
+		wantStart int32
+		wantEnd   int32
+	}{
+		{name: "start of word", text: "hello world", pos: 0, wantStart: 0, wantEnd: 5},
+		{name: "inside word", text: "hello world", pos: 2, wantStart: 0, wantEnd: 5},
+		{name: "directly after word", text: "hello world", pos: 5, wantStart: 0, wantEnd: 5},
+		{name: "second word", text: "hello world", pos: 6, wantStart: 6, wantEnd: 11},
+		{name: "end of text", text: "hello world", pos: 11, wantStart: 6, wantEnd: 11},
+		{name: "run of spaces", text: "ab   cd", pos: 4, wantStart: 0, wantEnd: 2},
+		{name: "dot separates", text: "foo.bar", pos: 5, wantStart: 4, wantEnd: 7},
+		{name: "on the dot", text: "foo.bar", pos: 3, wantStart: 0, wantEnd: 3},
+		{name: "slashes separate", text: `a/b\c`, pos: 2, wantStart: 2, wantEnd: 3},
+		{name: "other punctuation joins the word", text: "a, b", pos: 0, wantStart: 0, wantEnd: 2},
+		{name: "leading separators", text: "  ab", pos: 1, wantStart: 0, wantEnd: 0},
+		{name: "multi-byte runes", text: "héllo wörld", pos: 7, wantStart: 6, wantEnd: 11},
+		{name: "wide runes", text: "日本 語", pos: 1, wantStart: 0, wantEnd: 2},
+		{name: "empty text", text: "", pos: 0, wantStart: 0, wantEnd: 0},
+		{name: "before the text", text: "hello", pos: -1, wantStart: 0, wantEnd: 0},
+		{name: "past the text", text: "hello", pos: 6, wantStart: 0, wantEnd: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := WordAt(tt.text, tt.pos)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("WordAt(%q, %d) = %d, %d, want %d, %d", tt.text, tt.pos, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestWordLeftRight(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		pos       int32
+		wantLeft  int32
+		wantRight int32
+	}{
+		{name: "start of text", text: "hello world", pos: 0, wantLeft: 0, wantRight: 6},
+		{name: "end of text", text: "hello world", pos: 11, wantLeft: 6, wantRight: 11},
+		{name: "inside word", text: "hello world", pos: 3, wantLeft: 0, wantRight: 6},
+		{name: "start of second word", text: "hello world", pos: 6, wantLeft: 0, wantRight: 11},
+		{name: "run of spaces", text: "hello   world", pos: 6, wantLeft: 0, wantRight: 8},
+		{name: "path", text: "usr/local/bin", pos: 6, wantLeft: 4, wantRight: 10},
+		{name: "file extension", text: "main.go", pos: 7, wantLeft: 5, wantRight: 7},
+		{name: "trailing separators", text: "end.  ", pos: 6, wantLeft: 0, wantRight: 6},
+		{name: "leading separators", text: "  ab", pos: 0, wantLeft: 0, wantRight: 2},
+		{name: "other punctuation", text: "one, two", pos: 0, wantLeft: 0, wantRight: 5},
+		{name: "multi-byte runes", text: "grüße welt", pos: 10, wantLeft: 6, wantRight: 10},
+		{name: "wide runes", text: "日本 語", pos: 0, wantLeft: 0, wantRight: 3},
+		{name: "empty text", text: "", pos: 0, wantLeft: 0, wantRight: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runes := []rune(tt.text)
+			if got := wordLeft(runes, tt.pos); got != tt.wantLeft {
+				t.Errorf("wordLeft(%q, %d) = %d, want %d", tt.text, tt.pos, got, tt.wantLeft)
+			}
+			if got := wordRight(runes, tt.pos); got != tt.wantRight {
+				t.Errorf("wordRight(%q, %d) = %d, want %d", tt.text, tt.pos, got, tt.wantRight)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"unsafe"
 
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
 
 	"golang.org/x/sys/windows"
 )
@@ -17,22 +19,23 @@ var (
 	// Callback Maps \\
 	drawCallbackMap     = make(map[uintptr]func(hdc uintptr))
 	drawCallbackMu      sync.Mutex
+	resizeCallbackMap   = make(map[uintptr]func(width, height int32))
+	resizeCallbackMu    sync.Mutex
 	resizingState       = make(map[uintptr]bool)
 	resizingStateMu     sync.Mutex
 	wdwColorMap         = make(map[uintptr]common.Color)
 	wdwColorMapMu       sync.Mutex
 	fontCache           = make(map[string]windows.Handle)
 	fontCacheMu         sync.Mutex
-	customCursorDraw    = false
-	customCursorDrawMu  sync.Mutex
 	textInputStateMap   = make(map[uintptr]*common.TextInputState)
 	textInputStateMapMu sync.Mutex
 	selectorStateMap    = make(map[uintptr]*common.SelectorState)
 	selectorStateMapMu  sync.Mutex
 	buttonBoundsMap     = make(map[uintptr][4]int32)
 	buttonBoundsMapMu   sync.Mutex
-	buttonCbMap         = make(map[uintptr]map[string]func(any))
-	buttonCbMapMu       sync.Mutex
+	buttonEventsMap     = make(map[uintptr]*event.Dispatcher)
+	buttonPressedMap    = make(map[uintptr]bool)
+	buttonEventsMapMu   sync.Mutex
 
 	transparentBrush windows.Handle
 
@@ -40,6 +43,7 @@ var (
 	user32   = windows.NewLazySystemDLL("user32.dll")
 	gdi32    = windows.NewLazySystemDLL("gdi32.dll")
 	kernal32 = windows.NewLazySystemDLL("kernel32.dll")
+	imm32    = windows.NewLazySystemDLL("imm32.dll")
 
 	// User32 functions \\
 	procRegisterClassExW    = user32.NewProc("RegisterClassExW")
@@ -52,6 +56,7 @@ var (
 	procDispatchMessageW    = user32.NewProc("DispatchMessageW")
 	procDestroyWindow       = user32.NewProc("DestroyWindow")
 	procSetCursor           = user32.NewProc("SetCursor")
+	procCreateIconIndirect  = user32.NewProc("CreateIconIndirect")
 	procLoadCursorW         = user32.NewProc("LoadCursorW")
 	procBeginPaint          = user32.NewProc("BeginPaint")
 	procEndPaint            = user32.NewProc("EndPaint")
@@ -77,11 +82,14 @@ var (
 	procSetForegroundWindow = user32.NewProc("SetForegroundWindow")
 	procSetCapture          = user32.NewProc("SetCapture")
 	procReleaseCapture      = user32.NewProc("ReleaseCapture")
+	procTrackMouseEvent     = user32.NewProc("TrackMouseEvent")
 	procPostMessageW        = user32.NewProc("PostMessageW")
 	procGetWindowLongPtr    = user32.NewProc("GetWindowLongPtrW")
 	procSetWindowLongPtr    = user32.NewProc("SetWindowLongPtrW")
 	procGetAncestor         = user32.NewProc("GetAncestor")
 	procScreenToClient      = user32.NewProc("ScreenToClient")
+	procClientToScreen      = user32.NewProc("ClientToScreen")
+	procGetClientRect       = user32.NewProc("GetClientRect")
 
 	// GDI32 functions \\
 	procCreateSolidBrush       = gdi32.NewProc("CreateSolidBrush")
@@ -98,14 +106,29 @@ var (
 	procGetTextExtentPoint32W  = gdi32.NewProc("GetTextExtentPoint32W")
 	procRoundRect              = gdi32.NewProc("RoundRect")
 	procCreatePen              = gdi32.NewProc("CreatePen")
+	procSaveDC                 = gdi32.NewProc("SaveDC")
+	procRestoreDC              = gdi32.NewProc("RestoreDC")
+	procIntersectClipRect      = gdi32.NewProc("IntersectClipRect")
 	procCreateDIBSection       = gdi32.NewProc("CreateDIBSection")
+	procCreateBitmap           = gdi32.NewProc("CreateBitmap")
 
 	// Kernal32 Functions \\
 	procGlobalAlloc     = kernal32.NewProc("GlobalAlloc")
 	procGlobalLock      = kernal32.NewProc("GlobalLock")
 	procGlobalUnlock    = kernal32.NewProc("GlobalUnlock")
+	procGlobalSize      = kernal32.NewProc("GlobalSize")
+	procGlobalFree      = kernal32.NewProc("GlobalFree")
+	procRtlMoveMemory   = kernal32.NewProc("RtlMoveMemory")
 	procGetModuleHandle = kernal32.NewProc("GetModuleHandleW")
 
+	// Imm32 Functions \\
+	procImmGetContext            = imm32.NewProc("ImmGetContext")
+	procImmReleaseContext        = imm32.NewProc("ImmReleaseContext")
+	procImmGetCompositionStringW = imm32.NewProc("ImmGetCompositionStringW")
+	procImmSetCompositionWindow  = imm32.NewProc("ImmSetCompositionWindow")
+	procImmSetCandidateWindow    = imm32.NewProc("ImmSetCandidateWindow")
+	procImmNotifyIME             = imm32.NewProc("ImmNotifyIME")
+
 	// Caret Ticker \\
 	CT   = common.NewCaretTicker()
 	HLTR = common.NewHighlighter()
@@ -142,6 +165,8 @@ const (
 	WS_EX_LAYERED       = 0x00080000
 	WS_EX_TRANSPARENT   = 0x00000020
 	WS_EX_TOPMOST       = 0x00000008
+	WS_EX_TOOLWINDOW    = 0x00000080
+	WS_EX_NOACTIVATE    = 0x08000000
 	WS_POPUP            = 0x80000000
 
 	// Edit Control Styles
@@ -151,7 +176,10 @@ const (
 	ES_AUTOHSCROLL = 0x0080
 
 	// Window Actions
-	WM_CLOSE         = 0x0010
+	WM_CLOSE = 0x0010
+	WM_APP   = 0x8000
+	// WM_GOOEY_INVOKE wakes a window's event loop to run the functions queued with PostInvoke.
+	WM_GOOEY_INVOKE  = WM_APP + 1
 	WM_DESTROY       = 0x0002
 	WM_SETCURSOR     = 0x0020
 	WM_HTCLIENT      = 1
@@ -160,9 +188,21 @@ const (
 	WM_LBUTTONDOWN   = 0x0201
 	WM_LBUTTONUP     = 0x0202
 	WM_LBUTTONDBCLK  = 0x0203
+	WM_RBUTTONDOWN   = 0x0204
+	WM_RBUTTONUP     = 0x0205
+	WM_RBUTTONDBCLK  = 0x0206
+	WM_MBUTTONDOWN   = 0x0207
+	WM_MBUTTONUP     = 0x0208
+	WM_MBUTTONDBCLK  = 0x0209
+	WM_XBUTTONDOWN   = 0x020B
+	WM_XBUTTONUP     = 0x020C
+	WM_XBUTTONDBCLK  = 0x020D
+	WM_MOUSELEAVE    = 0x02A3
 	WM_ENTERSIZEMOVE = 0x0231
 	WM_EXITSIZEMOVE  = 0x0232
 	WM_MOUSEMOVE     = 0x0200
+	WM_MOUSEWHEEL    = 0x020A
+	WM_MOUSEHWHEEL   = 0x020E
 	WM_ERASEBKGND    = 0x0014
 	WM_GETTEXT       = 0x000D
 	WM_GETTEXTLENGTH = 0x000E
@@ -172,17 +212,54 @@ const (
 	WM_COMMAND       = 0x0111
 	WM_CHAR          = 0x0102
 	WM_KEYDOWN       = 0x0100
+	WM_KEYUP         = 0x0101
+	WM_SYSKEYDOWN    = 0x0104
+	WM_SYSKEYUP      = 0x0105
 	WM_MOUSEACTIVATE = 0x0021
 	WM_NCHITTEST     = 0x0084
 	WM_NCCREATE      = 0x0081
 
+	// Input Method Messages
+	WM_IME_STARTCOMPOSITION = 0x010D
+	WM_IME_ENDCOMPOSITION   = 0x010E
+	WM_IME_COMPOSITION      = 0x010F
+
+	// Composition String Parts
+	GCS_COMPSTR   = 0x0008
+	GCS_CURSORPOS = 0x0080
+	GCS_RESULTSTR = 0x0800
+
+	// Composition and Candidate Window Styles
+	CFS_POINT   = 0x0002
+	CFS_EXCLUDE = 0x0080
+
+	// ImmNotifyIME Actions
+	NI_COMPOSITIONSTR = 0x0015
+	CPS_CANCEL        = 0x0004
+
+	// WM_MOUSEACTIVATE Results
+	MA_NOACTIVATE = 3
+
+	// WM_SIZE Request Types
+	SIZE_RESTORED  = 0
+	SIZE_MINIMIZED = 1
+	SIZE_MAXIMIZED = 2
+
 	// Notification Codes
 	EN_CHANGE = 0x0300
 
 	// Cursor Styles
-	IDC_ARROW = 32512
-	IDC_BEAM  = 32513
-	IDC_CROSS = 32515
+	IDC_ARROW    = 32512
+	IDC_BEAM     = 32513
+	IDC_WAIT     = 32514
+	IDC_CROSS    = 32515
+	IDC_SIZENWSE = 32642
+	IDC_SIZENESW = 32643
+	IDC_SIZEWE   = 32644
+	IDC_SIZENS   = 32645
+	IDC_SIZEALL  = 32646
+	IDC_NO       = 32648
+	IDC_HAND     = 32649
 
 	// System Color Indexes
 	COLOR_WINDOW        = 5
@@ -237,9 +314,57 @@ const (
 	VK_BACK    = 0x08
 	VK_DELETE  = 0x2E
 	VK_CONTROL = 0x11
+	VK_SHIFT   = 0x10
+	VK_PRIOR   = 0x21
+	VK_NEXT    = 0x22
+	VK_END     = 0x23
+	VK_HOME    = 0x24
+	VK_LEFT    = 0x25
+	VK_UP      = 0x26
+	VK_RIGHT   = 0x27
+	VK_DOWN    = 0x28
+	VK_MENU    = 0x12
+	VK_LWIN    = 0x5B
+	VK_RWIN    = 0x5C
+	VK_TAB     = 0x09
+	VK_RETURN  = 0x0D
+	VK_CAPITAL = 0x14
+	VK_ESCAPE  = 0x1B
+	VK_SPACE   = 0x20
+	VK_INSERT  = 0x2D
+	VK_F1      = 0x70
+	VK_F12     = 0x7B
+	// VK_PROCESSKEY replaces the key of a key press an input method is composing with
+	VK_PROCESSKEY = 0xE5
+
+	// Punctuation Virtual Keys
+	VK_OEM_1      = 0xBA
+	VK_OEM_PLUS   = 0xBB
+	VK_OEM_COMMA  = 0xBC
+	VK_OEM_MINUS  = 0xBD
+	VK_OEM_PERIOD = 0xBE
+	VK_OEM_2      = 0xBF
+	VK_OEM_3      = 0xC0
+	VK_OEM_4      = 0xDB
+	VK_OEM_5      = 0xDC
+	VK_OEM_6      = 0xDD
+	VK_OEM_7      = 0xDE
 
 	// Mouse Keys
-	MK_LBUTTON = 0x0001
+	MK_LBUTTON  = 0x0001
+	MK_RBUTTON  = 0x0002
+	MK_SHIFT    = 0x0004
+	MK_CONTROL  = 0x0008
+	MK_MBUTTON  = 0x0010
+	MK_XBUTTON1 = 0x0020
+	MK_XBUTTON2 = 0x0040
+
+	// Extra Mouse Buttons
+	XBUTTON1 = 0x0001
+	XBUTTON2 = 0x0002
+
+	// TrackMouseEvent Flags
+	TME_LEAVE = 0x00000002
 
 	// Border Styles
 	BD_EDGE_RAISED       = 0x0004
@@ -251,6 +376,7 @@ const (
 	BF_RECT = 0x0F
 
 	// Clipboard Functions
+	CF_DIB         = 8
 	CF_UNICODETEXT = 13
 
 	// Global Memory Flags
@@ -353,11 +479,14 @@ type Paint struct {
 func WindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr {
 	switch msg {
 	case WM_SETCURSOR:
-		if !isCustomCursorDraw() && (uint16(lParam&0xFFFF) == WM_HTCLIENT) {
-			SetCursor(LoadArrowCursor())
+		if uint16(lParam&0xFFFF) == WM_HTCLIENT {
+			SetCursor(windowCursor(uintptr(hwnd)))
 			return 1
 		}
 	case WM_SIZE:
+		if wParam != SIZE_MINIMIZED {
+			handleResize(uintptr(hwnd), int32(LOWORD(lParam)), int32(HIWORD(lParam)))
+		}
 		_ = InvalidateRect(hwnd, nil, false)
 	case WM_ENTERSIZEMOVE:
 		SetResizingState(uintptr(hwnd), true)
@@ -366,32 +495,58 @@ func WindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr
 	case WM_ERASEBKGND:
 		return 1
 	case WM_CHAR:
+		if suppressChar {
+			suppressChar = false
+			return 0
+		}
+		if ch := typedChar(uint16(wParam)); ch != 0 && HLTR.TextInputID != 0 {
+			handleTextInputText(HLTR.TextInputID, string(ch))
+		}
+		return 0
+	case WM_IME_STARTCOMPOSITION:
 		if HLTR.TextInputID != 0 {
-			handleTextInputChar(HLTR.TextInputID, rune(wParam))
+			// the composition is drawn by the text input, the input method's own window stays hidden
+			placeInputMethod(hwnd)
+			return 0
 		}
+	case WM_IME_COMPOSITION:
+		if HLTR.TextInputID != 0 {
+			handleComposition(hwnd, HLTR.TextInputID, lParam)
+			return 0
+		}
+	case WM_IME_ENDCOMPOSITION:
+		if HLTR.TextInputID != 0 {
+			UpdateTextInputState(HLTR.TextInputID, common.UpdateTIPreedit("", 0))
+			return 0
+		}
+	case WM_SYSKEYDOWN, WM_SYSKEYUP:
+		// alt combinations and F10 arrive as system keys, unhandled ones keep their default behavior such as Alt+F4
+		kind := common.KeyInputDown
+		if msg == WM_SYSKEYUP {
+			kind = common.KeyInputUp
+		}
+		if handleKey(hwnd, kind, wParam, lParam) {
+			return 0
+		}
+	case WM_KEYUP:
+		handleKey(hwnd, common.KeyInputUp, wParam, lParam)
 		return 0
 	case WM_KEYDOWN:
-		if HLTR.TextInputID != 0 {
-			ctrlDown := (uint16(GetKeyState(VK_CONTROL)) & 0x8000) != 0
-			switch wParam {
-			case VK_BACK:
-				handleTextInputBackspace(HLTR.TextInputID)
-			case VK_DELETE:
-				handleTextInputDelete(HLTR.TextInputID)
-			case 'C', 'c':
-				if ctrlDown {
-					handleTextInputCopy(HLTR.TextInputID)
-				}
-			case 'X', 'x':
-				if ctrlDown {
-					handleTextInputCopy(HLTR.TextInputID)
-					handleTextInputBackspace(HLTR.TextInputID)
-				}
-			case 'V', 'v':
-				if ctrlDown {
-					handleTextInputPaste(HLTR.TextInputID)
-				}
-			}
+		if wParam == VK_PROCESSKEY {
+			// the input method takes the key, its composition arrives as WM_IME_COMPOSITION
+			break
+		}
+		suppressChar = false
+		if handleKey(hwnd, common.KeyInputDown, wParam, lParam) {
+			return 0
+		}
+		if HLTR.TextInputID == 0 {
+			handleScrollViewKey(wParam)
+			return 0
+		}
+		if handleTextInputCommand(HLTR.TextInputID, textedit.CommandForKey(vkKey(wParam), winModifiers(0))) {
+			// the command keys would otherwise be followed by a WM_CHAR with a control character
+			suppressChar = true
 		}
 		return 0
 	case WM_LBUTTONDOWN:
@@ -399,17 +554,48 @@ func WindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr
 		y := int32((lParam >> 16) & 0xFFFF)
 		HLTR.SuppressSelection = false
 		tiId, tiFound := FindTextInputAt(x, y)
-		handleTextInputClickCallbacks(tiId, tiFound, hwnd, x)
+		handleTextInputClickCallbacks(tiId, tiFound, hwnd, x, y)
 		btnId, btnFound := FindButtonAt(x, y)
-		handleButtonCallbacks(btnId, btnFound, true)
+		handleButtonEvents(btnId, btnFound, true)
+		if !tiFound && !btnFound {
+			activateScrollViewAt(x, y)
+		}
+		handleMouseButton(hwnd, common.MouseInputDown, wParam, lParam, event.MouseButtonLeft)
 		return 0
 	case WM_LBUTTONDBCLK:
 		x := int32(lParam & 0xFFFF)
 		y := int32((lParam >> 16) & 0xFFFF)
 		tiId, tiFound := FindTextInputAt(x, y)
-		handleTextInputClickCallbacks(tiId, tiFound, hwnd, x, true)
+		handleTextInputClickCallbacks(tiId, tiFound, hwnd, x, y, true)
 		btnId, btnFound := FindButtonAt(x, y)
-		handleButtonCallbacks(btnId, btnFound, true)
+		handleButtonEvents(btnId, btnFound, true)
+		handleMouseButton(hwnd, common.MouseInputDown, wParam, lParam, event.MouseButtonLeft)
+		return 0
+	case WM_RBUTTONDOWN, WM_RBUTTONDBCLK:
+		handleMouseButton(hwnd, common.MouseInputDown, wParam, lParam, event.MouseButtonRight)
+		return 0
+	case WM_MBUTTONDOWN, WM_MBUTTONDBCLK:
+		handleMouseButton(hwnd, common.MouseInputDown, wParam, lParam, event.MouseButtonMiddle)
+		return 0
+	case WM_XBUTTONDOWN, WM_XBUTTONDBCLK:
+		handleMouseButton(hwnd, common.MouseInputDown, wParam, lParam, xButton(wParam))
+		return 1
+	case WM_RBUTTONUP:
+		handleMouseButton(hwnd, common.MouseInputUp, wParam, lParam, event.MouseButtonRight)
+		return 0
+	case WM_MBUTTONUP:
+		handleMouseButton(hwnd, common.MouseInputUp, wParam, lParam, event.MouseButtonMiddle)
+		return 0
+	case WM_XBUTTONUP:
+		handleMouseButton(hwnd, common.MouseInputUp, wParam, lParam, xButton(wParam))
+		return 1
+	case WM_MOUSEWHEEL, WM_MOUSEHWHEEL:
+		pt := Point{X: int32(int16(LOWORD(lParam))), Y: int32(int16(HIWORD(lParam)))}
+		ScreenToClient(hwnd, &pt)
+		shiftDown := (uint16(GetKeyState(VK_SHIFT)) & 0x8000) != 0
+		dx, dy := wheelDelta(int32(int16(HIWORD(wParam))), msg == WM_MOUSEHWHEEL, shiftDown)
+		activateScrollViewAt(pt.X, pt.Y)
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputWheel, X: pt.X, Y: pt.Y, Modifiers: winModifiers(wParam), DeltaX: dx, DeltaY: dy})
 		return 0
 	case WM_MOUSEMOVE:
 		if HLTR.Active && HLTR.TextInputID != 0 && !HLTR.SuppressSelection {
@@ -417,23 +603,36 @@ func WindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr
 			y := int32((lParam >> 16) & 0xFFFF)
 			tiId, tiFound := FindTextInputAt(x, y)
 			if tiFound && tiId == HLTR.TextInputID {
-				updateTextInputSelection(tiId, hwnd, x, "update")
+				updateTextInputSelection(tiId, hwnd, x, y, "update")
 			}
 		}
+		trackMouseLeave(hwnd)
+		handleMouse(hwnd, common.MouseInput{
+			Kind:      common.MouseInputMove,
+			X:         int32(int16(LOWORD(lParam))),
+			Y:         int32(int16(HIWORD(lParam))),
+			Modifiers: winModifiers(wParam),
+		})
+		return 0
+	case WM_MOUSELEAVE:
+		untrackMouseLeave(hwnd)
+		x, y, _, _, _ := GetMouseState(hwnd)
+		handleMouse(hwnd, common.MouseInput{Kind: common.MouseInputLeave, X: x, Y: y, Modifiers: winModifiers(0)})
 		return 0
 	case WM_LBUTTONUP:
 		x := int32(lParam & 0xFFFF)
 		y := int32((lParam >> 16) & 0xFFFF)
 		btnId, btnFound := FindButtonAt(x, y)
-		handleButtonCallbacks(btnId, btnFound, false)
+		handleButtonEvents(btnId, btnFound, false)
 		tiId, tiFound := FindTextInputAt(x, y)
 		if HLTR.Active && HLTR.TextInputID != 0 && !HLTR.SuppressSelection {
 			if tiFound && HLTR.TextInputID == tiId {
-				updateTextInputSelection(tiId, hwnd, x, "end")
+				updateTextInputSelection(tiId, hwnd, x, y, "end")
 				handleTextInputCaretCallbacks(tiId)
 			}
 			HLTR.Active = false
 		}
+		handleMouseButton(hwnd, common.MouseInputUp, wParam, lParam, event.MouseButtonLeft)
 		return 0
 	case WM_PAINT:
 		handlePaint(hwnd)
@@ -441,7 +640,11 @@ func WindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr
 	case WM_CLOSE:
 		_ = DestroyWindow(hwnd)
 		return 0
+	case WM_GOOEY_INVOKE:
+		runInvoked(uintptr(hwnd))
+		return 0
 	case WM_DESTROY:
+		closeInvokeQueue(uintptr(hwnd))
 		_, _, _ = procPostQuitMessage.Call(0)
 		return 0
 	}
@@ -481,6 +684,33 @@ func getDrawCallback(hwnd uintptr) func(hdc uintptr) {
 	return drawCallbackMap[hwnd]
 }
 
+// RegisterResizeCallback registers a callback function to be called when the client area of the window changes size.
+// It takes a window handle and a callback function as parameters.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with the new client width and height
+func RegisterResizeCallback(hwnd uintptr, cb func(width, height int32)) {
+	resizeCallbackMu.Lock()
+	defer resizeCallbackMu.Unlock()
+	resizeCallbackMap[hwnd] = cb
+}
+
+// handleResize invokes the resize callback registered for the window handle, if any.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - width: The new client width of the window
+//   - height: The new client height of the window
+func handleResize(hwnd uintptr, width, height int32) {
+	resizeCallbackMu.Lock()
+	cb := resizeCallbackMap[hwnd]
+	resizeCallbackMu.Unlock()
+	if cb != nil && width > 0 && height > 0 {
+		cb(width, height)
+	}
+}
+
 // SetResizingState sets the resizing state for a window handle.
 //
 // Parameters:
@@ -596,26 +826,43 @@ func CleanupFontCache() {
 	fontCache = make(map[string]windows.Handle)
 }
 
-// SetCustomCursorDraw enables or disables custom cursor drawing.
-// It is used to control whether the custom cursor drawing logic should be applied.
+// UnregisterComponent forgets everything the backend tracks for a component removed from its window, so the bounds
+// it was last drawn at are no longer hit-tested and a removed text input cannot keep the keyboard focus.
+// Adding the component to a window registers it again, so a component added back later works as before.
 //
 // Parameters:
-//   - enabled: A boolean value indicating whether custom cursor drawing should be enabled
-func SetCustomCursorDraw(enabled bool) {
-	customCursorDrawMu.Lock()
-	defer customCursorDrawMu.Unlock()
-	customCursorDraw = enabled
-}
+//   - componentID: The ID of the removed component
+func UnregisterComponent(componentID uintptr) {
+	buttonBoundsMapMu.Lock()
+	delete(buttonBoundsMap, componentID)
+	buttonBoundsMapMu.Unlock()
 
-// isCustomCursorDraw checks if custom cursor drawing is enabled.
-// It returns true if custom cursor drawing is enabled, false otherwise.
-//
-// Returns:
-//   - bool: true if custom cursor drawing is enabled, false otherwise
-func isCustomCursorDraw() bool {
-	customCursorDrawMu.Lock()
-	defer customCursorDrawMu.Unlock()
-	return customCursorDraw
+	buttonEventsMapMu.Lock()
+	delete(buttonEventsMap, componentID)
+	delete(buttonPressedMap, componentID)
+	buttonEventsMapMu.Unlock()
+
+	textInputStateMapMu.Lock()
+	delete(textInputStateMap, componentID)
+	textInputStateMapMu.Unlock()
+	if HLTR.TextInputID == componentID {
+		HLTR.TextInputID = 0
+		HLTR.Active = false
+		HLTR.SelectionStart = 0
+		HLTR.SelectionEnd = 0
+		CT.Stop()
+	}
+
+	selectorStateMapMu.Lock()
+	delete(selectorStateMap, componentID)
+	selectorStateMapMu.Unlock()
+
+	scrollViewStateMapMu.Lock()
+	delete(scrollViewStateMap, componentID)
+	scrollViewStateMapMu.Unlock()
+	if activeScrollViewID == componentID {
+		activeScrollViewID = 0
+	}
 }
 
 // RegisterButtonBounds registers the bounds of a button control.
@@ -670,30 +917,29 @@ func FindButtonAt(x, y int32) (componentID uintptr, found bool) {
 	return 0, false
 }
 
-// RegisterButtonCallback registers a callback function for button events.
-// It is used to handle button click events and other button-related actions.
+// RegisterButtonEvents registers the event dispatcher of a button.
+// The backend publishes PressedEvent and ClickEvent on it when the button is pressed and released with the mouse.
 //
 // Parameters:
 //   - componentID: The ID of the component associated with the button
-//   - cbMap: A map of callback functions for different button events
-func RegisterButtonCallback(componentID uintptr, cbMap map[string]func(any)) {
-	buttonCbMapMu.Lock()
-	defer buttonCbMapMu.Unlock()
-	buttonCbMap[componentID] = cbMap
+//   - events: The event dispatcher of the button
+func RegisterButtonEvents(componentID uintptr, events *event.Dispatcher) {
+	buttonEventsMapMu.Lock()
+	defer buttonEventsMapMu.Unlock()
+	buttonEventsMap[componentID] = events
 }
 
-// GetButtonCallback retrieves the callback function for a given component ID.
-// It is used to retrieve the callback function associated with a specific component ID.
+// GetButtonEvents retrieves the event dispatcher registered for a given component ID.
 //
 // Parameters:
 //   - componentID: The ID of the component
 //
 // Returns:
-//   - func(any): The callback function to be called when the button is clicked
-func GetButtonCallback(componentID uintptr) map[string]func(any) {
-	buttonCbMapMu.Lock()
-	defer buttonCbMapMu.Unlock()
-	return buttonCbMap[componentID]
+//   - *event.Dispatcher: The event dispatcher of the button, or nil if it is not registered
+func GetButtonEvents(componentID uintptr) *event.Dispatcher {
+	buttonEventsMapMu.Lock()
+	defer buttonEventsMapMu.Unlock()
+	return buttonEventsMap[componentID]
 }
 
 // RegisterTextInputState registers the state of a text input control.
@@ -709,6 +955,18 @@ func RegisterTextInputState(componentID uintptr, state *common.TextInputState) {
 	textInputStateMap[componentID] = state
 }
 
+// textInputIDs returns the IDs of all registered text input controls.
+// The IDs are copied so the caller can update the states without holding the map lock.
+func textInputIDs() []uintptr {
+	textInputStateMapMu.Lock()
+	defer textInputStateMapMu.Unlock()
+	ids := make([]uintptr, 0, len(textInputStateMap))
+	for id := range textInputStateMap {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // GetTextInputState retrieves the state of a text input control.
 // It is used to get the current state of the text input control, including its bounds and other properties.
 // This is useful for handling text input events and managing the state of the text input control.
@@ -733,14 +991,21 @@ func GetTextInputState(componentID uintptr) *common.TextInputState {
 //   - updates: A variadic number of update functions to modify the state
 func UpdateTextInputState(componentID uintptr, updates ...common.UpdateTextInputState) {
 	textInputStateMapMu.Lock()
-	defer textInputStateMapMu.Unlock()
 	state, ok := textInputStateMap[componentID]
 	if !ok {
+		textInputStateMapMu.Unlock()
 		return
 	}
 	for _, update := range updates {
 		update(state)
 	}
+	pending := state.TakeEvents()
+	textInputStateMapMu.Unlock()
+
+	// the events are published after the lock is released so listeners may query or update the state again
+	for _, publish := range pending {
+		publish()
+	}
 }
 
 // FindTextInputAt checks if a point (x, y) is within the bounds of any text input control.
@@ -758,6 +1023,9 @@ func FindTextInputAt(x, y int32) (componentID uintptr, found bool) {
 	textInputStateMapMu.Lock()
 	defer textInputStateMapMu.Unlock()
 	for id, state := range textInputStateMap {
+		if state.Clip != nil && !state.Clip.Contains(x, y) {
+			continue
+		}
 		if x >= state.Bounds.X && x < state.Bounds.X+state.Bounds.Width &&
 			y >= state.Bounds.Y && y < state.Bounds.Y+state.Bounds.Height {
 			return id, true
@@ -788,14 +1056,74 @@ func RegisterSelectorState(componentID uintptr, state *common.SelectorState) {
 //   - updates: A variadic number of update functions to modify the state
 func UpdateSelectorState(componentID uintptr, updates ...common.UpdateSelectorState) {
 	selectorStateMapMu.Lock()
-	defer selectorStateMapMu.Unlock()
 	state, ok := selectorStateMap[componentID]
 	if !ok {
+		selectorStateMapMu.Unlock()
+		return
+	}
+	for _, update := range updates {
+		update(state)
+	}
+	pending := state.TakeEvents()
+	selectorStateMapMu.Unlock()
+
+	// the events are published after the lock is released so listeners may query or update the state again
+	for _, publish := range pending {
+		publish()
+	}
+}
+
+// UpdateSelectorStateFrom applies updates made outside of the owner window's event loop, such as by the selector overlay's thread.
+// The state changes under the lock right away while the resulting events are published on the owner window's event loop,
+// so the selector's listeners never run concurrently with the rest of the UI.
+//
+// Parameters:
+//   - owner: The handle of the window the selector belongs to
+//   - componentID: The ID of the component associated with the selector control
+//   - updates: A variadic number of update functions to modify the state
+func UpdateSelectorStateFrom(owner, componentID uintptr, updates ...common.UpdateSelectorState) {
+	selectorStateMapMu.Lock()
+	state, ok := selectorStateMap[componentID]
+	if !ok {
+		selectorStateMapMu.Unlock()
 		return
 	}
 	for _, update := range updates {
 		update(state)
 	}
+	pending := state.TakeEvents()
+	selectorStateMapMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	publish := func() {
+		for _, p := range pending {
+			p()
+		}
+	}
+	if !PostInvoke(owner, publish) {
+		publish()
+	}
+}
+
+// SelectorStateSnapshot returns a copy of the state of a selector control taken under the lock.
+// Unlike GetSelectorState the copy is safe to read while another thread updates the state.
+//
+// Parameters:
+//   - componentID: The ID of the component associated with the selector control
+//
+// Returns:
+//   - common.SelectorState: A copy of the selector state
+//   - bool: True if a selector with the ID is registered, false otherwise
+func SelectorStateSnapshot(componentID uintptr) (common.SelectorState, bool) {
+	selectorStateMapMu.Lock()
+	defer selectorStateMapMu.Unlock()
+	state, ok := selectorStateMap[componentID]
+	if !ok {
+		return common.SelectorState{}, false
+	}
+	return state.Snapshot(), true
 }
 
 // GetSelectorState retrieves the state of a selector control.
@@ -3,28 +3,42 @@
 
 package wdws
 
-// handleButtonCallbacks handles the callbacks for button components.
-// It checks if the button is pressed and calls the appropriate callback function.
-// It takes an additional argument `doCb` to determine if the callback should be executed.
-// This is especially useful for detecting mouse up events.
+import "github.com/Carmen-Shannon/gooey/event"
+
+// handleButtonEvents publishes the pressed and click events of the buttons after a mouse button press or release.
+// The button under the pointer takes the new pressed state, every other button is released without a click.
+// A click is published when the button under the pointer is released, its listeners run on the window's event loop like every other event.
 //
 // Parameters:
-//   - id: The ID of the button component
-//   - found: A boolean indicating whether the button was found
-//   - doCb: A boolean indicating whether to execute the callback
-func handleButtonCallbacks(id uintptr, found, doCb bool) {
-	for cid, cbMap := range buttonCbMap {
-		if cid == id && found {
-			if cb, ok := cbMap["pressed"]; ok {
-				cb(doCb)
-			}
-			if cb, ok := cbMap["onClick"]; doCb && ok {
-				go cb(nil)
-			}
-		} else {
-			if cb, ok := cbMap["pressed"]; ok {
-				cb(false)
-			}
+//   - id: The ID of the button under the pointer
+//   - found: A boolean indicating whether a button is under the pointer
+//   - pressed: A boolean indicating whether the mouse button was pressed or released
+func handleButtonEvents(id uintptr, found, pressed bool) {
+	type change struct {
+		id      uintptr
+		events  *event.Dispatcher
+		pressed bool
+	}
+	var changes []change
+	var clicked *event.Dispatcher
+
+	buttonEventsMapMu.Lock()
+	for cid, events := range buttonEventsMap {
+		p := pressed && cid == id && found
+		if buttonPressedMap[cid] != p {
+			buttonPressedMap[cid] = p
+			changes = append(changes, change{id: cid, events: events, pressed: p})
+		}
+		if cid == id && found && !pressed {
+			clicked = events
 		}
 	}
+	buttonEventsMapMu.Unlock()
+
+	for _, c := range changes {
+		event.Publish(c.events, event.PressedEvent{Source: c.id, Pressed: c.pressed})
+	}
+	if clicked != nil {
+		event.Publish(clicked, event.ClickEvent{Source: id})
+	}
 }
@@ -0,0 +1,164 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procRegisterClipboardFormatW = user32.NewProc("RegisterClipboardFormatW")
+
+	// ErrClipboardEmpty is returned when the clipboard holds no data in the requested format.
+	ErrClipboardEmpty = errors.New("clipboard holds no data in the requested format")
+	// ErrClipboardBusy is returned when another application keeps the clipboard open.
+	ErrClipboardBusy = errors.New("clipboard is open in another application")
+	// ErrClipboardFailed is returned when the clipboard could not store data.
+	ErrClipboardFailed = errors.New("clipboard could not store the data")
+)
+
+// openClipboard opens the clipboard, retrying for a moment while another application holds it open.
+func openClipboard() error {
+	for range 10 {
+		if r, _, _ := procOpenClipboard.Call(0); r != 0 {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return ErrClipboardBusy
+}
+
+// RegisterClipboardFormat returns the number of a named clipboard format, such as "PNG", registering it if no
+// application did before.
+//
+// Parameters:
+//   - name: The name of the format
+//
+// Returns:
+//   - uint32: The number of the format, 0 if it could not be registered
+func RegisterClipboardFormat(name string) uint32 {
+	ptr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0
+	}
+	r, _, _ := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(ptr)))
+	return uint32(r)
+}
+
+// SetClipboardData replaces the contents of the clipboard with data in one or more formats.
+//
+// Parameters:
+//   - data: The data by clipboard format, such as CF_UNICODETEXT or CF_DIB
+//
+// Returns:
+//   - error: ErrClipboardBusy or ErrClipboardFailed if the data could not be stored
+func SetClipboardData(data map[uint32][]byte) error {
+	if err := openClipboard(); err != nil {
+		return err
+	}
+	defer func() {
+		_, _, _ = procCloseClipboard.Call()
+	}()
+
+	_, _, _ = procEmptyClipboard.Call()
+	for format, value := range data {
+		hMem, _, _ := procGlobalAlloc.Call(GMEM_MOVEABLE, uintptr(max(len(value), 1)))
+		if hMem == 0 {
+			return ErrClipboardFailed
+		}
+		ptr, _, _ := procGlobalLock.Call(hMem)
+		if ptr == 0 {
+			_, _, _ = procGlobalFree.Call(hMem)
+			return ErrClipboardFailed
+		}
+		if len(value) > 0 {
+			_, _, _ = procRtlMoveMemory.Call(ptr, uintptr(unsafe.Pointer(&value[0])), uintptr(len(value)))
+		}
+		_, _, _ = procGlobalUnlock.Call(hMem)
+		// the clipboard owns the memory once it took it
+		if r, _, _ := procSetClipboardData.Call(uintptr(format), hMem); r == 0 {
+			_, _, _ = procGlobalFree.Call(hMem)
+			return ErrClipboardFailed
+		}
+	}
+	return nil
+}
+
+// GetClipboardData reads the data the clipboard holds in a format.
+// The data may be followed by padding, the size of the memory holding it is rounded up.
+//
+// Parameters:
+//   - format: The clipboard format, such as CF_UNICODETEXT or CF_DIB
+//
+// Returns:
+//   - []byte: The data of the format
+//   - error: ErrClipboardEmpty if the clipboard holds nothing in the format, ErrClipboardBusy if it could not be opened
+func GetClipboardData(format uint32) ([]byte, error) {
+	if err := openClipboard(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _, _ = procCloseClipboard.Call()
+	}()
+
+	hMem, _, _ := procGetClipboardData.Call(uintptr(format))
+	if hMem == 0 {
+		return nil, ErrClipboardEmpty
+	}
+	size, _, _ := procGlobalSize.Call(hMem)
+	ptr, _, _ := procGlobalLock.Call(hMem)
+	if ptr == 0 {
+		return nil, ErrClipboardEmpty
+	}
+	defer func() {
+		_, _, _ = procGlobalUnlock.Call(hMem)
+	}()
+	data := make([]byte, size)
+	if size > 0 {
+		// the memory is copied by the system, it is never addressed from Go
+		_, _, _ = procRtlMoveMemory.Call(uintptr(unsafe.Pointer(&data[0])), ptr, size)
+	}
+	return data, nil
+}
+
+// SetClipboardText replaces the contents of the clipboard with text.
+//
+// Parameters:
+//   - text: The text to put on the clipboard
+//
+// Returns:
+//   - error: ErrClipboardBusy or ErrClipboardFailed if the text could not be stored
+func SetClipboardText(text string) error {
+	units, err := windows.UTF16FromString(text)
+	if err != nil {
+		return err
+	}
+	data := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(data[2*i:], u)
+	}
+	return SetClipboardData(map[uint32][]byte{CF_UNICODETEXT: data})
+}
+
+// GetClipboardText reads the text on the clipboard.
+//
+// Returns:
+//   - string: The text on the clipboard
+//   - error: ErrClipboardEmpty if the clipboard holds no text, ErrClipboardBusy if it could not be opened
+func GetClipboardText() (string, error) {
+	data, err := GetClipboardData(CF_UNICODETEXT)
+	if err != nil {
+		return "", err
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return windows.UTF16ToString(units), nil
+}
@@ -0,0 +1,128 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"golang.org/x/sys/windows"
+)
+
+// iconInfo mirrors the Win32 ICONINFO structure.
+type iconInfo struct {
+	FIcon    int32
+	XHotspot uint32
+	YHotspot uint32
+	HbmMask  windows.Handle
+	HbmColor windows.Handle
+}
+
+var (
+	windowCursorMap   = make(map[uintptr]windows.Handle)
+	windowCursorMapMu sync.Mutex
+	imageCursorCache  = make(map[*common.Cursor]windows.Handle)
+	imageCursorMu     sync.Mutex
+)
+
+// SetWindowCursor sets the mouse pointer shown over the client area of the window.
+// The cursor is remembered for WM_SETCURSOR, image cursors are created the first time they are used and reused afterwards.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cursor: The cursor to show, nil for the arrow
+func SetWindowCursor(hwnd uintptr, cursor *common.Cursor) {
+	h := loadCursor(cursor)
+	windowCursorMapMu.Lock()
+	windowCursorMap[hwnd] = h
+	windowCursorMapMu.Unlock()
+	SetCursor(h)
+}
+
+// windowCursor returns the cursor set for the window with SetWindowCursor, the arrow if none was set.
+func windowCursor(hwnd uintptr) windows.Handle {
+	windowCursorMapMu.Lock()
+	defer windowCursorMapMu.Unlock()
+	if h, ok := windowCursorMap[hwnd]; ok && h != 0 {
+		return h
+	}
+	return LoadArrowCursor()
+}
+
+// loadCursor returns the native cursor for the cursor. Image cursors that cannot be created fall back to the arrow.
+func loadCursor(cursor *common.Cursor) windows.Handle {
+	if cursor == nil {
+		return LoadArrowCursor()
+	}
+	if cursor.Shape == common.CursorShapeImage {
+		if h := loadImageCursor(cursor); h != 0 {
+			return h
+		}
+		return LoadArrowCursor()
+	}
+	h, _, _ := procLoadCursorW.Call(0, uintptr(systemCursorID(cursor.Shape)))
+	return windows.Handle(h)
+}
+
+// loadImageCursor creates the native cursor of an image cursor with CreateIconIndirect, or returns the cached one.
+// https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-createiconindirect
+func loadImageCursor(cursor *common.Cursor) windows.Handle {
+	imageCursorMu.Lock()
+	defer imageCursorMu.Unlock()
+	if h, ok := imageCursorCache[cursor]; ok {
+		return h
+	}
+
+	pixels, w, h := cursor.ARGB()
+	if w == 0 || h == 0 {
+		return 0
+	}
+	// the 32bpp color bitmap carries the alpha channel, the all zero mask leaves it in charge
+	color, _, _ := procCreateBitmap.Call(uintptr(w), uintptr(h), 1, 32, uintptr(unsafe.Pointer(&pixels[0])))
+	maskStride := (w + 15) / 16 * 2
+	mask := make([]byte, maskStride*h)
+	monochrome, _, _ := procCreateBitmap.Call(uintptr(w), uintptr(h), 1, 1, uintptr(unsafe.Pointer(&mask[0])))
+	defer DeleteObject(windows.Handle(color))
+	defer DeleteObject(windows.Handle(monochrome))
+
+	info := iconInfo{
+		FIcon:    0,
+		XHotspot: uint32(cursor.HotX),
+		YHotspot: uint32(cursor.HotY),
+		HbmMask:  windows.Handle(monochrome),
+		HbmColor: windows.Handle(color),
+	}
+	ret, _, _ := procCreateIconIndirect.Call(uintptr(unsafe.Pointer(&info)))
+	imageCursorCache[cursor] = windows.Handle(ret)
+	return windows.Handle(ret)
+}
+
+// systemCursorID maps a cursor shape to the ID of the matching system cursor.
+func systemCursorID(shape common.CursorShape) uint32 {
+	switch shape {
+	case common.CursorShapeIBeam:
+		return IDC_BEAM
+	case common.CursorShapeHand:
+		return IDC_HAND
+	case common.CursorShapeCrosshair:
+		return IDC_CROSS
+	case common.CursorShapeResizeEW:
+		return IDC_SIZEWE
+	case common.CursorShapeResizeNS:
+		return IDC_SIZENS
+	case common.CursorShapeResizeNWSE:
+		return IDC_SIZENWSE
+	case common.CursorShapeResizeNESW:
+		return IDC_SIZENESW
+	case common.CursorShapeMove:
+		return IDC_SIZEALL
+	case common.CursorShapeWait:
+		return IDC_WAIT
+	case common.CursorShapeNotAllowed:
+		return IDC_NO
+	default:
+		return IDC_ARROW
+	}
+}
@@ -136,3 +136,50 @@ func FillRect(hdc uintptr, rect [4]int32, brush uintptr) {
 	r := rect
 	_, _, _ = procFillRect.Call(hdc, uintptr(unsafe.Pointer(&r)), brush)
 }
+
+// MeasureString measures the dimensions of a text string without requiring a window device context.
+// It uses the screen device context and the cached font for the given name and size.
+//
+// Parameters:
+//   - fontName: The name of the font used to measure the text
+//   - fontSize: The size of the font in logical units
+//   - text: The text string to be measured
+//
+// Returns:
+//   - int32: The width of the text
+//   - int32: The height of the text
+func MeasureString(fontName string, fontSize int32, text string) (int32, int32) {
+	hdc := GetDC(0)
+	defer ReleaseDC(0, hdc)
+	font := CreateFont(-fontSize, fontName)
+	if text == "" {
+		_, h := MeasureText(hdc, font, "Ag")
+		return 0, h
+	}
+	return MeasureText(hdc, font, text)
+}
+
+// PushClipRect restricts all following drawing on the device context to the given rectangle.
+// The current clip region is saved with SaveDC and intersected with the rectangle, and must be balanced with PopClipRect.
+// https://learn.microsoft.com/en-us/windows/win32/api/wingdi/nf-wingdi-intersectcliprect
+//
+// Parameters:
+//   - hdc: Handle to the device context
+//   - x: The x-coordinate of the clip rectangle
+//   - y: The y-coordinate of the clip rectangle
+//   - w: The width of the clip rectangle
+//   - h: The height of the clip rectangle
+func PushClipRect(hdc uintptr, x, y, w, h int32) {
+	_, _, _ = procSaveDC.Call(hdc)
+	_, _, _ = procIntersectClipRect.Call(hdc, uintptr(x), uintptr(y), uintptr(x+w), uintptr(y+h))
+}
+
+// PopClipRect restores the clip region saved by the matching PushClipRect.
+// https://learn.microsoft.com/en-us/windows/win32/api/wingdi/nf-wingdi-restoredc
+//
+// Parameters:
+//   - hdc: Handle to the device context
+func PopClipRect(hdc uintptr) {
+	restoreLast := int32(-1)
+	_, _, _ = procRestoreDC.Call(hdc, uintptr(restoreLast))
+}
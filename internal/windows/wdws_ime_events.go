@@ -0,0 +1,156 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/Carmen-Shannon/gooey/common"
+
+	"golang.org/x/sys/windows"
+)
+
+// highSurrogate holds the first half of a character outside of the Basic Multilingual Plane, whose two halves arrive
+// in separate WM_CHAR messages.
+var highSurrogate uint16
+
+// compositionForm is the COMPOSITIONFORM structure placing the composition window of an input method.
+type compositionForm struct {
+	Style      uint32
+	CurrentPos Point
+	Area       [4]int32
+}
+
+// candidateForm is the CANDIDATEFORM structure placing the candidate list of an input method.
+type candidateForm struct {
+	Index      uint32
+	Style      uint32
+	CurrentPos Point
+	Area       [4]int32
+}
+
+// typedChar returns the character a WM_CHAR message types, joining the halves of a surrogate pair.
+//
+// Parameters:
+//   - unit: The UTF-16 code unit of the message
+//
+// Returns:
+//   - rune: The typed character, 0 for control characters and the first half of a surrogate pair
+func typedChar(unit uint16) rune {
+	ch := rune(unit)
+	switch {
+	case ch >= 0xD800 && ch < 0xDC00:
+		highSurrogate = unit
+		return 0
+	case ch >= 0xDC00 && ch < 0xE000:
+		high := highSurrogate
+		highSurrogate = 0
+		if high == 0 {
+			return 0
+		}
+		return utf16.DecodeRune(rune(high), ch)
+	}
+	highSurrogate = 0
+	if ch < 32 || ch == 127 {
+		return 0
+	}
+	return ch
+}
+
+// handleComposition handles a WM_IME_COMPOSITION message for the focused text input.
+// The committed result is inserted at the caret and the text still being composed stored as its preedit.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - id: The ID of the focused text input component
+//   - lParam: The parts of the composition which changed
+func handleComposition(hwnd windows.Handle, id uintptr, lParam uintptr) {
+	himc, _, _ := procImmGetContext.Call(uintptr(hwnd))
+	if himc == 0 {
+		return
+	}
+	defer procImmReleaseContext.Call(uintptr(hwnd), himc)
+
+	if lParam&GCS_RESULTSTR != 0 {
+		if result := compositionString(himc, GCS_RESULTSTR); len(result) > 0 {
+			handleTextInputText(id, string(utf16.Decode(result)))
+		}
+		UpdateTextInputState(id, common.UpdateTIPreedit("", 0))
+	}
+	if lParam&GCS_COMPSTR != 0 {
+		units := compositionString(himc, GCS_COMPSTR)
+		cursor := len(units)
+		if lParam&GCS_CURSORPOS != 0 {
+			pos, _, _ := procImmGetCompositionStringW.Call(himc, GCS_CURSORPOS, 0, 0)
+			cursor = min(max(int(int32(pos)), 0), len(units))
+		}
+		// the cursor counts UTF-16 code units, the preedit characters
+		preedit := utf16.Decode(units)
+		UpdateTextInputState(id, common.UpdateTIPreedit(string(preedit), int32(len(utf16.Decode(units[:cursor])))))
+	}
+	placeInputMethod(hwnd)
+}
+
+// compositionString reads a part of the composition of an input context.
+//
+// Parameters:
+//   - himc: The input context
+//   - index: The part to read, GCS_COMPSTR or GCS_RESULTSTR
+//
+// Returns:
+//   - []uint16: The UTF-16 code units of the part, empty if there are none
+func compositionString(himc uintptr, index uintptr) []uint16 {
+	size, _, _ := procImmGetCompositionStringW.Call(himc, index, 0, 0)
+	if int32(size) <= 0 {
+		return nil
+	}
+	units := make([]uint16, int32(size)/2)
+	if len(units) == 0 {
+		return nil
+	}
+	procImmGetCompositionStringW.Call(himc, index, uintptr(unsafe.Pointer(&units[0])), size)
+	return units
+}
+
+// focusInputMethod cancels what the input method of a window is composing when the keyboard focus moves,
+// clearing the preedit of the text input which held the focus.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - prevID: The ID of the text input which held the focus, 0 for none
+func focusInputMethod(hwnd windows.Handle, prevID uintptr) {
+	if prevID == 0 {
+		return
+	}
+	if himc, _, _ := procImmGetContext.Call(uintptr(hwnd)); himc != 0 {
+		procImmNotifyIME.Call(himc, NI_COMPOSITIONSTR, CPS_CANCEL, 0)
+		procImmReleaseContext.Call(uintptr(hwnd), himc)
+	}
+	UpdateTextInputState(prevID, common.UpdateTIPreedit("", 0))
+}
+
+// placeInputMethod moves the composition window of the window's input method to the caret of the focused text input
+// and keeps its candidate list from covering the caret's line.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func placeInputMethod(hwnd windows.Handle) {
+	state := GetTextInputState(HLTR.TextInputID)
+	if state == nil {
+		return
+	}
+	himc, _, _ := procImmGetContext.Call(uintptr(hwnd))
+	if himc == 0 {
+		return
+	}
+	defer procImmReleaseContext.Call(uintptr(hwnd), himc)
+
+	caret := state.CaretRect(textMeasure(state))
+	area := [4]int32{caret.X, caret.Y, caret.X + caret.W, caret.Y + caret.H}
+	composition := compositionForm{Style: CFS_POINT, CurrentPos: Point{X: caret.X, Y: caret.Y}}
+	procImmSetCompositionWindow.Call(himc, uintptr(unsafe.Pointer(&composition)))
+	candidate := candidateForm{Style: CFS_EXCLUDE, CurrentPos: Point{X: caret.X, Y: caret.Y + caret.H}, Area: area}
+	procImmSetCandidateWindow.Call(himc, uintptr(unsafe.Pointer(&candidate)))
+}
@@ -0,0 +1,85 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	invokeQueueMap = make(map[uintptr][]func())
+	invokeQueueMu  sync.Mutex
+)
+
+// RegisterInvokeQueue opens the queue of functions invoked on the event loop of a window.
+// Until it is called, and after the window was destroyed, PostInvoke rejects functions for the window.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func RegisterInvokeQueue(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	defer invokeQueueMu.Unlock()
+	invokeQueueMap[hwnd] = nil
+}
+
+// PostInvoke queues fn to run on the event loop of the window and wakes the loop by posting it WM_GOOEY_INVOKE.
+// It is safe to call from any goroutine.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - fn: The function to run on the window's event loop
+//
+// Returns:
+//   - bool: True if fn was queued, false if the window has no open queue because it was never registered or was destroyed.
+func PostInvoke(hwnd uintptr, fn func()) bool {
+	invokeQueueMu.Lock()
+	defer invokeQueueMu.Unlock()
+
+	queue, ok := invokeQueueMap[hwnd]
+	if !ok {
+		return false
+	}
+	invokeQueueMap[hwnd] = append(queue, fn)
+	// a single pending message is enough, the loop runs the whole queue when it arrives
+	if len(queue) == 0 {
+		PostMessage(windows.Handle(hwnd), WM_GOOEY_INVOKE, 0, 0)
+	}
+	return true
+}
+
+// runInvoked runs the functions queued for the window in the order they were posted.
+// It is called by WindowProc on the window's event loop when WM_GOOEY_INVOKE arrives.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func runInvoked(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	queue, ok := invokeQueueMap[hwnd]
+	if ok {
+		invokeQueueMap[hwnd] = nil
+	}
+	invokeQueueMu.Unlock()
+
+	for _, fn := range queue {
+		fn()
+	}
+}
+
+// closeInvokeQueue closes the queue of a window that is being destroyed and runs the functions still queued,
+// so goroutines waiting on them are released. Functions posted afterwards are rejected by PostInvoke.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func closeInvokeQueue(hwnd uintptr) {
+	invokeQueueMu.Lock()
+	queue := invokeQueueMap[hwnd]
+	delete(invokeQueueMap, hwnd)
+	invokeQueueMu.Unlock()
+
+	for _, fn := range queue {
+		fn()
+	}
+}
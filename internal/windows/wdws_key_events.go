@@ -0,0 +1,145 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	keyCallbackMap = make(map[uintptr]func(common.KeyInput) bool)
+	keyCallbackMu  sync.Mutex
+	// suppressChar drops the WM_CHAR translated from a key press the window consumed, such as a shortcut.
+	suppressChar bool
+)
+
+// RegisterKeyCallback registers a callback function to be called with the raw keyboard input of a window.
+// The callback returns true when it consumed the key, for example because it triggered a shortcut,
+// in which case the key and the character it produces are not passed on to the focused text input.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with each key input
+func RegisterKeyCallback(hwnd uintptr, cb func(common.KeyInput) bool) {
+	keyCallbackMu.Lock()
+	defer keyCallbackMu.Unlock()
+	keyCallbackMap[hwnd] = cb
+}
+
+// handleKey reports a WM_KEYDOWN, WM_KEYUP, WM_SYSKEYDOWN or WM_SYSKEYUP message to the window.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - kind: common.KeyInputDown or common.KeyInputUp
+//   - wParam: The virtual key code of the key
+//   - lParam: The key flags, bit 30 holding the previous key state
+//
+// Returns:
+//   - bool: True if the window consumed the key, false otherwise
+func handleKey(hwnd windows.Handle, kind common.KeyInputKind, wParam, lParam uintptr) bool {
+	keyCallbackMu.Lock()
+	cb := keyCallbackMap[uintptr(hwnd)]
+	keyCallbackMu.Unlock()
+	if cb == nil {
+		return false
+	}
+	handled := cb(common.KeyInput{
+		Kind:      kind,
+		Key:       vkKey(wParam),
+		Modifiers: winModifiers(0),
+		Repeat:    kind == common.KeyInputDown && lParam&(1<<30) != 0,
+	})
+	if handled && kind == common.KeyInputDown {
+		suppressChar = true
+	}
+	return handled
+}
+
+// vkKey translates a virtual key code into a platform-neutral key.
+//
+// Parameters:
+//   - vk: The virtual key code
+//
+// Returns:
+//   - event.Key: The matching key, or event.KeyUnknown
+func vkKey(vk uintptr) event.Key {
+	switch {
+	case vk >= 'A' && vk <= 'Z':
+		return event.KeyA + event.Key(vk-'A')
+	case vk >= '0' && vk <= '9':
+		return event.Key0 + event.Key(vk-'0')
+	case vk >= VK_F1 && vk <= VK_F12:
+		return event.KeyF1 + event.Key(vk-VK_F1)
+	}
+	switch vk {
+	case VK_UP:
+		return event.KeyUp
+	case VK_DOWN:
+		return event.KeyDown
+	case VK_LEFT:
+		return event.KeyLeft
+	case VK_RIGHT:
+		return event.KeyRight
+	case VK_PRIOR:
+		return event.KeyPageUp
+	case VK_NEXT:
+		return event.KeyPageDown
+	case VK_HOME:
+		return event.KeyHome
+	case VK_END:
+		return event.KeyEnd
+	case VK_ESCAPE:
+		return event.KeyEscape
+	case VK_RETURN:
+		return event.KeyEnter
+	case VK_TAB:
+		return event.KeyTab
+	case VK_BACK:
+		return event.KeyBackspace
+	case VK_DELETE:
+		return event.KeyDelete
+	case VK_INSERT:
+		return event.KeyInsert
+	case VK_SPACE:
+		return event.KeySpace
+	case VK_OEM_MINUS:
+		return event.KeyMinus
+	case VK_OEM_PLUS:
+		return event.KeyEqual
+	case VK_OEM_COMMA:
+		return event.KeyComma
+	case VK_OEM_PERIOD:
+		return event.KeyPeriod
+	case VK_OEM_2:
+		return event.KeySlash
+	case VK_OEM_1:
+		return event.KeySemicolon
+	case VK_OEM_7:
+		return event.KeyApostrophe
+	case VK_OEM_4:
+		return event.KeyLeftBracket
+	case VK_OEM_6:
+		return event.KeyRightBracket
+	case VK_OEM_5:
+		return event.KeyBackslash
+	case VK_OEM_3:
+		return event.KeyGrave
+	case VK_SHIFT:
+		return event.KeyShift
+	case VK_CONTROL:
+		return event.KeyCtrl
+	case VK_MENU:
+		return event.KeyAlt
+	case VK_LWIN, VK_RWIN:
+		return event.KeySuper
+	case VK_CAPITAL:
+		return event.KeyCapsLock
+	}
+	return event.KeyUnknown
+}
@@ -0,0 +1,172 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+
+	"golang.org/x/sys/windows"
+)
+
+// trackMouseEvent is the TRACKMOUSEEVENT structure used to request a WM_MOUSELEAVE message.
+type trackMouseEvent struct {
+	CbSize      uint32
+	DwFlags     uint32
+	HwndTrack   windows.Handle
+	DwHoverTime uint32
+}
+
+var (
+	mouseCallbackMap = make(map[uintptr]func(common.MouseInput))
+	mouseCallbackMu  sync.Mutex
+	mouseTracking    = make(map[windows.Handle]bool)
+	mouseTrackingMu  sync.Mutex
+)
+
+// RegisterMouseCallback registers a callback function to be called with the raw mouse input of a window.
+// It takes a window handle and a callback function as parameters.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - cb: The callback function to be called with each mouse input
+func RegisterMouseCallback(hwnd uintptr, cb func(common.MouseInput)) {
+	mouseCallbackMu.Lock()
+	defer mouseCallbackMu.Unlock()
+	mouseCallbackMap[hwnd] = cb
+}
+
+// handleMouse invokes the mouse callback registered for the window handle, if any.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - input: The mouse input to report
+func handleMouse(hwnd windows.Handle, input common.MouseInput) {
+	mouseCallbackMu.Lock()
+	cb := mouseCallbackMap[uintptr(hwnd)]
+	mouseCallbackMu.Unlock()
+	if cb != nil {
+		cb(input)
+	}
+}
+
+// handleMouseButton reports a button press or release message to the window.
+// The window captures the mouse while any button is held so drags keep being reported outside of it.
+// Double click messages are reported as ordinary presses, the window counts the clicks itself.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+//   - kind: common.MouseInputDown or common.MouseInputUp
+//   - wParam: The wParam of the message holding the MK_ flags
+//   - lParam: The lParam of the message holding the client coordinates
+//   - button: The button the message is about
+func handleMouseButton(hwnd windows.Handle, kind common.MouseInputKind, wParam, lParam uintptr, button event.MouseButton) {
+	if kind == common.MouseInputDown {
+		SetCapture(hwnd)
+	} else if wParam&(MK_LBUTTON|MK_RBUTTON|MK_MBUTTON|MK_XBUTTON1|MK_XBUTTON2) == 0 {
+		ReleaseCapture()
+	}
+	handleMouse(hwnd, common.MouseInput{
+		Kind:      kind,
+		X:         int32(int16(LOWORD(lParam))),
+		Y:         int32(int16(HIWORD(lParam))),
+		Button:    button,
+		Modifiers: winModifiers(wParam),
+	})
+}
+
+// xButton returns the extra mouse button a WM_XBUTTON message is about.
+//
+// Parameters:
+//   - wParam: The wParam of the message, its high word holding XBUTTON1 or XBUTTON2
+//
+// Returns:
+//   - event.MouseButton: event.MouseButtonBack for XBUTTON1 and event.MouseButtonForward for XBUTTON2
+func xButton(wParam uintptr) event.MouseButton {
+	if HIWORD(wParam) == XBUTTON2 {
+		return event.MouseButtonForward
+	}
+	return event.MouseButtonBack
+}
+
+// winModifiers returns the modifier keys held during a mouse message.
+// Shift and control are taken from the MK_ flags of the message, alt and the windows keys from the keyboard state.
+//
+// Parameters:
+//   - wParam: The wParam of the mouse message, 0 when the message carries no MK_ flags
+//
+// Returns:
+//   - event.Modifiers: The modifier keys held during the message
+func winModifiers(wParam uintptr) event.Modifiers {
+	var mods event.Modifiers
+	if wParam&MK_SHIFT != 0 || uint16(GetKeyState(VK_SHIFT))&0x8000 != 0 {
+		mods |= event.ModShift
+	}
+	if wParam&MK_CONTROL != 0 || uint16(GetKeyState(VK_CONTROL))&0x8000 != 0 {
+		mods |= event.ModCtrl
+	}
+	if uint16(GetKeyState(VK_MENU))&0x8000 != 0 {
+		mods |= event.ModAlt
+	}
+	if uint16(GetKeyState(VK_LWIN))&0x8000 != 0 || uint16(GetKeyState(VK_RWIN))&0x8000 != 0 {
+		mods |= event.ModSuper
+	}
+	return mods
+}
+
+// wheelDelta translates the rotation of a WM_MOUSEWHEEL or WM_MOUSEHWHEEL message into a scroll distance.
+// Windows reports the vertical wheel as positive when rotated away from the user, which scrolls the content up.
+//
+// Parameters:
+//   - delta: The signed wheel rotation in multiples of WHEEL_DELTA
+//   - horizontal: Whether the message came from a horizontal wheel
+//   - shift: Whether the shift key is held, which turns vertical scrolling horizontal
+//
+// Returns:
+//   - dx: The horizontal scroll distance
+//   - dy: The vertical scroll distance
+func wheelDelta(delta int32, horizontal, shift bool) (dx, dy int32) {
+	if horizontal {
+		dx = delta
+	} else {
+		dy = -delta
+	}
+	if shift {
+		dx, dy = dy, dx
+	}
+	return dx, dy
+}
+
+// trackMouseLeave asks Windows to send WM_MOUSELEAVE once the pointer leaves the client area.
+// The request is only made once per entry into the window.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func trackMouseLeave(hwnd windows.Handle) {
+	mouseTrackingMu.Lock()
+	defer mouseTrackingMu.Unlock()
+	if mouseTracking[hwnd] {
+		return
+	}
+	tme := trackMouseEvent{
+		CbSize:    uint32(unsafe.Sizeof(trackMouseEvent{})),
+		DwFlags:   TME_LEAVE,
+		HwndTrack: hwnd,
+	}
+	ret, _, _ := procTrackMouseEvent.Call(uintptr(unsafe.Pointer(&tme)))
+	mouseTracking[hwnd] = ret != 0
+}
+
+// untrackMouseLeave records that the WM_MOUSELEAVE requested by trackMouseLeave was delivered.
+//
+// Parameters:
+//   - hwnd: The handle to the window
+func untrackMouseLeave(hwnd windows.Handle) {
+	mouseTrackingMu.Lock()
+	defer mouseTrackingMu.Unlock()
+	delete(mouseTracking, hwnd)
+}
@@ -0,0 +1,41 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/windows"
+)
+
+// LaunchPopupOnThread creates a popup window on a dedicated thread and pumps its messages there until it is destroyed.
+// Popups such as the selector overlay and tooltips need their own loop, as the owner's loop only pumps its own messages.
+// The function waits for the window to be created before returning the handle.
+//
+// Parameters:
+//   - create: Creates and shows the popup on the dedicated thread, returning 0 if it could not be created
+//
+// Returns:
+//   - windows.Handle: The handle of the popup, or 0 if it could not be created
+func LaunchPopupOnThread(create func() windows.Handle) windows.Handle {
+	created := make(chan windows.Handle)
+	go func() {
+		runtime.LockOSThread()
+		hwnd := create()
+		created <- hwnd
+		if hwnd == 0 {
+			return // failed to create window
+		}
+		msg := new(Msg)
+		for {
+			ret, err := GetMessage(MessageOpt(msg), WindowHandleOpt(hwnd))
+			if ret == 0 || err != nil {
+				break // the popup was destroyed
+			}
+			_ = TranslateMessage(msg)
+			_ = DispatchMessage(msg)
+		}
+	}()
+	return <-created
+}
@@ -0,0 +1,129 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+var (
+	scrollViewStateMap   = make(map[uintptr]*common.ScrollViewState)
+	scrollViewStateMapMu sync.Mutex
+	activeScrollViewID   uintptr
+)
+
+// RegisterScrollViewState registers the state of a scroll view so it can receive wheel, pointer and keyboard input.
+//
+// Parameters:
+//   - componentID: The ID of the scroll view component
+//   - state: A pointer to the ScrollViewState shared with the component
+func RegisterScrollViewState(componentID uintptr, state *common.ScrollViewState) {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	scrollViewStateMap[componentID] = state
+}
+
+// GetScrollViewState retrieves the state of a scroll view by its component ID.
+//
+// Parameters:
+//   - componentID: The ID of the scroll view component
+//
+// Returns:
+//   - *common.ScrollViewState: The state of the scroll view, or nil if it is not registered
+func GetScrollViewState(componentID uintptr) *common.ScrollViewState {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	return scrollViewStateMap[componentID]
+}
+
+// FindScrollViewAt finds the innermost scroll view at the given coordinates.
+// When scroll views are nested the one with the smallest bounds wins.
+//
+// Parameters:
+//   - x: The x-coordinate of the point
+//   - y: The y-coordinate of the point
+//
+// Returns:
+//   - componentID: The ID of the scroll view if found, 0 otherwise
+//   - found: A boolean indicating whether a scroll view was found
+func FindScrollViewAt(x, y int32) (componentID uintptr, found bool) {
+	scrollViewStateMapMu.Lock()
+	defer scrollViewStateMapMu.Unlock()
+	var area int64
+	for id, state := range scrollViewStateMap {
+		if !state.HitTest(x, y) {
+			continue
+		}
+		a := int64(state.Bounds.W) * int64(state.Bounds.H)
+		if !found || a < area {
+			componentID, found, area = id, true, a
+		}
+	}
+	return componentID, found
+}
+
+// scrollViewEvents returns the event dispatcher of a scroll view, or nil if it is not registered.
+// The map lock is released before the dispatcher is returned so listeners may re-enter the backend.
+func scrollViewEvents(id uintptr) *event.Dispatcher {
+	state := GetScrollViewState(id)
+	if state == nil {
+		return nil
+	}
+	return state.Events
+}
+
+// activateScrollViewAt makes the innermost scroll view under the pointer the target of the scrolling keys.
+// It is called for presses and wheel turns, the mouse input itself reaches the scroll view through its window.
+//
+// Parameters:
+//   - x: The x-coordinate of the pointer in client coordinates
+//   - y: The y-coordinate of the pointer in client coordinates
+func activateScrollViewAt(x, y int32) {
+	if id, found := FindScrollViewAt(x, y); found {
+		activeScrollViewID = id
+	}
+}
+
+// handleScrollViewKey translates a paging virtual key and forwards it to the scroll view that was last interacted with.
+//
+// Parameters:
+//   - vk: The virtual key code of the pressed key
+//
+// Returns:
+//   - bool: True if the key was a scrolling key and a scroll view consumed it, false otherwise
+func handleScrollViewKey(vk uintptr) bool {
+	if activeScrollViewID == 0 {
+		return false
+	}
+	var key event.Key
+	switch vk {
+	case VK_UP:
+		key = event.KeyUp
+	case VK_DOWN:
+		key = event.KeyDown
+	case VK_LEFT:
+		key = event.KeyLeft
+	case VK_RIGHT:
+		key = event.KeyRight
+	case VK_PRIOR:
+		key = event.KeyPageUp
+	case VK_NEXT:
+		key = event.KeyPageDown
+	case VK_HOME:
+		key = event.KeyHome
+	case VK_END:
+		key = event.KeyEnd
+	default:
+		return false
+	}
+	events := scrollViewEvents(activeScrollViewID)
+	if !event.HasListeners[event.KeyDownEvent](events) {
+		return false
+	}
+	event.Publish(events, event.KeyDownEvent{Source: activeScrollViewID, Key: key})
+	return true
+}
@@ -5,6 +5,7 @@ package wdws
 
 import (
 	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/internal/textedit"
 
 	"golang.org/x/sys/windows"
 )
@@ -16,9 +17,10 @@ import (
 //   - id: The ID of the text input component
 //   - windowHandle: The handle to the window containing the text input
 //   - mouseX: The X coordinate of the mouse click
+//   - mouseY: The Y coordinate of the mouse click, which picks the line of a text area
 //   - event: The event type (e.g., "start", "update", "end")
-func updateTextInputSelection(id uintptr, windowHandle windows.Handle, mouseX int32, event string) {
-	caret := getCaretPosForTextInput(id, windowHandle, mouseX)
+func updateTextInputSelection(id uintptr, windowHandle windows.Handle, mouseX, mouseY int32, event string) {
+	caret := getCaretPosForTextInput(id, windowHandle, mouseX, mouseY)
 	switch event {
 	case "start":
 		HLTR.TextInputID = id
@@ -27,7 +29,7 @@ func updateTextInputSelection(id uintptr, windowHandle windows.Handle, mouseX in
 		HLTR.SelectionEnd = caret
 	case "update":
 		if HLTR.Active && HLTR.TextInputID == id {
-			HLTR.SelectionEnd = caret
+			HLTR.SelectionEnd = dragCaret(id, caret, mouseX)
 		}
 	case "end":
 		if HLTR.Active && HLTR.TextInputID == id {
@@ -46,14 +48,18 @@ func updateTextInputSelection(id uintptr, windowHandle windows.Handle, mouseX in
 //   - id: The ID of the text input component
 //   - windowHandle: The handle to the window containing the text input
 //   - mouseX: The X coordinate of the mouse click
+//   - mouseY: The Y coordinate of the mouse click, which picks the line of a text area
 //
 // Returns:
 //   - int32: The calculated caret position
-func getCaretPosForTextInput(id uintptr, windowHandle windows.Handle, mouseX int32) int32 {
+func getCaretPosForTextInput(id uintptr, windowHandle windows.Handle, mouseX, mouseY int32) int32 {
 	state := GetTextInputState(id)
 	if state == nil {
 		return 0
 	}
+	if state.Multiline {
+		return state.PosAt(mouseX, mouseY, textMeasure(state))
+	}
 	rect := [4]int32{
 		state.Bounds.X,
 		state.Bounds.Y,
@@ -61,7 +67,7 @@ func getCaretPosForTextInput(id uintptr, windowHandle windows.Handle, mouseX int
 		state.Bounds.Y + state.Bounds.Height,
 	}
 	fontInfo := state.Font
-	text := state.Value
+	text := state.DisplayText()
 	font := CreateFont(-fontInfo.Size, fontInfo.Name)
 	defer DeleteObject(font)
 
@@ -69,7 +75,7 @@ func getCaretPosForTextInput(id uintptr, windowHandle windows.Handle, mouseX int
 	defer ReleaseDC(windowHandle, hdc)
 
 	padding := int32(4)
-	caret := caretPosFromClick(hdc, font, text, rect[0], mouseX, padding)
+	caret := caretPosFromClick(hdc, font, text, rect[0]-state.Scroll.X, mouseX, padding)
 	runes := []rune(text)
 	if caret < 0 {
 		caret = 0
@@ -80,98 +86,129 @@ func getCaretPosForTextInput(id uintptr, windowHandle windows.Handle, mouseX int
 	return caret
 }
 
+// dragCaret moves the caret of a drag selection a character past the visible text while the pointer is beyond the
+// left or right edge of a single-line text input, which the text input then scrolls into view.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - caret: The position under the pointer
+//   - mouseX: The X coordinate of the pointer
+//
+// Returns:
+//   - int32: The new caret position
+func dragCaret(id uintptr, caret, mouseX int32) int32 {
+	state := GetTextInputState(id)
+	if state == nil || state.Multiline {
+		return caret
+	}
+	return min(max(caret+state.EdgeStep(mouseX), 0), int32(len([]rune(state.Value))))
+}
+
+// textMeasure returns a function measuring text in the font of a text input, which lays out the lines of text areas.
+//
+// Parameters:
+//   - state: The state of the text input
+//
+// Returns:
+//   - func(string) int32: The width of a piece of text in pixels
+func textMeasure(state *common.TextInputState) func(text string) int32 {
+	name, size := state.Font.Name, state.Font.Size
+	return func(text string) int32 {
+		w, _ := MeasureString(name, size, text)
+		return w
+	}
+}
+
 // handleTextInputClickCallbacks handles the callbacks for text input components.
-// It checks if the component is focused and calls the appropriate callback function.
+// It focuses the clicked component, or unfocuses all of them, and the states publish the resulting events.
 //
 // Parameters:
 //   - id: The ID of the text input component
 //   - found: A boolean indicating whether the component was found
-func handleTextInputClickCallbacks(id uintptr, found bool, windowHandle windows.Handle, mouseX int32, doubleClick ...bool) {
+func handleTextInputClickCallbacks(id uintptr, found bool, windowHandle windows.Handle, mouseX, mouseY int32, doubleClick ...bool) {
 	isDoubleClick := len(doubleClick) > 0 && doubleClick[0]
+	// a click ends any composition, the caret moves or the focus leaves
+	focusInputMethod(windowHandle, HLTR.TextInputID)
 	if found {
 		HLTR.TextInputID = id
 		state := GetTextInputState(id)
 		if state == nil {
 			return
 		}
-		caretPos := getCaretPosForTextInput(id, windowHandle, mouseX)
+		caretPos := getCaretPosForTextInput(id, windowHandle, mouseX, mouseY)
 		var selStart, selEnd int32
 		if isDoubleClick {
 			HLTR.SuppressSelection = true
-			selStart, selEnd = getWordBounds(state.Value, caretPos)
+			selStart, selEnd = state.WordAt(caretPos)
 		} else {
 			selStart, selEnd = caretPos, caretPos
 		}
 		UpdateTextInputState(id,
 			common.UpdateTIFocused(true),
 			common.UpdateTICaretPos(selEnd),
-			common.UpdateTISelectionStart(selStart),
-			common.UpdateTISelectionEnd(selEnd),
+			common.UpdateTISelection(selStart, selEnd),
 		)
 		HLTR.SelectionStart = selStart
 		HLTR.SelectionEnd = selEnd
 		HLTR.Active = true
-		if !CT.Active {
-			CT.Start(uintptr(windowHandle), id)
-		}
+		CT.Start(uintptr(windowHandle), id)
 	} else {
 		HLTR.TextInputID = 0
 		HLTR.Active = false
 		HLTR.SelectionStart = 0
 		HLTR.SelectionEnd = 0
-		for id := range textInputStateMap {
+		for _, id := range textInputIDs() {
 			UpdateTextInputState(id,
 				common.UpdateTIFocused(false),
-				common.UpdateTISelectionStart(0),
-				common.UpdateTISelectionEnd(0),
+				common.UpdateTISelection(0, 0),
 			)
 		}
 	}
 }
 
-// getWordBounds calculates the start and end positions of a word in the given text.
-// It moves the caret to the nearest non-space character and finds the word boundaries.
+// FocusTextInput moves keyboard focus to or away from a text input without a mouse click, for example when tabbing.
+// Focusing selects the whole value with the caret at its end, blurring clears the selection.
+// Focusing the text input which already holds the focus keeps its caret and selection.
 //
 // Parameters:
-//   - text: The text in which to find the word boundaries
-//   - caret: The position of the caret in the text
-//
-// Returns:
-//   - int32: The start position of the word
-//   - int32: The end position of the word
-func getWordBounds(text string, caret int32) (int32, int32) {
-	runes := []rune(text)
-	n := int32(len(runes))
-	if n == 0 || caret < 0 || caret > n {
-		return 0, 0
-	}
-	// If caret is at the end, move back one to select the last word
-	if caret == n {
-		caret--
-	}
-	// If caret is on a space, move left to the nearest non-space
-	for caret > 0 && isSeperator(runes[caret]) {
-		caret--
-	}
-	// If still on a space, no word to select
-	if isSeperator(runes[caret]) {
-		return caret, caret
+//   - hwnd: The handle to the window containing the text input
+//   - id: The ID of the text input component
+//   - focused: True to focus the text input, false to blur it
+func FocusTextInput(hwnd, id uintptr, focused bool) {
+	state := GetTextInputState(id)
+	if state == nil {
+		return
 	}
-	// Find word start
-	start := caret
-	for start > 0 && !isSeperator(runes[start-1]) {
-		start--
+	if !focused {
+		if HLTR.TextInputID == id {
+			focusInputMethod(windows.Handle(hwnd), id)
+			HLTR.TextInputID = 0
+			HLTR.Active = false
+			HLTR.SelectionStart = 0
+			HLTR.SelectionEnd = 0
+		}
+		UpdateTextInputState(id,
+			common.UpdateTIFocused(false),
+			common.UpdateTISelection(0, 0),
+		)
+		return
 	}
-	// Find word end
-	end := caret + 1
-	for end < n && !isSeperator(runes[end]) {
-		end++
+	if HLTR.TextInputID == id {
+		return
 	}
-	return start, end
-}
-
-func isSeperator(ch rune) bool {
-	return ch == ' ' || ch == '/' || ch == '\\' || ch == '.'
+	end := int32(len([]rune(state.Value)))
+	focusInputMethod(windows.Handle(hwnd), HLTR.TextInputID)
+	HLTR.TextInputID = id
+	HLTR.Active = false
+	HLTR.SuppressSelection = false
+	HLTR.SelectionStart = 0
+	HLTR.SelectionEnd = end
+	UpdateTextInputState(id,
+		common.UpdateTIFocused(true),
+		common.UpdateTICaretPos(end),
+		common.UpdateTISelection(0, end),
+	)
+	CT.Start(hwnd, id)
 }
 
 // handleTextInputSelectionCallbacks handles the selection callbacks for text input components.
@@ -182,14 +219,11 @@ func isSeperator(ch rune) bool {
 //   - start: The start position of the selection
 //   - end: The end position of the selection
 func handleTextInputSelectionCallbacks(id uintptr, start, end int32) {
-	UpdateTextInputState(id,
-		common.UpdateTISelectionStart(start),
-		common.UpdateTISelectionEnd(end),
-	)
+	UpdateTextInputState(id, common.UpdateTISelection(start, end))
 }
 
 // handleTextInputCaretCallbacks handles the caret position callbacks for text input components.
-// It updates the caret position in the text input state and calls the appropriate callback function.
+// It updates the caret position in the text input state, which publishes a CaretMovedEvent when it moved.
 //
 // Parameters:
 //   - id: The ID of the text input component
@@ -197,216 +231,171 @@ func handleTextInputCaretCallbacks(id uintptr) {
 	UpdateTextInputState(id, common.UpdateTICaretPos(HLTR.SelectionEnd))
 }
 
-// handleTextInputChar handles the character input for text input components.
-// It updates the text input state with the new value and caret position.
-// It also calls the appropriate callback functions for value and caret position changes.
+// handleTextInputCommand runs an editing command on a text input.
+// The clipboard commands go through the Windows clipboard, every other command is applied by the shared editing model.
 //
 // Parameters:
 //   - id: The ID of the text input component
-//   - ch: The character input
-func handleTextInputChar(id uintptr, ch rune) {
-	if ch < 32 || ch == 127 {
-		return
-	}
+//   - cmd: The command bound to the pressed key
+//
+// Returns:
+//   - bool: True if cmd is an editing command, false for textedit.CommandNone
+func handleTextInputCommand(id uintptr, cmd textedit.Command) bool {
+	switch cmd {
+	case textedit.CommandNone:
+		return false
+	case textedit.CommandCopy:
+		handleTextInputCopy(id)
+	case textedit.CommandCut:
+		if handleTextInputCopy(id) {
+			editTextInput(id, textedit.EditCut, (*textedit.Buffer).DeleteSelection)
+		}
+	case textedit.CommandPaste:
+		handleTextInputPaste(id)
+	case textedit.CommandUndo:
+		UndoTextInput(id)
+	case textedit.CommandRedo:
+		RedoTextInput(id)
+	case textedit.CommandNewline:
+		editTextInput(id, textedit.EditTyping, func(b *textedit.Buffer) bool { return b.Apply(cmd) })
+	default:
+		editTextInput(id, textedit.EditDelete, func(b *textedit.Buffer) bool { return b.Apply(cmd) })
+	}
+	return true
+}
+
+// editTextInput runs edit on a buffer holding the value and selection of a text input and stores the result in the state.
+// The state publishes the value, caret and selection events of the change.
+// An edit which changed the value is recorded in the text input's history.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - kind: How the edit came about, deciding whether it merges with the previous undo step
+//   - edit: The edit to run, reporting whether it changed the value
+//
+// Returns:
+//   - bool: True if the value changed
+func editTextInput(id uintptr, kind textedit.EditKind, edit func(b *textedit.Buffer) bool) bool {
 	state := GetTextInputState(id)
 	if state == nil {
-		return
-	}
-	runes := []rune(state.Value)
-	caret := HLTR.SelectionEnd
-	if caret < 0 {
-		caret = 0
-	}
-	if caret > int32(len(runes)) {
-		caret = int32(len(runes))
-	}
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
+		return false
 	}
-	if start < 0 {
-		start = 0
+	b := textedit.New(state.Value, state.MaxLength)
+	if f := state.Filter; f != nil {
+		b.SetFilter(f.Rune, f.Value)
 	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
+	if state.Multiline {
+		b.SetMultiline(state.MaxLines)
+		b.SetLayout(func() *textedit.Layout { return state.Layout(textMeasure(state)) })
 	}
-
-	var newVal string
-	var newCaret int32
-	if start != end {
-		newVal = string(runes[:start]) + string(ch) + string(runes[end:])
-		newCaret = start + int32(len([]rune(string(ch))))
-	} else {
-		newVal = string(runes[:caret]) + string(ch) + string(runes[caret:])
-		newCaret = caret + int32(len([]rune(string(ch))))
+	b.Select(HLTR.SelectionStart, HLTR.SelectionEnd)
+	if HLTR.GoalPos == b.Caret() {
+		b.SetGoal(HLTR.GoalX)
 	}
-
-	if state.MaxLength > 0 && int32(len([]rune(newVal))) > state.MaxLength {
-		return
+	before := b.Snapshot()
+	changed := edit(b)
+	if changed && state.History != nil {
+		state.History.Record(kind, before, b.Snapshot())
 	}
+	HLTR.SelectionStart = b.Anchor()
+	HLTR.SelectionEnd = b.Caret()
+	HLTR.GoalX = b.Goal()
+	HLTR.GoalPos = b.Caret()
 	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
+		common.UpdateTIStateValue(b.Value()),
+		common.UpdateTISelection(b.Anchor(), b.Caret()),
+		common.UpdateTICaretPos(b.Caret()),
 	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
+	return changed
 }
 
-// handleTextInputBackspace handles the backspace key input for text input components.
-// It deletes the character before the caret position or the selected text.
-// It updates the text input state with the new value and caret position.
-// It also calls the appropriate callback functions for value and caret position changes.
+// UndoTextInput reverts the latest edit of a text input, restoring its value, caret and selection from before the edit.
 //
 // Parameters:
 //   - id: The ID of the text input component
-func handleTextInputBackspace(id uintptr) {
-	state := GetTextInputState(id)
-	if state == nil {
-		return
-	}
-	val := state.Value
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	runes := []rune(val)
-	if start != end {
-		if start > end {
-			start, end = end, start
-		}
-		newVal := string(runes[:start]) + string(runes[end:])
-		UpdateTextInputState(id,
-			common.UpdateTIStateValue(newVal),
-			common.UpdateTISelectionStart(start),
-			common.UpdateTISelectionEnd(start),
-			common.UpdateTICaretPos(start),
-		)
-		HLTR.SelectionStart = start
-		HLTR.SelectionEnd = start
-	} else if end > 0 {
-		newVal := string(runes[:end-1]) + string(runes[end:])
-		UpdateTextInputState(id,
-			common.UpdateTIStateValue(newVal),
-			common.UpdateTISelectionStart(end-1),
-			common.UpdateTISelectionEnd(end-1),
-			common.UpdateTICaretPos(end-1),
-		)
-		HLTR.SelectionStart = end - 1
-		HLTR.SelectionEnd = end - 1
-	}
-	UpdateTextInputState(id, common.UpdateTICaretPos(HLTR.SelectionEnd))
-	handleTextInputSelectionCallbacks(id, HLTR.SelectionStart, HLTR.SelectionEnd)
+//
+// Returns:
+//   - bool: True if there was an edit to undo
+func UndoTextInput(id uintptr) bool {
+	return restoreTextInput(id, (*textedit.History).Undo)
 }
 
-// handleTextInputDelete handles the delete key input for text input components.
-// It deletes the character at the caret position or the selected text.
-// It updates the text input state with the new value and caret position.
-// It also calls the appropriate callback functions for value and caret position changes.
+// RedoTextInput reapplies the latest undone edit of a text input, restoring its value, caret and selection from after the edit.
 //
 // Parameters:
 //   - id: The ID of the text input component
-func handleTextInputDelete(id uintptr) {
+//
+// Returns:
+//   - bool: True if there was an edit to redo
+func RedoTextInput(id uintptr) bool {
+	return restoreTextInput(id, (*textedit.History).Redo)
+}
+
+// restoreTextInput moves a text input's history one step and stores the snapshot it returns in the state.
+func restoreTextInput(id uintptr, step func(h *textedit.History) (textedit.Snapshot, bool)) bool {
 	state := GetTextInputState(id)
-	if state == nil {
-		return
+	if state == nil || state.History == nil {
+		return false
 	}
-	val := state.Value
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	runes := []rune(val)
-	if start != end {
-		if start > end {
-			start, end = end, start
-		}
-		newVal := string(runes[:start]) + string(runes[end:])
-		UpdateTextInputState(id,
-			common.UpdateTIStateValue(newVal),
-			common.UpdateTISelectionStart(start),
-			common.UpdateTISelectionEnd(start),
-			common.UpdateTICaretPos(start),
-		)
-	} else if end < int32(len(runes)) {
-		newVal := string(runes[:end]) + string(runes[end+1:])
-		UpdateTextInputState(id,
-			common.UpdateTIStateValue(newVal),
-			common.UpdateTISelectionStart(end),
-			common.UpdateTISelectionEnd(end),
-			common.UpdateTICaretPos(end),
-		)
+	snap, ok := step(state.History)
+	if !ok {
+		return false
 	}
-	UpdateTextInputState(id, common.UpdateTICaretPos(HLTR.SelectionEnd))
-	handleTextInputSelectionCallbacks(id, HLTR.SelectionStart, HLTR.SelectionEnd)
+	if HLTR.TextInputID == id {
+		HLTR.SelectionStart = snap.Anchor
+		HLTR.SelectionEnd = snap.Caret
+	}
+	UpdateTextInputState(id,
+		common.UpdateTIStateValue(snap.Value),
+		common.UpdateTISelection(snap.Anchor, snap.Caret),
+		common.UpdateTICaretPos(snap.Caret),
+	)
+	return true
+}
+
+// handleTextInputText handles the typed text for text input components.
+// It inserts the text at the caret position or replaces the selected text. The text is a single character for most
+// keys and whatever an input method committed otherwise.
+// The state publishes the value, caret and selection events of the change.
+//
+// Parameters:
+//   - id: The ID of the text input component
+//   - text: The typed text, control characters are ignored
+func handleTextInputText(id uintptr, text string) {
+	editTextInput(id, textedit.EditTyping, func(b *textedit.Buffer) bool { return b.Insert(text) })
 }
 
 // handleTextInputPaste handles the paste operation for text input components.
 // It retrieves the text from the clipboard and inserts it at the caret position or replaces the selected text.
-// It updates the text input state with the new value and caret position.
-// It also calls the appropriate callback functions for value and caret position changes.
+// The state publishes the value, caret and selection events of the change.
 //
 // Parameters:
 //   - id: The ID of the text input component
 func handleTextInputPaste(id uintptr) {
-	state := GetTextInputState(id)
-	if state == nil {
-		return
-	}
-	clipText := getClipboardText()
-	if clipText == "" {
+	clipText, err := GetClipboardText()
+	if err != nil || clipText == "" {
 		return
 	}
-	runes := []rune(state.Value)
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
-	}
-	if start < 0 {
-		start = 0
-	}
-	if end > int32(len(runes)) {
-		end = int32(len(runes))
-	}
-
-	newVal := string(runes[:start]) + clipText + string(runes[end:])
-	if state.MaxLength > 0 && int32(len([]rune(newVal))) > state.MaxLength {
-		allowed := state.MaxLength - int32(len([]rune(string(runes[:start])+string(runes[end:]))))
-		if allowed < 0 {
-			allowed = 0
-		}
-		clipRunes := []rune(clipText)
-		clipText = string(clipRunes[:allowed])
-		newVal = string(runes[:start]) + clipText + string(runes[end:])
-	}
-
-	newCaret := start + int32(len([]rune(clipText)))
-	UpdateTextInputState(id,
-		common.UpdateTIStateValue(newVal),
-		common.UpdateTISelectionStart(newCaret),
-		common.UpdateTISelectionEnd(newCaret),
-		common.UpdateTICaretPos(newCaret),
-	)
-	HLTR.SelectionStart = newCaret
-	HLTR.SelectionEnd = newCaret
-	handleTextInputSelectionCallbacks(id, newCaret, newCaret)
+	editTextInput(id, textedit.EditPaste, func(b *textedit.Buffer) bool { return b.Insert(clipText) })
 }
 
 // handleTextInputCopy handles the copy operation for text input components.
-// It copies the selected text to the clipboard.
-// It retrieves the selected text and sets it to the clipboard.
+// It copies the selected text to the clipboard, unless the text input is masked.
 //
 // Parameters:
 //   - id: The ID of the text input component
-func handleTextInputCopy(id uintptr) {
+//
+// Returns:
+//   - bool: True if the selection was copied, cutting only removes it then
+func handleTextInputCopy(id uintptr) bool {
 	state := GetTextInputState(id)
-	if state == nil {
-		return
-	}
-	start, end := HLTR.SelectionStart, HLTR.SelectionEnd
-	if start > end {
-		start, end = end, start
+	if state == nil || state.Mask != 0 {
+		return false
 	}
-	runes := []rune(state.Value)
-	if start < 0 || end > int32(len(runes)) || start == end {
-		return // nothing to copy
+	b := textedit.New(state.Value, 0)
+	b.Select(HLTR.SelectionStart, HLTR.SelectionEnd)
+	if !b.HasSelection() {
+		return false // nothing to copy
 	}
-	text := string(runes[start:end])
-	setClipboardText(text)
+	return SetClipboardText(b.SelectedText()) == nil
 }
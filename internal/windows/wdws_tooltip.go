@@ -0,0 +1,160 @@
+//go:build windows
+// +build windows
+
+package wdws
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"golang.org/x/sys/windows"
+)
+
+// tooltipClassName is the window class of tooltip popups.
+const tooltipClassName = "GooeyTooltip"
+
+// tooltipPopup is the topmost tool window showing the tooltip of a window.
+// Like the selector overlay it runs its own message loop on a dedicated thread, see LaunchPopupOnThread.
+type tooltipPopup struct {
+	hwnd windows.Handle
+	tip  common.Tooltip
+}
+
+var (
+	tooltipMap      = make(map[uintptr]*tooltipPopup)
+	tooltipMu       sync.Mutex
+	tooltipProc     uintptr
+	tooltipProcOnce sync.Once
+)
+
+// ShowTooltip shows a tooltip for the window in a topmost tool window, replacing the tooltip the window already shows.
+// The popup is placed near the pointer and kept within the screen, see common.PlaceTooltip. It never takes the activation.
+//
+// Parameters:
+//   - hwnd: The handle to the window the tooltip belongs to
+//   - x: The x-coordinate of the pointer in window coordinates
+//   - y: The y-coordinate of the pointer in window coordinates
+//   - tip: The text and colors of the tooltip
+func ShowTooltip(hwnd uintptr, x, y int32, tip common.Tooltip) {
+	HideTooltip(hwnd)
+	pt := Point{X: x, Y: y}
+	if tip.Text == "" || !ClientToScreen(windows.Handle(hwnd), &pt) {
+		return
+	}
+	textW, textH := MeasureString(tip.Font, tip.FontSize, tip.Text)
+	w := textW + 2*tip.Padding
+	h := textH + 2*tip.Padding
+	px, py := common.PlaceTooltip(pt.X, pt.Y, w, h, GetSystemMetrics(0), GetSystemMetrics(1))
+
+	popup := &tooltipPopup{tip: tip}
+	tooltipMu.Lock()
+	tooltipMap[hwnd] = popup
+	tooltipMu.Unlock()
+
+	popupHwnd := LaunchPopupOnThread(func() windows.Handle {
+		tooltipProcOnce.Do(func() {
+			tooltipProc = windows.NewCallback(tooltipWindowProc)
+		})
+		clsName, _ := windows.UTF16PtrFromString(tooltipClassName)
+		hInstance := GetModuleHandle()
+		_, _ = RegisterClassExW(
+			ClassNameOpt(tooltipClassName),
+			ProcedureOpt(tooltipProc),
+			InstanceHandleOpt(uintptr(hInstance)),
+			StyleOpt(0),
+		)
+		popupHwnd, err := CreateWindow(
+			CreateWindowOptClassName(clsName),
+			CreateWindowOptWindowName(clsName),
+			CreateWindowOptStyle(WS_POPUP),
+			CreateWindowOptExStyle(WS_EX_TOPMOST|WS_EX_TOOLWINDOW|WS_EX_NOACTIVATE),
+			CreateWindowOptPosition(px, py),
+			CreateWindowOptSize(w, h),
+			CreateWindowOptInstance(hInstance),
+			CreateWindowOptParent(0),
+		)
+		if err != nil {
+			return 0
+		}
+		// the popup is hidden until shown, so it paints nothing before it knows its owner
+		SetWindowLongPtr(popupHwnd, GWLP_USERDATA, hwnd)
+		ShowWindow(popupHwnd, SW_SHOWNOACTIVATE)
+		return popupHwnd
+	})
+	tooltipMu.Lock()
+	defer tooltipMu.Unlock()
+	if popupHwnd == 0 {
+		if tooltipMap[hwnd] == popup {
+			delete(tooltipMap, hwnd)
+		}
+		return
+	}
+	popup.hwnd = popupHwnd
+}
+
+// HideTooltip hides the tooltip of the window, if it shows one.
+//
+// Parameters:
+//   - hwnd: The handle to the window the tooltip belongs to
+func HideTooltip(hwnd uintptr) {
+	tooltipMu.Lock()
+	popup := tooltipMap[hwnd]
+	delete(tooltipMap, hwnd)
+	tooltipMu.Unlock()
+
+	if popup != nil && popup.hwnd != 0 {
+		PostMessage(popup.hwnd, WM_CLOSE, 0, 0)
+	}
+}
+
+// tooltipWindowProc is the window procedure of tooltip popups.
+// It paints the tooltip of the owner window whose handle ShowTooltip stores in the popup's user data and lets clicks pass to the windows below.
+//
+// Parameters:
+//   - hwnd: The handle of the popup.
+//   - msg: The message being processed.
+//   - wParam: Additional message-specific information.
+//   - lParam: Additional message-specific information.
+//
+// Returns:
+//   - uintptr: The result of the message processing.
+func tooltipWindowProc(hwnd windows.Handle, msg uint32, wParam, lParam uintptr) uintptr {
+	switch msg {
+	case WM_MOUSEACTIVATE:
+		return MA_NOACTIVATE
+	case WM_PAINT:
+		owner := GetWindowLongPtr(hwnd, GWLP_USERDATA)
+		tooltipMu.Lock()
+		popup := tooltipMap[owner]
+		tooltipMu.Unlock()
+
+		var p Paint
+		BeginPaint(hwnd, &p)
+		defer EndPaint(hwnd, &p)
+		if popup == nil {
+			return 0
+		}
+		tip := popup.tip
+		w, h := GetClientRect(hwnd)
+		border := CreateSolidBrush(tip.Border)
+		defer DeleteObject(border)
+		background := CreateSolidBrush(tip.Background)
+		defer DeleteObject(background)
+		FillRect(p.Hdc, [4]int32{0, 0, w, h}, uintptr(border))
+		FillRect(p.Hdc, [4]int32{1, 1, w - 1, h - 1}, uintptr(background))
+
+		if font := CreateFont(-tip.FontSize, tip.Font); font != 0 {
+			oldFont := SelectObject(p.Hdc, font)
+			defer SelectObject(p.Hdc, oldFont)
+		}
+		SetTextColor(p.Hdc, tip.Color)
+		SetBkMode(p.Hdc, BK_TRANSPARENT)
+		rect := [4]int32{tip.Padding, tip.Padding, w - tip.Padding, h - tip.Padding}
+		DrawText(p.Hdc, tip.Text, &rect, DT_LEFT|DT_VCENTER|DT_SINGLELINE)
+		return 0
+	case WM_CLOSE:
+		_ = DestroyWindow(hwnd)
+		return 0
+	}
+	return DefWindowProc(hwnd, msg, wParam, lParam)
+}
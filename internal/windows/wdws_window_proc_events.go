@@ -202,6 +202,24 @@ func InvalidateRect(windowHandle windows.Handle, rect *[4]int32, erase bool) err
 	return nil
 }
 
+// GetClientRect wraps the Win32 GetClientRect function.
+// https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getclientrect
+//
+// Parameters:
+//   - windowHandle: Handle to the window whose client area is measured
+//
+// Returns:
+//   - int32: The width of the client area
+//   - int32: The height of the client area
+func GetClientRect(windowHandle windows.Handle) (int32, int32) {
+	var rect [4]int32
+	ret, _, _ := procGetClientRect.Call(uintptr(windowHandle), uintptr(unsafe.Pointer(&rect)))
+	if ret == 0 {
+		return 0, 0
+	}
+	return rect[2] - rect[0], rect[3] - rect[1]
+}
+
 // SetCursor wraps the Win32 SetCursor function
 // https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-setcursor
 //
@@ -534,87 +552,6 @@ func caretPosFromClick(hdc uintptr, font windows.Handle, text string, inputX, cl
 	return int32(low)
 }
 
-// SetClipboardText sets the clipboard text to the specified string.
-// It uses the OpenClipboard, EmptyClipboard, GlobalAlloc, GlobalLock, GlobalUnlock,
-// SetClipboardData, and CloseClipboard functions to manage the clipboard data.
-// It allocates memory for the text in UTF-16 format and copies it to the clipboard.
-// It also handles the necessary cleanup of resources.
-//
-// Parameters:
-//   - text: The text string to be set in the clipboard
-func setClipboardText(text string) {
-	r, _, _ := procOpenClipboard.Call(0)
-	if r == 0 {
-		return
-	}
-	defer func() {
-		_, _, _ = procCloseClipboard.Call()
-	}()
-
-	_, _, _ = procEmptyClipboard.Call()
-
-	utf16, _ := windows.UTF16FromString(text)
-	size := len(utf16) * 2
-
-	hMem, _, _ := procGlobalAlloc.Call(GMEM_MOVEABLE, uintptr(size))
-	if hMem == 0 {
-		return
-	}
-	ptr, _, _ := procGlobalLock.Call(hMem)
-	if ptr == 0 {
-		return
-	}
-	for i, v := range utf16 {
-		*(*uint16)(unsafe.Pointer(ptr + uintptr(i*2))) = v
-	}
-	_, _, _ = procGlobalUnlock.Call(hMem)
-	_, _, _ = procSetClipboardData.Call(CF_UNICODETEXT, hMem)
-}
-
-// GetClipboardText retrieves the text from the clipboard.
-// It uses the OpenClipboard, CloseClipboard, GetClipboardData, GlobalLock,
-// GlobalUnlock, and GlobalFree functions to manage the clipboard data.
-// It allocates memory for the text in UTF-16 format and copies it to a string.
-// It also handles the necessary cleanup of resources.
-//
-// Returns:
-//   - string: The text string retrieved from the clipboard
-func getClipboardText() string {
-	r, _, _ := procOpenClipboard.Call(0)
-	if r == 0 {
-		return ""
-	}
-	defer func() {
-		_, _, _ = procCloseClipboard.Call()
-	}()
-
-	hMem, _, _ := procGetClipboardData.Call(CF_UNICODETEXT)
-	if hMem == 0 {
-		return ""
-	}
-	ptr, _, _ := procGlobalLock.Call(hMem)
-	if ptr == 0 {
-		return ""
-	}
-	defer func() {
-		_, _, _ = procGlobalUnlock.Call(hMem)
-	}()
-
-	var length int
-	for {
-		c := *(*uint16)(unsafe.Pointer(ptr + uintptr(length*2)))
-		if c == 0 {
-			break
-		}
-		length++
-	}
-	buf := make([]uint16, length)
-	for i := 0; i < length; i++ {
-		buf[i] = *(*uint16)(unsafe.Pointer(ptr + uintptr(i*2)))
-	}
-	return windows.UTF16ToString(buf)
-}
-
 // CreateCompatibleDC creates a memory device context compatible with the specified device.
 //
 // Parameters:
@@ -941,3 +878,19 @@ func ScreenToClient(hwnd windows.Handle, pt *Point) bool {
 	ret, _, _ := procScreenToClient.Call(uintptr(hwnd), uintptr(unsafe.Pointer(pt)))
 	return ret != 0
 }
+
+// ClientToScreen converts client-area coordinates of the given window to screen coordinates.
+// It is a wrapper around the Windows API ClientToScreen function.
+// https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-clienttoscreen
+//
+// Parameters:
+//   - hwnd: Handle to the window whose client area is used for conversion.
+//   - pt: Pointer to a Point struct containing the client-area coordinates.
+//     On return, the struct is updated with screen coordinates.
+//
+// Returns:
+//   - bool: true if the function succeeds, false otherwise.
+func ClientToScreen(hwnd windows.Handle, pt *Point) bool {
+	ret, _, _ := procClientToScreen.Call(uintptr(hwnd), uintptr(unsafe.Pointer(pt)))
+	return ret != 0
+}
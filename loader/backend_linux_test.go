@@ -0,0 +1,22 @@
+//go:build linux
+// +build linux
+
+package loader
+
+import (
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/internal/linux"
+)
+
+// buttonEvents returns the dispatcher the backend publishes the clicks of the button with the ID on.
+func buttonEvents(id uintptr) *event.Dispatcher {
+	return linux.GetButtonEvents(id)
+}
+
+// textInputEvents returns the dispatcher of the text input state the backend edits for the ID.
+func textInputEvents(id uintptr) *event.Dispatcher {
+	if state := linux.GetTextInputState(id); state != nil {
+		return state.Events
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build windows
+// +build windows
+
+package loader
+
+import (
+	"github.com/Carmen-Shannon/gooey/event"
+	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
+)
+
+// buttonEvents returns the dispatcher the backend publishes the clicks of the button with the ID on.
+func buttonEvents(id uintptr) *event.Dispatcher {
+	return wdws.GetButtonEvents(id)
+}
+
+// textInputEvents returns the dispatcher of the text input state the backend edits for the ID.
+func textInputEvents(id uintptr) *event.Dispatcher {
+	if state := wdws.GetTextInputState(id); state != nil {
+		return state.Events
+	}
+	return nil
+}
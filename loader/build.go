@@ -0,0 +1,577 @@
+package loader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/component"
+)
+
+// builder turns the nodes of a document into components.
+type builder struct {
+	doc      *Document
+	handlers Handlers
+	nextID   uintptr
+	named    map[string]component.Component
+}
+
+func newBuilder(doc *Document, handlers Handlers) *builder {
+	b := &builder{
+		doc:      doc,
+		handlers: handlers,
+		named:    make(map[string]component.Component),
+	}
+	for _, n := range doc.Components {
+		walkNodes(n, func(n *Node) {
+			b.nextID = max(b.nextID, n.ID)
+		})
+	}
+	return b
+}
+
+// walkNodes calls fn for n and every node below it.
+func walkNodes(n *Node, fn func(*Node)) {
+	if n == nil {
+		return
+	}
+	fn(n)
+	for _, child := range n.Children {
+		walkNodes(child, fn)
+	}
+	walkNodes(n.Content, fn)
+}
+
+// build creates the components of the document's top-level nodes.
+//
+// Returns:
+//   - []component.Component: The top-level components in document order.
+//   - error: An error describing the first invalid node, or nil if it succeeds.
+func (b *builder) build() ([]component.Component, error) {
+	components := make([]component.Component, 0, len(b.doc.Components))
+	for i, n := range b.doc.Components {
+		c, err := b.node(n, fmt.Sprintf("components[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, c)
+	}
+	return components, nil
+}
+
+// node creates the component of a node and its children.
+//
+// Parameters:
+//   - n: The node to build.
+//   - path: The location of the node in the document, used in errors.
+//
+// Returns:
+//   - component.Component: The created component.
+//   - error: An error describing the first invalid node, or nil if it succeeds.
+func (b *builder) node(n *Node, path string) (component.Component, error) {
+	if n == nil {
+		return nil, fmt.Errorf("loader: %s: empty node", path)
+	}
+	if n.Name != "" {
+		path = fmt.Sprintf("%s (%s)", path, n.Name)
+	}
+	c, err := b.create(n, path)
+	if err != nil {
+		return nil, fmt.Errorf("loader: %s: %w", path, err)
+	}
+	if err := bindHandlers(c.Events(), n, b.handlers); err != nil {
+		return nil, fmt.Errorf("loader: %s: %w", path, err)
+	}
+	if n.Name != "" {
+		if _, ok := b.named[n.Name]; ok {
+			return nil, fmt.Errorf("loader: %s: duplicate name %q", path, n.Name)
+		}
+		b.named[n.Name] = c
+	}
+	return c, nil
+}
+
+// create creates the component of a node according to its type.
+func (b *builder) create(n *Node, path string) (component.Component, error) {
+	s, err := b.style(n)
+	if err != nil {
+		return nil, err
+	}
+	cOpts, err := b.componentOptions(n, s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Type {
+	case "label":
+		opts := []component.CreateLabelOption{component.LabelComponentOptionsOpt(cOpts...)}
+		if n.Text != nil {
+			opts = append(opts, component.LabelTextOpt(*n.Text))
+		}
+		if s.Font != nil {
+			opts = append(opts, component.LabelFontOpt(*s.Font))
+		}
+		if s.FontSize != nil {
+			opts = append(opts, component.LabelTextSizeOpt(*s.FontSize))
+		}
+		if s.Color != nil {
+			opts = append(opts, component.LabelColorOpt(s.Color.common()))
+		}
+		if s.TextAlign != nil {
+			align, err := parseTextAlignment(*s.TextAlign)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, component.LabelTextAlignmentOpt(align))
+		}
+		if n.WordWrap != nil {
+			opts = append(opts, component.LabelWordWrapOpt(*n.WordWrap))
+		}
+		return component.NewLabel(opts...), nil
+
+	case "button":
+		opts := []component.CreateButtonOption{component.ButtonComponentOptionsOpt(cOpts...)}
+		if n.Text != nil {
+			opts = append(opts, component.ButtonLabelOpt(*n.Text))
+		}
+		if s.Font != nil {
+			opts = append(opts, component.ButtonLabelFontOpt(*s.Font))
+		}
+		if s.FontSize != nil {
+			opts = append(opts, component.ButtonLabelSizeOpt(*s.FontSize))
+		}
+		if s.Color != nil {
+			opts = append(opts, component.ButtonLabelColorOpt(s.Color.common()))
+		}
+		if s.Background != nil {
+			opts = append(opts, component.ButtonBackgroundColorOpt(s.Background.common()))
+		}
+		if s.HoverBackground != nil {
+			opts = append(opts, component.ButtonBackgroundColorHoverOpt(s.HoverBackground.common()))
+		}
+		if s.PressedBackground != nil {
+			opts = append(opts, component.ButtonBackgroundColorPressedOpt(s.PressedBackground.common()))
+		}
+		if s.DisabledBackground != nil {
+			opts = append(opts, component.ButtonBackgroundColorDisabledOpt(s.DisabledBackground.common()))
+		}
+		if s.Roundness != nil {
+			opts = append(opts, component.ButtonRoundnessOpt(*s.Roundness))
+		}
+		return component.NewButton(opts...), nil
+
+	case "textInput":
+		opts := []component.CreateTextInputOption{component.TextInputComponentOptionsOpt(cOpts...)}
+		if n.Text != nil {
+			opts = append(opts, component.TextInputValueOpt(*n.Text))
+		}
+		if n.MaxLength != nil {
+			opts = append(opts, component.TextInputMaxLengthOpt(*n.MaxLength))
+		}
+		if s.FontSize != nil {
+			opts = append(opts, component.TextInputTextSizeOpt(*s.FontSize))
+		}
+		if s.Color != nil {
+			opts = append(opts, component.TextInputTextColorOpt(s.Color.common()))
+		}
+		if s.Background != nil {
+			opts = append(opts, component.TextInputColorOpt(s.Background.common()))
+		}
+		if s.TextAlign != nil {
+			align, err := parseTextAlignment(*s.TextAlign)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, component.TextInputTextAlignmentOpt(align))
+		}
+		if n.Password != nil && *n.Password {
+			opts = append(opts, component.TextInputPasswordOpt(0))
+		}
+		if n.Placeholder != nil {
+			opts = append(opts, component.TextInputPlaceholderOpt(*n.Placeholder))
+		}
+		if n.Filter != nil {
+			filter, err := parseTextFilter(*n.Filter)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, component.TextInputFilterOpt(filter))
+		}
+		return component.NewTextInput(opts...), nil
+
+	case "textArea":
+		opts := []component.CreateTextAreaOption{component.TextAreaComponentOptionsOpt(cOpts...)}
+		if n.Text != nil {
+			opts = append(opts, component.TextAreaValueOpt(*n.Text))
+		}
+		if n.MaxLength != nil {
+			opts = append(opts, component.TextAreaMaxLengthOpt(*n.MaxLength))
+		}
+		if n.MaxLines != nil {
+			opts = append(opts, component.TextAreaMaxLinesOpt(*n.MaxLines))
+		}
+		if n.WordWrap != nil {
+			opts = append(opts, component.TextAreaWrapOpt(*n.WordWrap))
+		}
+		if n.Placeholder != nil {
+			opts = append(opts, component.TextAreaPlaceholderOpt(*n.Placeholder))
+		}
+		if s.Font != nil {
+			opts = append(opts, component.TextAreaFontOpt(*s.Font))
+		}
+		if s.FontSize != nil {
+			opts = append(opts, component.TextAreaTextSizeOpt(*s.FontSize))
+		}
+		if s.Color != nil {
+			opts = append(opts, component.TextAreaTextColorOpt(s.Color.common()))
+		}
+		if s.Background != nil {
+			opts = append(opts, component.TextAreaColorOpt(s.Background.common()))
+		}
+		return component.NewTextArea(opts...), nil
+
+	case "vbox", "hbox":
+		opts, err := layoutOptions(n, s, cOpts)
+		if err != nil {
+			return nil, err
+		}
+		box := component.NewHBox(opts...)
+		if n.Type == "vbox" {
+			box = component.NewVBox(opts...)
+		}
+		for i, child := range n.Children {
+			c, childOpts, err := b.child(child, fmt.Sprintf("%s.children[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			box.AddChild(c, childOpts...)
+		}
+		return box, nil
+
+	case "grid":
+		opts, err := layoutOptions(n, s, cOpts)
+		if err != nil {
+			return nil, err
+		}
+		grid := component.NewGrid(opts...)
+		for row, stretch := range n.RowStretch {
+			grid.SetRowStretch(int32(row), stretch)
+		}
+		for col, stretch := range n.ColumnStretch {
+			grid.SetColumnStretch(int32(col), stretch)
+		}
+		for i, child := range n.Children {
+			c, childOpts, err := b.child(child, fmt.Sprintf("%s.children[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			var row, col int32
+			if child.Layout != nil {
+				row, col = child.Layout.Row, child.Layout.Col
+			}
+			grid.AddChild(c, row, col, childOpts...)
+		}
+		return grid, nil
+
+	case "scrollView":
+		opts := []component.CreateScrollViewOption{component.ScrollViewComponentOptionsOpt(cOpts...)}
+		if n.Content != nil {
+			content, err := b.node(n.Content, path+".content")
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, component.ScrollViewContentOpt(content))
+		}
+		if n.Scrollbars != nil {
+			h, v, err := parseScrollbars(*n.Scrollbars)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, component.ScrollViewScrollbarPolicyOpt(h, v))
+		}
+		if n.ScrollStep != nil {
+			opts = append(opts, component.ScrollViewScrollStepOpt(*n.ScrollStep))
+		}
+		if n.ScrollbarSize != nil {
+			opts = append(opts, component.ScrollViewScrollbarSizeOpt(*n.ScrollbarSize))
+		}
+		if s.Background != nil {
+			opts = append(opts, component.ScrollViewBackgroundColorOpt(s.Background.common()))
+		}
+		return component.NewScrollView(opts...), nil
+	}
+	return nil, fmt.Errorf("unknown component type %q", n.Type)
+}
+
+// child creates a child of a layout along with the options placing it.
+func (b *builder) child(n *Node, path string) (component.Component, []component.LayoutChildOption, error) {
+	c, err := b.node(n, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n.Layout == nil {
+		return c, nil, nil
+	}
+
+	var opts []component.LayoutChildOption
+	if n.Layout.Stretch != nil {
+		opts = append(opts, component.LayoutStretchOpt(*n.Layout.Stretch))
+	}
+	if n.Layout.HAlign != nil || n.Layout.VAlign != nil {
+		h, v := component.LayoutAlignFill, component.LayoutAlignFill
+		if n.Layout.HAlign != nil {
+			if h, err = parseLayoutAlignment(*n.Layout.HAlign); err != nil {
+				return nil, nil, fmt.Errorf("loader: %s: %w", path, err)
+			}
+		}
+		if n.Layout.VAlign != nil {
+			if v, err = parseLayoutAlignment(*n.Layout.VAlign); err != nil {
+				return nil, nil, fmt.Errorf("loader: %s: %w", path, err)
+			}
+		}
+		opts = append(opts, component.LayoutAlignOpt(h, v))
+	}
+	if n.Layout.RowSpan > 0 || n.Layout.ColSpan > 0 {
+		opts = append(opts, component.LayoutSpanOpt(max(n.Layout.RowSpan, 1), max(n.Layout.ColSpan, 1)))
+	}
+	return c, opts, nil
+}
+
+// style merges the named styles of a node and its own properties, later ones taking precedence.
+func (b *builder) style(n *Node) (Style, error) {
+	var s Style
+	for _, name := range strings.Fields(n.StyleNames) {
+		named, ok := b.doc.Styles[name]
+		if !ok {
+			return Style{}, fmt.Errorf("unknown style %q", name)
+		}
+		s.merge(named)
+	}
+	s.merge(n.Style)
+	return s, nil
+}
+
+// merge copies the properties set in o over s.
+func (s *Style) merge(o Style) {
+	s.Size = or(o.Size, s.Size)
+	s.Anchor = or(o.Anchor, s.Anchor)
+	s.Font = or(o.Font, s.Font)
+	s.FontSize = or(o.FontSize, s.FontSize)
+	s.Color = or(o.Color, s.Color)
+	s.Background = or(o.Background, s.Background)
+	s.HoverBackground = or(o.HoverBackground, s.HoverBackground)
+	s.PressedBackground = or(o.PressedBackground, s.PressedBackground)
+	s.DisabledBackground = or(o.DisabledBackground, s.DisabledBackground)
+	s.Roundness = or(o.Roundness, s.Roundness)
+	s.TextAlign = or(o.TextAlign, s.TextAlign)
+	s.Spacing = or(o.Spacing, s.Spacing)
+	s.Padding = or(o.Padding, s.Padding)
+	s.Alignment = or(o.Alignment, s.Alignment)
+}
+
+// or returns v if it is set and fallback otherwise.
+func or[T any](v, fallback *T) *T {
+	if v != nil {
+		return v
+	}
+	return fallback
+}
+
+// componentOptions converts the properties shared by every component type.
+func (b *builder) componentOptions(n *Node, s Style) ([]component.CreateComponentOption, error) {
+	id := n.ID
+	if id == 0 {
+		b.nextID++
+		id = b.nextID
+	}
+	opts := []component.CreateComponentOption{
+		component.ComponentIDOpt(id),
+		component.ComponentTabIndexOpt(n.TabIndex),
+		component.ComponentTooltipOpt(n.Tooltip),
+	}
+	if s.Size != nil {
+		opts = append(opts, component.ComponentSizeOpt(s.Size[0], s.Size[1]))
+	}
+	if n.Position != nil {
+		opts = append(opts, component.ComponentPositionOpt(n.Position[0], n.Position[1]))
+	}
+	if n.MinSize != nil {
+		opts = append(opts, component.ComponentMinSizeOpt(n.MinSize[0], n.MinSize[1]))
+	}
+	if n.MaxSize != nil {
+		opts = append(opts, component.ComponentMaxSizeOpt(n.MaxSize[0], n.MaxSize[1]))
+	}
+	if n.Visible != nil {
+		opts = append(opts, component.ComponentVisibleOpt(*n.Visible))
+	}
+	if n.Enabled != nil {
+		opts = append(opts, component.ComponentEnabledOpt(*n.Enabled))
+	}
+	if n.Focusable != nil {
+		opts = append(opts, component.ComponentFocusableOpt(*n.Focusable))
+	}
+	if n.Cursor != "" {
+		cursor, err := parseCursor(n.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, component.ComponentCursorOpt(cursor))
+	}
+	if s.Anchor != nil {
+		anchor, err := parseAnchor(*s.Anchor)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, component.ComponentAnchorOpt(anchor))
+	}
+	return opts, nil
+}
+
+// layoutOptions converts the properties of box and grid layouts.
+func layoutOptions(n *Node, s Style, cOpts []component.CreateComponentOption) ([]component.CreateLayoutOption, error) {
+	opts := []component.CreateLayoutOption{component.LayoutComponentOptionsOpt(cOpts...)}
+	if s.Spacing != nil {
+		opts = append(opts, component.LayoutSpacingOpt(*s.Spacing))
+	}
+	if s.Padding != nil {
+		opts = append(opts, component.LayoutPaddingOpt(common.Insets(*s.Padding)))
+	}
+	if s.Alignment != nil {
+		align, err := parseLayoutAlignment(*s.Alignment)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, component.LayoutAlignmentOpt(align))
+	}
+	if n.FitContent != nil {
+		opts = append(opts, component.LayoutFitContentOpt(*n.FitContent))
+	}
+	return opts, nil
+}
+
+// parseAnchor parses edges joined by "|", such as "left|top", or one of "none" and "fill".
+func parseAnchor(s string) (component.Anchor, error) {
+	anchor := component.AnchorNone
+	for _, edge := range strings.Split(s, "|") {
+		switch strings.TrimSpace(edge) {
+		case "none":
+		case "left":
+			anchor |= component.AnchorLeft
+		case "top":
+			anchor |= component.AnchorTop
+		case "right":
+			anchor |= component.AnchorRight
+		case "bottom":
+			anchor |= component.AnchorBottom
+		case "fill":
+			anchor |= component.AnchorFill
+		default:
+			return 0, fmt.Errorf("invalid anchor %q", s)
+		}
+	}
+	return anchor, nil
+}
+
+// parseCursor parses the name of a standard cursor such as "hand", "crosshair" or "resize-ew".
+func parseCursor(s string) (*common.Cursor, error) {
+	switch s {
+	case "arrow":
+		return common.CursorArrow, nil
+	case "ibeam", "text":
+		return common.CursorIBeam, nil
+	case "hand", "pointer":
+		return common.CursorHand, nil
+	case "crosshair":
+		return common.CursorCrosshair, nil
+	case "resize-ew":
+		return common.CursorResizeEW, nil
+	case "resize-ns":
+		return common.CursorResizeNS, nil
+	case "resize-nwse":
+		return common.CursorResizeNWSE, nil
+	case "resize-nesw":
+		return common.CursorResizeNESW, nil
+	case "move":
+		return common.CursorMove, nil
+	case "wait":
+		return common.CursorWait, nil
+	case "not-allowed":
+		return common.CursorNotAllowed, nil
+	}
+	return nil, fmt.Errorf("invalid cursor %q", s)
+}
+
+// parseTextAlignment parses one of "left", "center" and "right".
+func parseTextAlignment(s string) (component.TextAlignment, error) {
+	switch s {
+	case "left":
+		return component.LeftAlign, nil
+	case "center":
+		return component.CenterAlign, nil
+	case "right":
+		return component.RightAlign, nil
+	}
+	return 0, fmt.Errorf("invalid text alignment %q", s)
+}
+
+// parseTextFilter parses one of "digits", "decimal" and "hex", or a regular expression written as "/expr/".
+func parseTextFilter(s string) (*common.TextFilter, error) {
+	switch s {
+	case "digits":
+		return common.FilterDigits, nil
+	case "decimal":
+		return common.FilterDecimal, nil
+	case "hex":
+		return common.FilterHex, nil
+	}
+	if len(s) >= 2 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/") {
+		re, err := regexp.Compile(s[1 : len(s)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+		}
+		return common.RegexpFilter(re), nil
+	}
+	return nil, fmt.Errorf("invalid filter %q", s)
+}
+
+// parseLayoutAlignment parses one of "fill", "start", "center" and "end".
+func parseLayoutAlignment(s string) (component.LayoutAlignment, error) {
+	switch s {
+	case "fill":
+		return component.LayoutAlignFill, nil
+	case "start":
+		return component.LayoutAlignStart, nil
+	case "center":
+		return component.LayoutAlignCenter, nil
+	case "end":
+		return component.LayoutAlignEnd, nil
+	}
+	return 0, fmt.Errorf("invalid alignment %q", s)
+}
+
+// parseScrollbars parses a scrollbar policy of "auto", "always" or "never" for both scrollbars,
+// or a horizontal and a vertical policy separated by a space.
+func parseScrollbars(s string) (component.ScrollbarPolicy, component.ScrollbarPolicy, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 1 {
+		fields = append(fields, fields[0])
+	}
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid scrollbars %q", s)
+	}
+	var policies [2]component.ScrollbarPolicy
+	for i, f := range fields {
+		switch f {
+		case "auto":
+			policies[i] = component.ScrollbarAuto
+		case "always":
+			policies[i] = component.ScrollbarAlways
+		case "never":
+			policies[i] = component.ScrollbarNever
+		default:
+			return 0, 0, fmt.Errorf("invalid scrollbars %q", s)
+		}
+	}
+	return policies[0], policies[1], nil
+}
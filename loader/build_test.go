@@ -0,0 +1,134 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// buildDoc parses and builds a YAML document.
+func buildDoc(t *testing.T, src string, handlers Handlers) (*builder, []component.Component, error) {
+	t.Helper()
+	doc, err := Parse([]byte(src), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	b := newBuilder(doc, handlers)
+	components, err := b.build()
+	return b, components, err
+}
+
+func TestBuildIDs(t *testing.T) {
+	// nodes without an ID are numbered in document order after the highest ID anywhere in the document
+	_, components, err := buildDoc(t, `
+components:
+  - type: vbox
+    children:
+      - type: label
+      - type: label
+        id: 7
+  - type: label
+    id: 5
+  - type: button
+`, nil)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	var got []uintptr
+	for _, c := range components {
+		component.Walk(c, func(c component.Component) bool {
+			got = append(got, c.ID())
+			return true
+		})
+	}
+	want := []uintptr{8, 9, 7, 5, 10}
+	if len(got) != len(want) {
+		t.Fatalf("IDs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("IDs = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBuildNamed(t *testing.T) {
+	b, components, err := buildDoc(t, `
+components:
+  - type: vbox
+    name: root
+    children:
+      - type: textInput
+        name: input
+      - type: label
+`, nil)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if len(b.named) != 2 || b.named["root"] != components[0] {
+		t.Fatalf("named = %v, want root and input", b.named)
+	}
+	if _, ok := b.named["input"].(component.TextInput); !ok {
+		t.Errorf("named input is %T, want a TextInput", b.named["input"])
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	handlers := Handlers{
+		"save":    func() {},
+		"changed": func(event.ValueChangedEvent[string]) {},
+		"count":   42,
+	}
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "unknown type", src: "components:\n  - type: slider\n", wantErr: "slider"},
+		{name: "empty node", src: "components:\n  - null\n", wantErr: "empty node"},
+		{name: "duplicate name", src: "components:\n  - type: label\n    name: a\n  - type: label\n    name: a\n", wantErr: `duplicate name "a"`},
+		{name: "unknown event", src: "components:\n  - type: button\n    on: {press: save}\n", wantErr: `unknown event "press"`},
+		{name: "unknown handler", src: "components:\n  - type: button\n    on: {click: load}\n", wantErr: `unknown handler "load"`},
+		{name: "wrong signature", src: "components:\n  - type: button\n    on: {click: changed}\n", wantErr: `handler "changed" has type`},
+		{name: "not a function", src: "components:\n  - type: button\n    on: {click: count}\n", wantErr: `handler "count" has type int`},
+		{name: "error names the path", src: "components:\n  - type: vbox\n    children:\n      - type: button\n        name: ok\n        on: {click: load}\n", wantErr: "components[0].children[0] (ok)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := buildDoc(t, tt.src, handlers)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("build() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildBindsHandlers(t *testing.T) {
+	var clicks, changes []string
+	handlers := Handlers{
+		"save":    func() { clicks = append(clicks, "save") },
+		"changed": func(e event.ValueChangedEvent[string]) { changes = append(changes, e.New) },
+	}
+	b, _, err := buildDoc(t, `
+components:
+  - type: button
+    name: ok
+    on: {click: save}
+  - type: textInput
+    name: input
+    on: {change: changed}
+`, handlers)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	event.Publish(b.named["ok"].Events(), event.ClickEvent{})
+	event.Publish(b.named["input"].Events(), event.ValueChangedEvent[string]{New: "typed"})
+	if len(clicks) != 1 {
+		t.Errorf("click handler called %d times, want 1", len(clicks))
+	}
+	if len(changes) != 1 || changes[0] != "typed" {
+		t.Errorf("change handler got %v, want [typed]", changes)
+	}
+}
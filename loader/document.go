@@ -0,0 +1,222 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the syntax of a UI document.
+type Format int
+
+const (
+	// FormatAuto treats documents starting with '{' as JSON and everything else as YAML.
+	FormatAuto Format = iota
+	FormatJSON
+	FormatYAML
+)
+
+// Document is the root of a UI definition.
+type Document struct {
+	// Window configures the window created by the loader, it is ignored when loading into an existing window.
+	Window WindowSpec `json:"window"`
+	// Styles are named sets of properties that nodes refer to with their style property.
+	Styles map[string]Style `json:"styles"`
+	// Components are the top-level components of the window in drawing order.
+	Components []*Node `json:"components"`
+}
+
+// WindowSpec describes the window created for a document.
+type WindowSpec struct {
+	Title      string `json:"title"`
+	Width      int32  `json:"width"`
+	Height     int32  `json:"height"`
+	Background *Color `json:"background"`
+	// Theme is the name of a preset theme, light or dark. Unlike the rest of the window section it is applied on reload.
+	Theme string `json:"theme"`
+}
+
+// Style holds the properties that can be shared between nodes through named styles.
+// Properties that do not apply to a component type are ignored, so one style can serve several types.
+type Style struct {
+	Size               *Pair   `json:"size"`
+	Anchor             *string `json:"anchor"`
+	Font               *string `json:"font"`
+	FontSize           *int32  `json:"fontSize"`
+	Color              *Color  `json:"color"`
+	Background         *Color  `json:"background"`
+	HoverBackground    *Color  `json:"hoverBackground"`
+	PressedBackground  *Color  `json:"pressedBackground"`
+	DisabledBackground *Color  `json:"disabledBackground"`
+	Roundness          *int32  `json:"roundness"`
+	TextAlign          *string `json:"textAlign"`
+	Spacing            *int32  `json:"spacing"`
+	Padding            *Insets `json:"padding"`
+	Alignment          *string `json:"alignment"`
+}
+
+// Node describes a single component and, for containers, its children.
+type Node struct {
+	// Type is one of label, button, textInput, textArea, vbox, hbox, grid or scrollView.
+	Type string `json:"type"`
+	// ID is the component ID, nodes without one are numbered after the highest ID in the document.
+	ID uintptr `json:"id"`
+	// Name makes the component available through UI.ByName, names must be unique within a document.
+	Name string `json:"name"`
+	// Style lists the names of the styles applied to the node, separated by spaces. Later styles and the node's own
+	// properties take precedence.
+	StyleNames string `json:"style"`
+	Style
+
+	Position    *Pair   `json:"position"`
+	MinSize     *Pair   `json:"minSize"`
+	MaxSize     *Pair   `json:"maxSize"`
+	Visible     *bool   `json:"visible"`
+	Enabled     *bool   `json:"enabled"`
+	Focusable   *bool   `json:"focusable"`
+	TabIndex    int     `json:"tabIndex"`
+	Tooltip     string  `json:"tooltip"`
+	Cursor      string  `json:"cursor"`
+	Text        *string `json:"text"`
+	MaxLength   *int32  `json:"maxLength"`
+	MaxLines    *int32  `json:"maxLines"`
+	Password    *bool   `json:"password"`
+	Placeholder *string `json:"placeholder"`
+	WordWrap    *bool   `json:"wordWrap"`
+	// Filter limits what can be typed into a textInput, one of digits, decimal or hex, or a regular expression
+	// written as "/expr/".
+	Filter *string `json:"filter"`
+
+	FitContent    *bool   `json:"fitContent"`
+	RowStretch    []int32 `json:"rowStretch"`
+	ColumnStretch []int32 `json:"columnStretch"`
+	Children      []*Node `json:"children"`
+	// Layout places the node inside of its parent layout.
+	Layout *ChildLayout `json:"layout"`
+
+	Content       *Node   `json:"content"`
+	Scrollbars    *string `json:"scrollbars"`
+	ScrollStep    *int32  `json:"scrollStep"`
+	ScrollbarSize *int32  `json:"scrollbarSize"`
+
+	// On maps event names (click, change, focus, mouseDown, mouseUp, mouseMove, mouseEnter, mouseLeave,
+	// doubleClick, wheel, scroll) to the names of handlers passed with HandlersOpt.
+	On map[string]string `json:"on"`
+}
+
+// ChildLayout holds the settings a parent layout uses to place a child.
+type ChildLayout struct {
+	Stretch *int32  `json:"stretch"`
+	HAlign  *string `json:"hAlign"`
+	VAlign  *string `json:"vAlign"`
+	Row     int32   `json:"row"`
+	Col     int32   `json:"col"`
+	RowSpan int32   `json:"rowSpan"`
+	ColSpan int32   `json:"colSpan"`
+}
+
+// Pair is a width and height or an x and y coordinate, written as a two element list.
+type Pair [2]int32
+
+// Color is a common.Color written as "#rgb", "#rrggbb" or a list of three channels.
+type Color common.Color
+
+// UnmarshalJSON decodes a color from a hex string or a list of channels.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var channels [3]uint8
+	if err := json.Unmarshal(data, &channels); err == nil {
+		*c = Color{Red: channels[0], Green: channels[1], Blue: channels[2]}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("color must be a hex string or a list of three channels, got %s", data)
+	}
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return fmt.Errorf("invalid color %q", s)
+	}
+	*c = Color{Red: uint8(v >> 16), Green: uint8(v >> 8), Blue: uint8(v)}
+	return nil
+}
+
+// common returns the color as a *common.Color, or nil for a nil color.
+func (c *Color) common() *common.Color {
+	if c == nil {
+		return nil
+	}
+	cc := common.Color(*c)
+	return &cc
+}
+
+// Insets is a common.Insets written as a single value, a [vertical, horizontal] pair or [top, right, bottom, left].
+type Insets common.Insets
+
+// UnmarshalJSON decodes insets from a number or a list of two or four numbers.
+func (in *Insets) UnmarshalJSON(data []byte) error {
+	var v int32
+	if err := json.Unmarshal(data, &v); err == nil {
+		*in = Insets(common.UniformInsets(v))
+		return nil
+	}
+	var vs []int32
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return fmt.Errorf("insets must be a number or a list, got %s", data)
+	}
+	switch len(vs) {
+	case 2:
+		*in = Insets{Top: vs[0], Right: vs[1], Bottom: vs[0], Left: vs[1]}
+	case 4:
+		*in = Insets{Top: vs[0], Right: vs[1], Bottom: vs[2], Left: vs[3]}
+	default:
+		return fmt.Errorf("insets must have 2 or 4 values, got %d", len(vs))
+	}
+	return nil
+}
+
+// Parse decodes a UI document. YAML documents are converted to JSON first, so both formats share one schema.
+// Unknown properties are rejected to catch typos early.
+//
+// Parameters:
+//   - data: The document source.
+//   - format: The syntax of the document.
+//
+// Returns:
+//   - *Document: The decoded document.
+//   - error: An error if the document cannot be decoded, or nil if it succeeds.
+func Parse(data []byte, format Format) (*Document, error) {
+	if format == FormatAuto {
+		format = FormatYAML
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			format = FormatJSON
+		}
+	}
+	if format == FormatYAML {
+		var v any
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("loader: %w", err)
+		}
+		converted, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("loader: %w", err)
+		}
+		data = converted
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	doc := &Document{}
+	if err := dec.Decode(doc); err != nil {
+		return nil, fmt.Errorf("loader: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const (
+		jsonDoc = `{"window": {"title": "Demo", "background": "#102030"}, "components": [{"type": "label", "text": "hi"}]}`
+		yamlDoc = "window:\n  title: Demo\n  background: [16, 32, 48]\ncomponents:\n  - type: label\n    text: hi\n"
+	)
+	tests := []struct {
+		name    string
+		data    string
+		format  Format
+		wantErr string
+	}{
+		{name: "json", data: jsonDoc, format: FormatJSON},
+		{name: "yaml", data: yamlDoc, format: FormatYAML},
+		{name: "auto detects json", data: "  " + jsonDoc, format: FormatAuto},
+		{name: "auto detects yaml", data: yamlDoc, format: FormatAuto},
+		{name: "json is valid yaml", data: jsonDoc, format: FormatYAML},
+		{name: "yaml is not json", data: yamlDoc, format: FormatJSON, wantErr: "invalid character"},
+		{name: "unknown json field", data: `{"components": [{"type": "label", "colour": "#fff"}]}`, format: FormatJSON, wantErr: `unknown field "colour"`},
+		{name: "unknown yaml field", data: "components:\n  - type: label\n    txt: hi\n", format: FormatYAML, wantErr: `unknown field "txt"`},
+		{name: "unknown window field", data: "window:\n  titel: Demo\n", format: FormatAuto, wantErr: `unknown field "titel"`},
+		{name: "invalid color", data: `{"window": {"background": "#12345"}}`, format: FormatJSON, wantErr: "invalid color"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.data), tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if doc.Window.Title != "Demo" {
+				t.Errorf("window title = %q, want %q", doc.Window.Title, "Demo")
+			}
+			if bg := doc.Window.Background; bg == nil || *bg != (Color{Red: 16, Green: 32, Blue: 48}) {
+				t.Errorf("window background = %v, want 16, 32, 48", bg)
+			}
+			if len(doc.Components) != 1 || doc.Components[0].Type != "label" || doc.Components[0].Text == nil || *doc.Components[0].Text != "hi" {
+				t.Errorf("components = %+v, want a single label with the text hi", doc.Components)
+			}
+		})
+	}
+}
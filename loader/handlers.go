@@ -0,0 +1,72 @@
+package loader
+
+import (
+	"fmt"
+
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// Handlers maps the handler names used in a document to Go functions.
+// A handler is either a func() or a func taking the event type of the event it is bound to,
+// for example func(event.ClickEvent) for click or func(event.ValueChangedEvent[string]) for change.
+type Handlers map[string]any
+
+// subscribers holds for each event name of a document the function subscribing a handler to it.
+var subscribers = map[string]func(d *event.Dispatcher, handler any) (event.Handle, bool){
+	"click":       subscribe[event.ClickEvent],
+	"change":      subscribe[event.ValueChangedEvent[string]],
+	"focus":       subscribe[event.FocusEvent],
+	"mouseDown":   subscribe[event.MouseDownEvent],
+	"mouseUp":     subscribe[event.MouseUpEvent],
+	"mouseMove":   subscribe[event.MouseMoveEvent],
+	"mouseEnter":  subscribe[event.MouseEnterEvent],
+	"mouseLeave":  subscribe[event.MouseLeaveEvent],
+	"doubleClick": subscribe[event.DoubleClickEvent],
+	"wheel":       subscribe[event.WheelEvent],
+	"scroll":      subscribe[event.ScrollEvent],
+}
+
+// subscribe subscribes handler to events of type T if it has one of the accepted signatures.
+//
+// Parameters:
+//   - d: The dispatcher of the component.
+//   - handler: The handler to subscribe.
+//
+// Returns:
+//   - event.Handle: The handle of the subscription.
+//   - bool: False if the handler has an unsupported signature.
+func subscribe[T any](d *event.Dispatcher, handler any) (event.Handle, bool) {
+	switch fn := handler.(type) {
+	case func():
+		return event.Subscribe(d, func(T) { fn() }), true
+	case func(T):
+		return event.Subscribe(d, fn), true
+	}
+	return event.Handle{}, false
+}
+
+// bindHandlers subscribes the handlers named in a node's on property.
+//
+// Parameters:
+//   - d: The dispatcher of the node's component.
+//   - n: The node.
+//   - handlers: The handlers by name.
+//
+// Returns:
+//   - error: An error if an event or handler is unknown or a handler has the wrong signature, or nil if it succeeds.
+func bindHandlers(d *event.Dispatcher, n *Node, handlers Handlers) error {
+	for evt, name := range n.On {
+		sub, ok := subscribers[evt]
+		if !ok {
+			return fmt.Errorf("unknown event %q", evt)
+		}
+		handler, ok := handlers[name]
+		if !ok {
+			return fmt.Errorf("unknown handler %q for event %q", name, evt)
+		}
+		if _, ok := sub(d, handler); !ok {
+			return fmt.Errorf("handler %q has type %T which cannot handle event %q", name, handler, evt)
+		}
+	}
+	return nil
+}
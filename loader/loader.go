@@ -0,0 +1,273 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/theme"
+	"github.com/Carmen-Shannon/gooey/window"
+)
+
+// UI is a window and the component tree built from a document.
+// Like the components it holds, a UI belongs to the window's UI thread.
+type UI struct {
+	window     window.Window
+	components []component.Component
+	named      map[string]component.Component
+	opts       *loadOptions
+	// read returns the current source of the document, it is re-read on every reload.
+	read func() ([]byte, error)
+	path string
+}
+
+// Load builds a UI from a JSON or YAML document.
+// Unless WindowOpt is given, a window is created from the document's window section.
+//
+// Parameters:
+//   - data: The document source.
+//   - options: A variadic list of LoadOption functions to customize loading.
+//
+// Returns:
+//   - *UI: The loaded UI.
+//   - error: An error if the document is invalid, or nil if it succeeds.
+func Load(data []byte, options ...LoadOption) (*UI, error) {
+	return load(func() ([]byte, error) { return data, nil }, "", options...)
+}
+
+// LoadFile builds a UI from a JSON or YAML file. Files ending in .json are read as JSON and all others as YAML
+// unless FormatOpt says otherwise. UIs loaded from a file can be reloaded when the file changes, see Watch.
+//
+// Parameters:
+//   - path: The path of the document.
+//   - options: A variadic list of LoadOption functions to customize loading.
+//
+// Returns:
+//   - *UI: The loaded UI.
+//   - error: An error if the file cannot be read or the document is invalid, or nil if it succeeds.
+func LoadFile(path string, options ...LoadOption) (*UI, error) {
+	format := FormatYAML
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = FormatJSON
+	}
+	return load(func() ([]byte, error) { return os.ReadFile(path) }, path, append([]LoadOption{FormatOpt(format)}, options...)...)
+}
+
+func load(read func() ([]byte, error), path string, options ...LoadOption) (*UI, error) {
+	opts := newLoadOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	ui := &UI{opts: opts, read: read, path: path}
+	doc, components, named, err := ui.build()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := presetTheme(doc.Window.Theme)
+	if err != nil {
+		return nil, err
+	}
+	ui.window = opts.Window
+	if ui.window == nil {
+		ui.window = window.NewWindow(windowOptions(doc.Window, t)...)
+	} else if t != nil {
+		ui.window.SetTheme(t)
+	}
+	ui.attach(components, named)
+	return ui, nil
+}
+
+// presetTheme returns the preset theme with the given name.
+//
+// Parameters:
+//   - name: The name of the theme, or an empty string for none.
+//
+// Returns:
+//   - *theme.Theme: The theme, or nil if name is empty.
+//   - error: An error if there is no preset with the name, or nil if it succeeds.
+func presetTheme(name string) (*theme.Theme, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "light":
+		return theme.Light(), nil
+	case "dark":
+		return theme.Dark(), nil
+	}
+	return nil, fmt.Errorf("loader: unknown theme %q", name)
+}
+
+// windowOptions converts the window section of a document.
+func windowOptions(spec WindowSpec, t *theme.Theme) []window.NewWindowOption {
+	var opts []window.NewWindowOption
+	if t != nil {
+		opts = append(opts, window.ThemeOpt(t))
+	}
+	if spec.Title != "" {
+		opts = append(opts, window.TitleOpt(spec.Title))
+	}
+	if spec.Width > 0 {
+		opts = append(opts, window.WidthOpt(spec.Width))
+	}
+	if spec.Height > 0 {
+		opts = append(opts, window.HeightOpt(spec.Height))
+	}
+	if spec.Background != nil {
+		opts = append(opts, window.BackgroundColorOpt((*common.Color)(spec.Background)))
+	}
+	return opts
+}
+
+// Window returns the window the UI's components were added to.
+//
+// Returns:
+//   - window.Window: The window of the UI.
+func (ui *UI) Window() window.Window {
+	return ui.window
+}
+
+// Components returns the top-level components built from the document.
+//
+// Returns:
+//   - []component.Component: The top-level components in document order.
+func (ui *UI) Components() []component.Component {
+	return ui.components
+}
+
+// ByName returns the component built from the node with the given name.
+// The components are replaced on reload, so look them up again afterwards instead of keeping them.
+//
+// Parameters:
+//   - name: The name of the node.
+//
+// Returns:
+//   - component.Component: The component, or nil if no node has the name.
+func (ui *UI) ByName(name string) component.Component {
+	return ui.named[name]
+}
+
+// Reload reads the document again and replaces the UI's components with the newly built ones.
+// If the document is invalid the current components stay in place and the error is returned. The old components are
+// removed from the window together with what the backend tracked for them, so none of them is left clickable.
+// The window section is not applied again, apart from its theme. Reload must be called on the UI thread, use Invoke from other goroutines.
+//
+// Returns:
+//   - error: An error if the document cannot be read or is invalid, or nil if it succeeds.
+func (ui *UI) Reload() error {
+	doc, components, named, err := ui.build()
+	var t *theme.Theme
+	if err == nil {
+		t, err = presetTheme(doc.Window.Theme)
+	}
+	if err != nil {
+		// building registered the new components with the backend under the IDs of the current ones, take them back
+		for _, c := range ui.components {
+			component.Register(c)
+		}
+		return err
+	}
+
+	if t != nil && t.Name != ui.window.Theme().Name {
+		ui.window.SetTheme(t)
+	}
+	// removing the old components drops the registrations of their IDs, which the new components share until adding
+	// them to the window registers them again
+	for _, c := range ui.components {
+		ui.window.RemoveComponent(c.ID())
+	}
+	ui.attach(components, named)
+	if ui.opts.OnReload != nil {
+		ui.opts.OnReload(ui)
+	}
+	return nil
+}
+
+// Watch reloads the UI on the window's event loop whenever its file changes, checking the modification time every interval.
+// It is meant for fast iteration while developing a screen. UIs not loaded with LoadFile cannot be watched.
+// The watch stops by itself when the window is closed.
+//
+// Parameters:
+//   - interval: The time between checks of the file.
+//   - onError: The function called on the UI thread with errors of failed reloads, or nil to ignore them.
+//
+// Returns:
+//   - func(): The function stopping the watch.
+//   - error: An error if the UI was not loaded from a file or the file cannot be read, or nil if it succeeds.
+func (ui *UI) Watch(interval time.Duration, onError func(error)) (func(), error) {
+	if ui.path == "" {
+		return nil, fmt.Errorf("loader: only UIs loaded from a file can be watched")
+	}
+	info, err := os.Stat(ui.path)
+	if err != nil {
+		return nil, fmt.Errorf("loader: %w", err)
+	}
+
+	stop := make(chan struct{})
+	go func(modTime time.Time) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ui.window.Done():
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(ui.path)
+			if err != nil || info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			ui.window.Invoke(func() {
+				select {
+				case <-ui.window.Done():
+					return // the window closed in the meantime and fn runs on the watcher instead of the UI thread
+				default:
+				}
+				if err := ui.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			})
+		}
+	}(info.ModTime())
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}, nil
+}
+
+// build reads and builds the document.
+func (ui *UI) build() (*Document, []component.Component, map[string]component.Component, error) {
+	data, err := ui.read()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("loader: %w", err)
+	}
+	doc, err := Parse(data, ui.opts.Format)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	b := newBuilder(doc, ui.opts.Handlers)
+	components, err := b.build()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return doc, components, b.named, nil
+}
+
+// attach adds freshly built components to the window.
+func (ui *UI) attach(components []component.Component, named map[string]component.Component) {
+	ui.components = components
+	ui.named = named
+	for _, c := range components {
+		ui.window.AddComponent(c)
+	}
+}
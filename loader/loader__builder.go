@@ -0,0 +1,74 @@
+package loader
+
+import "github.com/Carmen-Shannon/gooey/window"
+
+type loadOptions struct {
+	Handlers Handlers
+	Window   window.Window
+	Format   Format
+	OnReload func(ui *UI)
+}
+
+type LoadOption func(*loadOptions)
+
+func newLoadOptions() *loadOptions {
+	return &loadOptions{
+		Handlers: nil,
+		Window:   nil,
+		Format:   FormatAuto,
+		OnReload: nil,
+	}
+}
+
+// HandlersOpt sets the handlers the event names of a document are resolved against.
+//
+// Parameters:
+//   - handlers: The handlers by name.
+//
+// Returns:
+//   - LoadOption: A function that takes a pointer to loadOptions and sets the Handlers field.
+func HandlersOpt(handlers Handlers) LoadOption {
+	return func(opts *loadOptions) {
+		opts.Handlers = handlers
+	}
+}
+
+// WindowOpt loads the document into an existing window instead of creating one from the document's window section.
+//
+// Parameters:
+//   - w: The window to add the components to.
+//
+// Returns:
+//   - LoadOption: A function that takes a pointer to loadOptions and sets the Window field.
+func WindowOpt(w window.Window) LoadOption {
+	return func(opts *loadOptions) {
+		opts.Window = w
+	}
+}
+
+// FormatOpt sets the syntax of the document. By default files are detected by their extension and other sources by their content.
+//
+// Parameters:
+//   - format: The syntax of the document.
+//
+// Returns:
+//   - LoadOption: A function that takes a pointer to loadOptions and sets the Format field.
+func FormatOpt(format Format) LoadOption {
+	return func(opts *loadOptions) {
+		opts.Format = format
+	}
+}
+
+// OnReloadOpt sets a function called on the UI thread after each successful reload,
+// for example to bind observables to the newly created components.
+//
+// Parameters:
+//   - onReload: The function called with the reloaded UI.
+//
+// Returns:
+//   - LoadOption: A function that takes a pointer to loadOptions and sets the OnReload field.
+func OnReloadOpt(onReload func(ui *UI)) LoadOption {
+	return func(opts *loadOptions) {
+		opts.OnReload = onReload
+	}
+}
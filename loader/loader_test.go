@@ -0,0 +1,262 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/theme"
+	"github.com/Carmen-Shannon/gooey/window"
+)
+
+// fakeWindow keeps the components of a UI and registers them with the backend the way a window does, without a display.
+type fakeWindow struct {
+	window.Window
+	// mu stands in for the UI thread, invoked functions run one at a time
+	mu         sync.Mutex
+	components []component.Component
+	done       chan struct{}
+	// closeOnInvoke closes the window when a function is invoked, as if it was closed while the function was queued
+	closeOnInvoke bool
+}
+
+func newFakeWindow() *fakeWindow {
+	return &fakeWindow{done: make(chan struct{})}
+}
+
+func (w *fakeWindow) AddComponent(c component.Component) {
+	w.components = append(w.components, c)
+	component.Register(c)
+}
+
+func (w *fakeWindow) RemoveComponent(id uintptr) {
+	i := slices.IndexFunc(w.components, func(c component.Component) bool { return c.ID() == id })
+	if i < 0 {
+		return
+	}
+	component.Unregister(w.components[i])
+	w.components = slices.Delete(w.components, i, i+1)
+}
+
+func (w *fakeWindow) Theme() *theme.Theme {
+	return theme.Light()
+}
+
+func (w *fakeWindow) SetTheme(*theme.Theme) {}
+
+func (w *fakeWindow) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *fakeWindow) Invoke(fn func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closeOnInvoke {
+		w.closeOnInvoke = false
+		close(w.done)
+	}
+	fn()
+}
+
+const (
+	reloadDoc = `
+components:
+  - type: button
+    name: ok
+    text: OK
+  - type: textInput
+    name: input
+  - type: button
+    name: cancel
+`
+	reloadedDoc = `
+components:
+  - type: button
+    name: ok
+    text: Done
+  - type: textInput
+    name: input
+`
+	// invalidDoc builds its first components before failing on the handler
+	invalidDoc = `
+components:
+  - type: button
+    name: ok
+  - type: textInput
+    name: input
+    on: {change: missing}
+`
+)
+
+// writeDoc writes a document and moves its modification time forward, so a watch notices the change right away.
+func writeDoc(t *testing.T, path, src string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(time.Duration(len(src)) * time.Second)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// loadDoc loads a document from a temporary file into a fake window.
+func loadDoc(t *testing.T, src string, options ...LoadOption) (*UI, *fakeWindow, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ui.yaml")
+	writeDoc(t, path, src)
+	w := newFakeWindow()
+	ui, err := LoadFile(path, append([]LoadOption{WindowOpt(w)}, options...)...)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	return ui, w, path
+}
+
+// assertRegistered fails unless the backend resolves the IDs of the UI's ok button and input to the UI's components.
+func assertRegistered(t *testing.T, ui *UI) {
+	t.Helper()
+	ok, input := ui.ByName("ok"), ui.ByName("input")
+	if got := buttonEvents(ok.ID()); got != ok.Events() {
+		t.Errorf("backend resolves button %d to %p, want the UI's button %p", ok.ID(), got, ok.Events())
+	}
+	if got := textInputEvents(input.ID()); got != input.Events() {
+		t.Errorf("backend resolves text input %d to %p, want the UI's text input %p", input.ID(), got, input.Events())
+	}
+}
+
+func TestReload(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		// wantErr is set for documents that fail to build, the current components must stay registered then
+		wantErr string
+	}{
+		{name: "same document", doc: reloadDoc},
+		{name: "changed document", doc: reloadedDoc},
+		{name: "invalid document", doc: invalidDoc, wantErr: `unknown handler "missing"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui, w, path := loadDoc(t, reloadDoc)
+			before := ui.ByName("ok")
+			cancel := ui.ByName("cancel").ID()
+			assertRegistered(t, ui)
+
+			writeDoc(t, path, tt.doc)
+			err := ui.Reload()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Reload() error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				if ui.ByName("ok") != before {
+					t.Errorf("failed reload replaced the components")
+				}
+				assertRegistered(t, ui)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reload() error = %v", err)
+			}
+
+			after := ui.ByName("ok")
+			if after == before || after.ID() != before.ID() {
+				t.Fatalf("reloaded button %p with ID %d, want a new button with the ID %d", after, after.ID(), before.ID())
+			}
+			// the new components share the IDs of the removed ones, removing those must not unregister the new ones
+			assertRegistered(t, ui)
+			if len(w.components) != len(ui.Components()) {
+				t.Errorf("window holds %d components, want %d", len(w.components), len(ui.Components()))
+			}
+			if ui.ByName("cancel") == nil && buttonEvents(cancel) != nil {
+				t.Errorf("button %d was removed by the reload but is still registered", cancel)
+			}
+		})
+	}
+}
+
+func TestRemovedComponentAddedBack(t *testing.T) {
+	ui, w, _ := loadDoc(t, reloadDoc)
+	ok := ui.ByName("ok")
+	w.RemoveComponent(ok.ID())
+	if buttonEvents(ok.ID()) != nil {
+		t.Fatalf("removed button is still registered")
+	}
+	w.AddComponent(ok)
+	assertRegistered(t, ui)
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name string
+		// closeWindow closes the window before the file changes
+		closeWindow bool
+		// closeOnInvoke closes the window while the reload is queued
+		closeOnInvoke bool
+		wantReload    bool
+	}{
+		{name: "reloads on change", wantReload: true},
+		{name: "stops when the window closed", closeWindow: true},
+		{name: "skips a reload queued while the window closed", closeOnInvoke: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloaded := make(chan struct{}, 1)
+			ui, w, path := loadDoc(t, reloadDoc, OnReloadOpt(func(*UI) { reloaded <- struct{}{} }))
+			w.closeOnInvoke = tt.closeOnInvoke
+			invoked := make(chan struct{})
+			stop, err := ui.Watch(time.Millisecond, func(err error) { t.Errorf("reload failed: %v", err) })
+			if err != nil {
+				t.Fatalf("Watch() error = %v", err)
+			}
+			defer stop()
+			if tt.closeWindow {
+				close(w.done)
+			}
+			if tt.closeOnInvoke {
+				go func() {
+					<-w.done
+					close(invoked)
+				}()
+			}
+
+			writeDoc(t, path, reloadedDoc)
+			wait := 100 * time.Millisecond
+			if tt.wantReload {
+				wait = 5 * time.Second
+			}
+			select {
+			case <-reloaded:
+				if !tt.wantReload {
+					t.Fatalf("watch reloaded after the window closed")
+				}
+			case <-time.After(wait):
+				if tt.wantReload {
+					t.Fatalf("watch did not reload the changed file")
+				}
+			}
+			if tt.closeOnInvoke {
+				select {
+				case <-invoked:
+				default:
+					t.Errorf("watch did not queue the reload")
+				}
+			}
+		})
+	}
+}
+
+func TestWatchRequiresFile(t *testing.T) {
+	ui, err := Load([]byte(reloadDoc), WindowOpt(newFakeWindow()))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, err := ui.Watch(time.Millisecond, nil); err == nil {
+		t.Errorf("Watch() of a UI not loaded from a file succeeded")
+	}
+}
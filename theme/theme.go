@@ -0,0 +1,226 @@
+package theme
+
+import "github.com/Carmen-Shannon/gooey/common"
+
+// State is the interaction state a component is drawn in.
+type State int
+
+const (
+	StateNormal State = iota
+	StateHover
+	StatePressed
+	StateDisabled
+	StateFocused
+	// StateError is the state of a text input whose value failed validation, it wins over StateFocused.
+	StateError
+)
+
+// Palette holds the named color roles of a theme.
+type Palette struct {
+	// Background is the color of the window behind the components.
+	Background common.Color
+	// Surface is the background of components the user types into.
+	Surface common.Color
+	// Text is the color of regular text.
+	Text common.Color
+	// TextDisabled is the color of text on disabled components.
+	TextDisabled common.Color
+	// Primary is the accent color, used for the focus ring and focused borders.
+	Primary common.Color
+	// Control is the background of buttons, ControlHover, ControlPressed and ControlDisabled its interaction states.
+	Control         common.Color
+	ControlHover    common.Color
+	ControlPressed  common.Color
+	ControlDisabled common.Color
+	// Border is the color of component outlines.
+	Border common.Color
+	// Selection is the background of selected text.
+	Selection common.Color
+	// Caret is the color of the text caret.
+	Caret common.Color
+	// Error marks text inputs whose value failed validation.
+	Error common.Color
+	// Scrollbar is the color of scrollbar tracks and Thumb the color of their thumbs.
+	Scrollbar common.Color
+	Thumb     common.Color
+}
+
+// Font is a font family and size.
+type Font struct {
+	Family string
+	Size   int32
+}
+
+// StateStyle holds the colors of a component in one interaction state. Nil colors fall back to the normal state.
+type StateStyle struct {
+	Background *common.Color
+	Foreground *common.Color
+	Border     *common.Color
+}
+
+// ComponentStyle holds the style of one component type.
+type ComponentStyle struct {
+	Font Font
+	// Roundness is the corner roundness between 0 and 100, see component.ButtonRoundnessOpt.
+	Roundness int32
+	States    map[State]StateStyle
+}
+
+// Resolve returns the colors for a state, filling the colors the state leaves unset from the normal state.
+//
+// Parameters:
+//   - state: The interaction state of the component.
+//
+// Returns:
+//   - StateStyle: The colors of the state.
+func (s ComponentStyle) Resolve(state State) StateStyle {
+	resolved := s.States[StateNormal]
+	if state == StateNormal {
+		return resolved
+	}
+	override := s.States[state]
+	if override.Background != nil {
+		resolved.Background = override.Background
+	}
+	if override.Foreground != nil {
+		resolved.Foreground = override.Foreground
+	}
+	if override.Border != nil {
+		resolved.Border = override.Border
+	}
+	return resolved
+}
+
+// Theme is the look shared by the components of a window.
+// Colors, fonts and sizes a component was not given explicitly are taken from the theme of its window when it is drawn,
+// so switching the theme restyles every such component. Themes are read on the UI thread and must not be modified
+// once they are in use, build a new one and switch to it instead.
+type Theme struct {
+	Name    string
+	Palette Palette
+	// Font is the default font of text.
+	Font Font
+	// Spacing is the default space between the children of layouts.
+	Spacing int32
+	// Roundness is the default corner roundness between 0 and 100.
+	Roundness int32
+
+	Button     ComponentStyle
+	Label      ComponentStyle
+	TextInput  ComponentStyle
+	ScrollView ComponentStyle
+	Tooltip    ComponentStyle
+}
+
+// New creates a theme whose component styles are derived from a palette.
+//
+// Parameters:
+//   - name: The name of the theme.
+//   - palette: The colors of the theme.
+//   - options: A variadic list of NewThemeOption functions to customize the theme.
+//
+// Returns:
+//   - *Theme: A pointer to the newly created theme.
+func New(name string, palette Palette, options ...NewThemeOption) *Theme {
+	opts := newNewThemeOptions()
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	t := &Theme{
+		Name:      name,
+		Palette:   palette,
+		Font:      opts.Font,
+		Spacing:   opts.Spacing,
+		Roundness: opts.Roundness,
+	}
+	// the state styles point into the theme's own palette
+	p := &t.Palette
+	t.Button = ComponentStyle{
+		Font:      opts.Font,
+		Roundness: opts.Roundness,
+		States: map[State]StateStyle{
+			StateNormal:   {Background: &p.Control, Foreground: &p.Text, Border: &p.Border},
+			StateHover:    {Background: &p.ControlHover},
+			StatePressed:  {Background: &p.ControlPressed},
+			StateDisabled: {Background: &p.ControlDisabled, Foreground: &p.TextDisabled},
+			StateFocused:  {Border: &p.Primary},
+		},
+	}
+	t.Label = ComponentStyle{
+		Font: opts.Font,
+		States: map[State]StateStyle{
+			StateNormal:   {Foreground: &p.Text},
+			StateDisabled: {Foreground: &p.TextDisabled},
+		},
+	}
+	t.TextInput = ComponentStyle{
+		Font: opts.Font,
+		States: map[State]StateStyle{
+			StateNormal:   {Background: &p.Surface, Foreground: &p.Text, Border: &p.Border},
+			StateDisabled: {Foreground: &p.TextDisabled},
+			StateFocused:  {Border: &p.Primary},
+			StateError:    {Border: &p.Error},
+		},
+	}
+	t.ScrollView = ComponentStyle{
+		States: map[State]StateStyle{
+			StateNormal: {Background: &p.Scrollbar, Foreground: &p.Thumb},
+		},
+	}
+	t.Tooltip = ComponentStyle{
+		Font: opts.Font,
+		States: map[State]StateStyle{
+			StateNormal: {Background: &p.Surface, Foreground: &p.Text, Border: &p.Border},
+		},
+	}
+	return t
+}
+
+// Light returns the light theme, which matches the look of components before themes existed. It is the default theme.
+//
+// Returns:
+//   - *Theme: A new light theme.
+func Light() *Theme {
+	return New("light", Palette{
+		Background:      common.Color{Red: 255, Green: 255, Blue: 255},
+		Surface:         common.Color{Red: 255, Green: 255, Blue: 255},
+		Text:            common.Color{Red: 0, Green: 0, Blue: 0},
+		TextDisabled:    common.Color{Red: 128, Green: 128, Blue: 128},
+		Primary:         common.Color{Red: 0, Green: 120, Blue: 215},
+		Control:         common.Color{Red: 240, Green: 240, Blue: 240},
+		ControlHover:    common.Color{Red: 200, Green: 200, Blue: 200},
+		ControlPressed:  common.Color{Red: 150, Green: 150, Blue: 150},
+		ControlDisabled: common.Color{Red: 200, Green: 200, Blue: 200},
+		Border:          common.Color{Red: 180, Green: 180, Blue: 180},
+		Selection:       common.Color{Red: 120, Green: 160, Blue: 240},
+		Caret:           common.Color{Red: 0, Green: 0, Blue: 0},
+		Error:           common.Color{Red: 200, Green: 30, Blue: 30},
+		Scrollbar:       common.Color{Red: 230, Green: 230, Blue: 230},
+		Thumb:           common.Color{Red: 160, Green: 160, Blue: 160},
+	})
+}
+
+// Dark returns the dark theme.
+//
+// Returns:
+//   - *Theme: A new dark theme.
+func Dark() *Theme {
+	return New("dark", Palette{
+		Background:      common.Color{Red: 32, Green: 32, Blue: 36},
+		Surface:         common.Color{Red: 45, Green: 45, Blue: 50},
+		Text:            common.Color{Red: 230, Green: 230, Blue: 230},
+		TextDisabled:    common.Color{Red: 120, Green: 120, Blue: 125},
+		Primary:         common.Color{Red: 76, Green: 160, Blue: 255},
+		Control:         common.Color{Red: 60, Green: 60, Blue: 66},
+		ControlHover:    common.Color{Red: 78, Green: 78, Blue: 86},
+		ControlPressed:  common.Color{Red: 96, Green: 96, Blue: 106},
+		ControlDisabled: common.Color{Red: 48, Green: 48, Blue: 52},
+		Border:          common.Color{Red: 85, Green: 85, Blue: 92},
+		Selection:       common.Color{Red: 38, Green: 79, Blue: 120},
+		Caret:           common.Color{Red: 230, Green: 230, Blue: 230},
+		Error:           common.Color{Red: 240, Green: 90, Blue: 90},
+		Scrollbar:       common.Color{Red: 40, Green: 40, Blue: 44},
+		Thumb:           common.Color{Red: 100, Green: 100, Blue: 108},
+	})
+}
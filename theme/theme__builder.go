@@ -0,0 +1,57 @@
+package theme
+
+type newThemeOptions struct {
+	Font      Font
+	Spacing   int32
+	Roundness int32
+}
+
+type NewThemeOption func(*newThemeOptions)
+
+func newNewThemeOptions() *newThemeOptions {
+	return &newThemeOptions{
+		Font:      Font{Family: "Arial", Size: 12},
+		Spacing:   4,
+		Roundness: 0,
+	}
+}
+
+// FontOpt sets the default font of the theme.
+//
+// Parameters:
+//   - family: The font family.
+//   - size: The font size.
+//
+// Returns:
+//   - NewThemeOption: A function that takes a pointer to newThemeOptions and sets the Font field.
+func FontOpt(family string, size int32) NewThemeOption {
+	return func(opts *newThemeOptions) {
+		opts.Font = Font{Family: family, Size: size}
+	}
+}
+
+// SpacingOpt sets the default space between the children of layouts.
+//
+// Parameters:
+//   - spacing: The spacing in pixels.
+//
+// Returns:
+//   - NewThemeOption: A function that takes a pointer to newThemeOptions and sets the Spacing field.
+func SpacingOpt(spacing int32) NewThemeOption {
+	return func(opts *newThemeOptions) {
+		opts.Spacing = spacing
+	}
+}
+
+// RoundnessOpt sets the default corner roundness of the theme, clamped between 0 and 100.
+//
+// Parameters:
+//   - roundness: The roundness of corners.
+//
+// Returns:
+//   - NewThemeOption: A function that takes a pointer to newThemeOptions and sets the Roundness field.
+func RoundnessOpt(roundness int32) NewThemeOption {
+	return func(opts *newThemeOptions) {
+		opts.Roundness = max(0, min(100, roundness))
+	}
+}
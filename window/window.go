@@ -4,9 +4,12 @@ import (
 	"runtime"
 	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/Carmen-Shannon/gooey/common"
 	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
+	"github.com/Carmen-Shannon/gooey/theme"
 )
 
 type wdw struct {
@@ -19,8 +22,32 @@ type wdw struct {
 	BackgroundColor common.Color
 	redraw          chan struct{}
 	Components      []component.Component
+	onResize        func(width, height int32)
+	mouse           mouseState
+	tooltip         tooltipState
+
+	events             *event.Dispatcher
+	shortcuts          []shortcut
+	shortcutPrecedence ShortcutPrecedence
+	focus              *component.FocusManager
+	theme              *theme.Theme
+	// fixedBackground is set when the background color was given explicitly and does not follow the theme.
+	fixedBackground bool
+	// loopThread is the OS thread running the event loop while Run is active, 0 otherwise.
+	loopThread atomic.Uint64
+	// done is closed once the window is closed, see Done. It is made on first use.
+	done      chan struct{}
+	doneOnce  sync.Once
+	closeOnce sync.Once
 }
 
+// Window is a top-level native window hosting components.
+//
+// The window's event loop, started by Run, is the UI thread: backend input, event listeners and drawing all run on it,
+// and components are not safe for concurrent use. Components may be created and added on any goroutine before Run is
+// called, after that they must only be touched from the UI thread, from event listeners or through Invoke and InvokeAndWait.
+// The window methods documented as safe to call from any goroutine are Invoke, InvokeAndWait, Done, GetID, Size, OnResize,
+// OnKeyDown, OnKeyUp, AddShortcut, RemoveShortcut and SetWindowDisplay, all other methods belong to the UI thread.
 type Window interface {
 	// AddComponent adds a component to the window's list of components.
 	// It takes a component.Component as a parameter.
@@ -29,6 +56,19 @@ type Window interface {
 	//  - c: The component to add to the window.
 	AddComponent(c component.Component)
 
+	// AddShortcut registers fn to be called when the key combination accel is pressed in the window, for example "Ctrl+Shift+P" or "F5".
+	// Registering the same combination again replaces the previous function.
+	// By default shortcuts override the focused component, use ShortcutPrecedenceOpt or DefaultShortcutPrecedenceOpt to let them yield instead.
+	//
+	// Parameters:
+	//  - accel: The key combination, modifiers and key joined by "+".
+	//  - fn: The function to call when the shortcut is pressed.
+	//  - options: A variadic list of AddShortcutOption functions to customize the shortcut.
+	//
+	// Returns:
+	//  - error: An error if accel cannot be parsed, or nil if it succeeds.
+	AddShortcut(accel string, fn func(), options ...AddShortcutOption) error
+
 	// DrawComponents draws the components of the window using the provided context.
 	// It iterates over the window's components and calls their Draw method.
 	//
@@ -36,7 +76,23 @@ type Window interface {
 	//  - ctx: The context to use for drawing the components.
 	DrawComponents(ctx *common.DrawCtx)
 
+	// Done returns a channel which is closed once the window is closed, for goroutines that must stop with the window.
+	// It is closed before a function given to Invoke runs on the calling goroutine because the window is closed.
+	// It is safe to call from any goroutine.
+	//
+	// Returns:
+	//  - <-chan struct{}: The channel closed when the window is closed.
+	Done() <-chan struct{}
+
+	// FocusedComponent returns the component of the window that has keyboard focus.
+	// Focus moves by clicking a focusable component, with Tab and Shift+Tab, or by calling Focus on a component.
+	//
+	// Returns:
+	//  - component.Component: The focused component, or nil if no component has focus.
+	FocusedComponent() component.Component
+
 	// GetComponent retrieves a component from the window's list of components by its ID.
+	// Components nested inside of containers such as layouts are searched as well.
 	// It takes a uintptr as a parameter and returns the corresponding component.Component.
 	//
 	// Parameters:
@@ -54,14 +110,64 @@ type Window interface {
 	//  - uintptr: The ID of the window.
 	GetID() uintptr
 
+	// Invoke queues fn to run on the window's event loop and returns without waiting for it.
+	// Functions run in the order they were queued. Use it to update components from other goroutines.
+	// If the window was already closed fn runs on the calling goroutine instead.
+	// It is safe to call from any goroutine.
+	//
+	// Parameters:
+	//  - fn: The function to run on the event loop.
+	Invoke(fn func())
+
+	// InvokeAndWait runs fn on the window's event loop and waits until it returned.
+	// Called from the event loop itself, for example from an event listener, fn runs immediately.
+	// It must not be called before Run from the goroutine that is going to call Run, as that would wait forever.
+	// It is safe to call from any goroutine.
+	//
+	// Parameters:
+	//  - fn: The function to run on the event loop.
+	InvokeAndWait(fn func())
+
+	// OnKeyDown subscribes fn to keys pressed while the window has keyboard focus, including auto-repeated presses.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnKeyDown(fn func(event.KeyDownEvent)) event.Handle
+
+	// OnKeyUp subscribes fn to keys released while the window has keyboard focus.
+	//
+	// Parameters:
+	//  - fn: The function called with each event.
+	//
+	// Returns:
+	//  - event.Handle: The handle used to unsubscribe fn.
+	OnKeyUp(fn func(event.KeyUpEvent)) event.Handle
+
+	// OnResize sets the callback invoked after the window changed size and its components were reflowed.
+	//
+	// Parameters:
+	//  - cb: The callback to invoke with the new width and height of the window's client area.
+	OnResize(cb func(width, height int32))
+
 	// RemoveComponent removes a component from the window's list of components.
 	// It takes a component.Component as a parameter.
 	// The component is identified by its ID, and if found, it is removed from the list.
+	// The backend forgets the component and its descendants, so they can no longer be clicked or focused where they
+	// were last drawn. Adding the component again registers it with the backend anew.
 	//
 	// Parameters:
 	//  - c: The component to remove from the window.
 	RemoveComponent(id uintptr)
 
+	// RemoveShortcut removes the shortcut registered for the key combination accel, if any.
+	//
+	// Parameters:
+	//  - accel: The key combination the shortcut was registered with.
+	RemoveShortcut(accel string)
+
 	// Run starts the window's message loop and begins processing events.
 	// It locks the OS thread to ensure that the window runs on the main thread.
 	//
@@ -73,6 +179,21 @@ type Window interface {
 	//  - refresh: An integer value that specifies the refresh rate or interval for the window in FPS.
 	Run(refresh int)
 
+	// Size returns the current width and height of the window's client area.
+	//
+	// Returns:
+	//  - int32: The width of the window.
+	//  - int32: The height of the window.
+	Size() (int32, int32)
+
+	// SetTheme switches the theme of the window and restyles its components.
+	// Colors, fonts and sizes set explicitly on components are kept, everything else follows the new theme.
+	// The window's background follows the theme as well, unless it was set with BackgroundColorOpt.
+	//
+	// Parameters:
+	//  - t: The new theme, for example theme.Dark().
+	SetTheme(t *theme.Theme)
+
 	// SetWindowDisplay sets the display state of the window.
 	// It takes a WindowDisplayFlag to specify the desired display state.
 	//
@@ -82,6 +203,12 @@ type Window interface {
 	// Returns:
 	//  - error: An error if the operation fails, or nil if it succeeds.
 	SetWindowDisplay(flag WindowDisplayFlag) error
+
+	// Theme returns the theme of the window.
+	//
+	// Returns:
+	//  - *theme.Theme: The theme the window's components are drawn with.
+	Theme() *theme.Theme
 }
 
 var _ Window = (*wdw)(nil)
@@ -103,19 +230,36 @@ func (w *wdw) AddComponent(c component.Component) {
 	defer w.mu.Unlock()
 
 	w.Components = append(w.Components, c)
+	w.focus.Attach(c)
+	// a component removed from a window before, or one built under the ID of a removed one, lost its registration
+	component.Register(c)
+	// applying the theme also lays out layouts against the theme's fonts and spacing
+	component.ApplyTheme(c, w.theme)
 }
 
 func (w *wdw) DrawComponents(ctx *common.DrawCtx) {
 	drawComponents(w, ctx)
 }
 
+func (w *wdw) FocusedComponent() component.Component {
+	return w.focus.Focused()
+}
+
 func (w *wdw) GetComponent(id uintptr) component.Component {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	for _, comp := range w.Components {
-		if comp.ID() == id {
-			return comp
+	var found component.Component
+	for _, top := range w.Components {
+		component.Walk(top, func(c component.Component) bool {
+			if c.ID() == id {
+				found = c
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return found
 		}
 	}
 	return nil
@@ -125,23 +269,132 @@ func (w *wdw) GetID() uintptr {
 	return w.ID
 }
 
-func (w *wdw) RemoveComponent(id uintptr) {
+func (w *wdw) OnResize(cb func(width, height int32)) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	for i, comp := range w.Components {
-		if comp.ID() == id {
-			w.Components = slices.Delete(w.Components, i, i+1)
-			return
-		}
+	w.onResize = cb
+}
+
+func (w *wdw) RemoveComponent(id uintptr) {
+	w.mu.Lock()
+	i := slices.IndexFunc(w.Components, func(c component.Component) bool {
+		return c.ID() == id
+	})
+	if i < 0 {
+		w.mu.Unlock()
+		return
+	}
+	removed := w.Components[i]
+	w.Components = slices.Delete(w.Components, i, i+1)
+	w.mu.Unlock()
+
+	// detaching may blur the removed component, whose focus listeners are free to call back into the window
+	w.focus.Detach(removed)
+	// the walk stops early when the removed subtree contains the tooltip's target
+	if target := w.tooltip.target; target != nil && !component.Walk(removed, func(c component.Component) bool { return c != target }) {
+		w.hideTooltip()
+		w.tooltip.target = nil
 	}
+	// the backend still knows where the removed components were drawn, they must not be clicked or focused there
+	component.Unregister(removed)
 }
 
 func (w *wdw) Run(refresh int) {
 	runtime.LockOSThread()
+	w.loopThread.Store(currentThreadID())
+	defer w.markClosed()
+	defer w.loopThread.Store(0)
 	run(w, refresh)
 }
 
 func (w *wdw) SetWindowDisplay(flag WindowDisplayFlag) error {
 	return setWindowDisplay(w, flag)
 }
+
+func (w *wdw) SetTheme(t *theme.Theme) {
+	if t == nil {
+		t = theme.Light()
+	}
+	w.mu.Lock()
+	w.theme = t
+	if !w.fixedBackground {
+		w.BackgroundColor = t.Palette.Background
+		setWindowColor(w.ID, &w.BackgroundColor)
+	}
+	components := slices.Clone(w.Components)
+	w.mu.Unlock()
+
+	for _, c := range components {
+		component.ApplyTheme(c, t)
+	}
+}
+
+func (w *wdw) Theme() *theme.Theme {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.theme
+}
+
+func (w *wdw) Size() (int32, int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.Width, w.Height
+}
+
+// handleResize records the new size of the window, reflows the top-level components against
+// their anchors and notifies the OnResize callback. Sizes that did not change are ignored.
+//
+// Parameters:
+//   - width: The new width of the window's client area.
+//   - height: The new height of the window's client area.
+func (w *wdw) handleResize(width, height int32) {
+	w.mu.Lock()
+	if width == w.Width && height == w.Height {
+		w.mu.Unlock()
+		return
+	}
+	oldWidth, oldHeight := w.Width, w.Height
+	w.Width, w.Height = width, height
+	for _, c := range w.Components {
+		component.Reflow(c, oldWidth, oldHeight, width, height)
+	}
+	cb := w.onResize
+	w.mu.Unlock()
+
+	if cb != nil {
+		cb(width, height)
+	}
+}
+
+// windowTheme returns the theme a window is created with and the background color it starts with.
+//
+// Parameters:
+//   - opts: The options of the window.
+//
+// Returns:
+//   - *theme.Theme: The theme of the window, the light theme if none was given.
+//   - common.Color: The background color of the window, the theme's background if none was given.
+func windowTheme(opts *newWindowOption) (*theme.Theme, common.Color) {
+	t := opts.Theme
+	if t == nil {
+		t = theme.Light()
+	}
+	if opts.BackgroundColor != nil {
+		return t, *opts.BackgroundColor
+	}
+	return t, t.Palette.Background
+}
+
+// components returns a snapshot of the window's top-level components.
+//
+// Returns:
+//   - []component.Component: The top-level components in drawing order.
+func (w *wdw) components() []component.Component {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.Components)
+}
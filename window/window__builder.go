@@ -1,6 +1,11 @@
 package window
 
-import "github.com/Carmen-Shannon/gooey/common"
+import (
+	"time"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/theme"
+)
 
 type newWindowOption struct {
 	Title           string
@@ -10,6 +15,11 @@ type newWindowOption struct {
 	ClassName       string
 	CloseChan       chan struct{}
 	BackgroundColor *common.Color
+	Theme           *theme.Theme
+	// ShortcutPrecedence is the precedence of shortcuts added without ShortcutPrecedenceOpt.
+	ShortcutPrecedence ShortcutPrecedence
+	// TooltipDelay is how long the pointer rests over a component before its tooltip shows, 0 for the default.
+	TooltipDelay time.Duration
 }
 
 type NewWindowOption func(*newWindowOption)
@@ -104,3 +114,43 @@ func BackgroundColorOpt(color *common.Color) NewWindowOption {
 		opts.BackgroundColor = color
 	}
 }
+
+// ThemeOpt sets the theme of the window. Without it the window uses the light theme.
+// The window's background follows the theme unless BackgroundColorOpt is given.
+//
+// Parameters:
+//   - t: The theme of the window, for example theme.Dark().
+//
+// Returns:
+//   - NewWindowOption: A function that takes a pointer to newWindowOption and sets the Theme field.
+func ThemeOpt(t *theme.Theme) NewWindowOption {
+	return func(opts *newWindowOption) {
+		opts.Theme = t
+	}
+}
+
+// DefaultShortcutPrecedenceOpt sets the precedence of shortcuts added to the window without ShortcutPrecedenceOpt.
+//
+// Parameters:
+//   - precedence: The default precedence of the window's shortcuts.
+//
+// Returns:
+//   - NewWindowOption: A function that takes a pointer to newWindowOption and sets the ShortcutPrecedence field.
+func DefaultShortcutPrecedenceOpt(precedence ShortcutPrecedence) NewWindowOption {
+	return func(opts *newWindowOption) {
+		opts.ShortcutPrecedence = precedence
+	}
+}
+
+// TooltipDelayOpt sets how long the pointer has to rest over a component before its tooltip shows. The default is 500ms.
+//
+// Parameters:
+//   - delay: The delay before a tooltip shows.
+//
+// Returns:
+//   - NewWindowOption: A function that takes a pointer to newWindowOption and sets the TooltipDelay field.
+func TooltipDelayOpt(delay time.Duration) NewWindowOption {
+	return func(opts *newWindowOption) {
+		opts.TooltipDelay = delay
+	}
+}
@@ -0,0 +1,54 @@
+package window
+
+// post queues a function on the event loop of a window, tests replace it to run an event loop without a display.
+var post = postInvoke
+
+func (w *wdw) Invoke(fn func()) {
+	if !post(w.ID, fn) {
+		// the window is closed, without an event loop left there is nothing fn could race with
+		w.markClosed()
+		fn()
+	}
+}
+
+func (w *wdw) Done() <-chan struct{} {
+	return w.doneChan()
+}
+
+// doneChan returns the channel closed by markClosed, making it on first use.
+func (w *wdw) doneChan() chan struct{} {
+	w.doneOnce.Do(func() {
+		w.done = make(chan struct{})
+	})
+	return w.done
+}
+
+// markClosed closes the channel returned by Done, once the event loop ended or Invoke found the window closed.
+func (w *wdw) markClosed() {
+	w.closeOnce.Do(func() {
+		close(w.doneChan())
+	})
+}
+
+func (w *wdw) InvokeAndWait(fn func()) {
+	if w.onLoopThread() {
+		fn()
+		return
+	}
+	done := make(chan struct{})
+	w.Invoke(func() {
+		defer close(done)
+		fn()
+	})
+	<-done
+}
+
+// onLoopThread reports whether the caller runs on the window's event loop.
+// Run locks the event loop to its OS thread, so no other goroutine can run on that thread while the loop is alive.
+//
+// Returns:
+//   - bool: True if the caller is the window's event loop, false otherwise.
+func (w *wdw) onLoopThread() bool {
+	tid := w.loopThread.Load()
+	return tid != 0 && tid == currentThreadID()
+}
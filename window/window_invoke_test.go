@@ -0,0 +1,139 @@
+package window
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLoop is an event loop running invoked functions on its own locked OS thread, as Run does, without a display.
+type fakeLoop struct {
+	w      *wdw
+	queue  chan func()
+	thread uint64
+	done   chan struct{}
+	once   sync.Once
+}
+
+// startFakeLoop starts the event loop of w and routes Invoke to it until the test ends.
+func startFakeLoop(t *testing.T) *fakeLoop {
+	t.Helper()
+	l := &fakeLoop{w: &wdw{ID: 1}, queue: make(chan func(), 64), done: make(chan struct{})}
+	started := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		l.thread = currentThreadID()
+		l.w.loopThread.Store(l.thread)
+		defer l.w.loopThread.Store(0)
+		close(started)
+		for {
+			select {
+			case fn := <-l.queue:
+				fn()
+			case <-l.done:
+				return
+			}
+		}
+	}()
+	<-started
+
+	prev := post
+	post = func(hwnd uintptr, fn func()) bool {
+		select {
+		case <-l.done:
+			return false
+		default:
+		}
+		l.queue <- fn
+		return true
+	}
+	t.Cleanup(func() {
+		l.stop()
+		post = prev
+	})
+	return l
+}
+
+// stop ends the event loop and waits until it left its thread, later functions are rejected like for a closed window.
+func (l *fakeLoop) stop() {
+	l.once.Do(func() { close(l.done) })
+	for l.w.loopThread.Load() != 0 {
+		runtime.Gosched()
+	}
+}
+
+func TestInvokeRunsOnLoopThread(t *testing.T) {
+	l := startFakeLoop(t)
+	const goroutines, calls = 32, 50
+
+	// count is only touched on the loop, the race detector reports any call running elsewhere
+	count := 0
+	var wg sync.WaitGroup
+	offThread := make(chan uint64, goroutines*calls*2)
+	check := func() {
+		if tid := currentThreadID(); tid != l.thread {
+			offThread <- tid
+		}
+		count++
+	}
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range calls {
+				l.w.Invoke(check)
+				l.w.InvokeAndWait(check)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var got int
+	l.w.InvokeAndWait(func() { got = count })
+	if want := goroutines * calls * 2; got != want {
+		t.Errorf("ran %d invoked functions, want %d", got, want)
+	}
+	close(offThread)
+	for tid := range offThread {
+		t.Errorf("invoked function ran on thread %d, want the loop thread %d", tid, l.thread)
+	}
+}
+
+func TestInvokeAndWaitOnLoopThreadRunsInline(t *testing.T) {
+	l := startFakeLoop(t)
+	finished := make(chan bool, 1)
+	l.w.Invoke(func() {
+		ran := false
+		// the loop is busy running this function, waiting for it to run another one would deadlock
+		l.w.InvokeAndWait(func() { ran = true })
+		finished <- ran
+	})
+	select {
+	case ran := <-finished:
+		if !ran {
+			t.Errorf("InvokeAndWait on the loop thread returned without running fn")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("InvokeAndWait on the loop thread deadlocked")
+	}
+}
+
+func TestInvokeAfterClose(t *testing.T) {
+	l := startFakeLoop(t)
+	l.stop()
+
+	// without an event loop the functions run right away on the caller
+	ran := 0
+	l.w.Invoke(func() { ran++ })
+	l.w.InvokeAndWait(func() { ran++ })
+	if ran != 2 {
+		t.Errorf("ran %d functions after the loop ended, want 2", ran)
+	}
+	select {
+	case <-l.w.Done():
+	default:
+		t.Errorf("Done() is not closed after Invoke found the window closed")
+	}
+}
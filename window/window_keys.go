@@ -0,0 +1,168 @@
+package window
+
+import (
+	"slices"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+// ShortcutPrecedence decides whether a window shortcut fires when the focused component handles the same keys itself.
+type ShortcutPrecedence int
+
+const (
+	// ShortcutOverridesFocus fires the shortcut even if the focused component would handle the keys, this is the default.
+	ShortcutOverridesFocus ShortcutPrecedence = iota
+	// ShortcutYieldsToFocus leaves the keys to the focused component if it handles them, for example Ctrl+A in a text input.
+	ShortcutYieldsToFocus
+)
+
+// shortcut is an entry of a window's accelerator table.
+type shortcut struct {
+	accel      event.Shortcut
+	fn         func()
+	precedence ShortcutPrecedence
+}
+
+type addShortcutOptions struct {
+	Precedence *ShortcutPrecedence
+}
+
+type AddShortcutOption func(*addShortcutOptions)
+
+// ShortcutPrecedenceOpt sets the precedence of a single shortcut, overriding the window's default precedence.
+//
+// Parameters:
+//   - precedence: The precedence to use for the shortcut.
+//
+// Returns:
+//   - AddShortcutOption: A function that takes a pointer to addShortcutOptions and sets the Precedence field.
+func ShortcutPrecedenceOpt(precedence ShortcutPrecedence) AddShortcutOption {
+	return func(opts *addShortcutOptions) {
+		opts.Precedence = &precedence
+	}
+}
+
+func (w *wdw) OnKeyDown(fn func(event.KeyDownEvent)) event.Handle {
+	return event.Subscribe(w.events, fn)
+}
+
+func (w *wdw) OnKeyUp(fn func(event.KeyUpEvent)) event.Handle {
+	return event.Subscribe(w.events, fn)
+}
+
+func (w *wdw) AddShortcut(accel string, fn func(), options ...AddShortcutOption) error {
+	sc, err := event.ParseShortcut(accel)
+	if err != nil {
+		return err
+	}
+	opts := &addShortcutOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	precedence := w.shortcutPrecedence
+	if opts.Precedence != nil {
+		precedence = *opts.Precedence
+	}
+	w.shortcuts = slices.DeleteFunc(w.shortcuts, func(s shortcut) bool {
+		return s.accel == sc
+	})
+	w.shortcuts = append(w.shortcuts, shortcut{accel: sc, fn: fn, precedence: precedence})
+	return nil
+}
+
+func (w *wdw) RemoveShortcut(accel string) {
+	sc, err := event.ParseShortcut(accel)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.shortcuts = slices.DeleteFunc(w.shortcuts, func(s shortcut) bool {
+		return s.accel == sc
+	})
+}
+
+// handleKey turns a piece of raw keyboard input from the backend into window key events, shortcuts and focus changes.
+// The key events are published to the window's listeners first, then a key press is matched against the shortcuts
+// and finally used to move the focus or activate the focused button.
+//
+// Parameters:
+//   - in: The keyboard input reported by the backend.
+//
+// Returns:
+//   - bool: True if the window used the key and it must not reach the focused text input, false otherwise.
+func (w *wdw) handleKey(in common.KeyInput) bool {
+	if in.Kind == common.KeyInputUp {
+		event.Publish(w.events, event.KeyUpEvent{Source: w.ID, Key: in.Key, Modifiers: in.Modifiers})
+		return false
+	}
+	event.Publish(w.events, event.KeyDownEvent{Source: w.ID, Key: in.Key, Modifiers: in.Modifiers, Repeat: in.Repeat})
+
+	if w.handleShortcut(in) {
+		return true
+	}
+	return w.handleFocusKey(in)
+}
+
+// handleShortcut fires the shortcut matching a key press.
+// A shortcut which yields to focus is skipped when the focused component consumes the key.
+//
+// Parameters:
+//   - in: The key press reported by the backend.
+//
+// Returns:
+//   - bool: True if a shortcut fired, false otherwise.
+func (w *wdw) handleShortcut(in common.KeyInput) bool {
+	w.mu.Lock()
+	idx := slices.IndexFunc(w.shortcuts, func(s shortcut) bool {
+		return s.accel.Matches(in.Key, in.Modifiers)
+	})
+	if idx < 0 {
+		w.mu.Unlock()
+		return false
+	}
+	sc := w.shortcuts[idx]
+	w.mu.Unlock()
+
+	if sc.precedence == ShortcutYieldsToFocus {
+		if kc, ok := w.focus.Focused().(component.KeyConsumer); ok && kc.ConsumesKey(in.Key, in.Modifiers) {
+			return false
+		}
+	}
+	sc.fn()
+	return true
+}
+
+// handleFocusKey moves the focus with Tab and Shift+Tab and activates a focused button with Space or Enter.
+//
+// Parameters:
+//   - in: The key press reported by the backend.
+//
+// Returns:
+//   - bool: True if the key was used for focus handling, false otherwise.
+func (w *wdw) handleFocusKey(in common.KeyInput) bool {
+	switch {
+	case in.Key == event.KeyTab && in.Modifiers == 0:
+		return w.focus.Next()
+	case in.Key == event.KeyTab && in.Modifiers == event.ModShift:
+		return w.focus.Prev()
+	case (in.Key == event.KeySpace || in.Key == event.KeyEnter) && in.Modifiers == 0:
+		b, ok := w.focus.Focused().(component.Button)
+		if !ok || !component.CanFocus(b) {
+			return false
+		}
+		if !in.Repeat {
+			b.Click()
+		}
+		return true
+	}
+	return false
+}
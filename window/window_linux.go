@@ -6,13 +6,18 @@ package window
 import (
 	"errors"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Carmen-Shannon/gooey/common"
 	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
 	"github.com/Carmen-Shannon/gooey/internal/linux"
 )
 
+// middleClickPastes reports whether a middle click pastes into a text input, which X11 does with the PRIMARY selection.
+const middleClickPastes = true
+
 func createWindow(options ...NewWindowOption) Window {
 	opts := newWindowOption{}
 	for _, opt := range options {
@@ -28,10 +33,7 @@ func createWindow(options ...NewWindowOption) Window {
 	if opts.Height == 0 {
 		opts.Height = 600
 	}
-	bgColor := common.Color{Red: 255, Green: 255, Blue: 255}
-	if opts.BackgroundColor != nil {
-		bgColor = *opts.BackgroundColor
-	}
+	t, bgColor := windowTheme(&opts)
 	bgPixel := uint32(bgColor.Red)<<16 | uint32(bgColor.Green)<<8 | uint32(bgColor.Blue)
 
 	display := linux.XOpenDisplay()
@@ -55,10 +57,12 @@ func createWindow(options ...NewWindowOption) Window {
 			linux.ButtonPressMask|
 			linux.ButtonReleaseMask|
 			linux.PointerMotionMask|
+			linux.LeaveWindowMask|
 			linux.KeyPressMask|
 			linux.KeyReleaseMask,
 	)
 	linux.RegisterDisplay(uintptr(window), display)
+	linux.OpenInputMethod(uintptr(window))
 	linux.XStoreName(display, window, opts.Title)
 	linux.XMapWindow(display, window)
 
@@ -70,7 +74,14 @@ func createWindow(options ...NewWindowOption) Window {
 		Title:           opts.Title,
 		BackgroundColor: bgColor,
 		redraw:          make(chan struct{}, 1),
+		theme:           t,
+		fixedBackground: opts.BackgroundColor != nil,
+
+		events:             event.NewDispatcher(),
+		shortcutPrecedence: opts.ShortcutPrecedence,
+		tooltip:            tooltipState{delay: opts.TooltipDelay},
 	}
+	w.focus = component.NewFocusManager(w.ID, w.components)
 
 	linux.RegisterDrawCallback(w.ID, func(hdc uintptr) {
 		w.DrawComponents(&common.DrawCtx{
@@ -78,7 +89,11 @@ func createWindow(options ...NewWindowOption) Window {
 			Hdc:  hdc,
 		})
 	})
-	linux.SetWindowColor(w.ID, opts.BackgroundColor)
+	linux.RegisterResizeCallback(w.ID, w.handleResize)
+	linux.RegisterMouseCallback(w.ID, w.handleMouse)
+	linux.RegisterInvokeQueue(w.ID)
+	linux.RegisterKeyCallback(w.ID, w.handleKey)
+	linux.SetWindowColor(w.ID, &bgColor)
 
 	return w
 }
@@ -91,33 +106,10 @@ func drawComponents(w *wdw, ctx *common.DrawCtx) {
 		return
 	}
 
-	x, y := linux.GetMouseState(w.ID)
-	display := linux.GetDisplay(w.ID)
-	window := linux.C_Window(w.ID)
-
-	for _, c := range w.Components {
-		if btn, ok := c.(component.Button); ok {
-			bx, by := btn.Position()
-			bw, bh := btn.Size()
-			inside := x >= bx && x < bx+bw && y >= by && y < by+bh
-			btn.SetHovered(inside)
-		}
-		if ti, ok := c.(component.TextInput); ok {
-			tx, ty := ti.Position()
-			tw, th := ti.Size()
-			inside := x >= tx && x < tx+tw && y >= ty && y < ty+th
-
-			linux.SetCustomCursorDraw(inside)
-			if ti.Enabled() && inside {
-				linux.SetCursor(display, window, linux.LoadIBeamCursor(display))
-			}
-		}
-		c.Draw(ctx)
-	}
-
-	if !linux.IsCustomCursorDraw() {
-		linux.SetCursor(display, window, linux.LoadArrowCursor(display))
+	for _, top := range w.Components {
+		top.Draw(ctx)
 	}
+	w.focus.DrawFocusRing(ctx)
 }
 
 func run(w *wdw, refresh int) {
@@ -188,3 +180,60 @@ func startDrawHandler(w *wdw, fps int) {
 		}
 	}()
 }
+
+// setWindowColor sets the color the backend paints the window's background with.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - color: The background color.
+func setWindowColor(hwnd uintptr, color *common.Color) {
+	linux.SetWindowColor(hwnd, color)
+}
+
+// openTooltip shows a tooltip popup for the window near the pointer, replacing the one already shown.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - x: The x-coordinate of the pointer in window coordinates.
+//   - y: The y-coordinate of the pointer in window coordinates.
+//   - tip: The text and colors of the tooltip.
+func openTooltip(hwnd uintptr, x, y int32, tip common.Tooltip) {
+	linux.ShowTooltip(hwnd, x, y, tip)
+}
+
+// closeTooltip hides the tooltip popup of the window.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+func closeTooltip(hwnd uintptr) {
+	linux.HideTooltip(hwnd)
+}
+
+// setCursor sets the mouse pointer shown over the window.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - cursor: The cursor to show.
+func setCursor(hwnd uintptr, cursor *common.Cursor) {
+	linux.SetWindowCursor(hwnd, cursor)
+}
+
+// postInvoke queues fn to run on the event loop of the window and wakes the loop.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - fn: The function to run on the event loop.
+//
+// Returns:
+//   - bool: True if fn was queued, false if the window is closed.
+func postInvoke(hwnd uintptr, fn func()) bool {
+	return linux.PostInvoke(hwnd, fn)
+}
+
+// currentThreadID returns the ID of the OS thread the caller runs on.
+//
+// Returns:
+//   - uint64: The ID of the current OS thread.
+func currentThreadID() uint64 {
+	return uint64(syscall.Gettid())
+}
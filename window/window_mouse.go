@@ -0,0 +1,237 @@
+package window
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
+)
+
+const (
+	// doubleClickTime is the longest time between two presses that still counts as a multi-click.
+	doubleClickTime = 400 * time.Millisecond
+	// doubleClickDistance is the furthest the pointer may travel between two presses of a multi-click.
+	doubleClickDistance = 4
+)
+
+// mouseState tracks the pointer for a window between two pieces of mouse input.
+// It is only touched from the thread running the window's event loop.
+type mouseState struct {
+	hovered       []component.Component
+	capture       component.Component
+	captureButton event.MouseButton
+	lastButton    event.MouseButton
+	lastPress     time.Time
+	lastX         int32
+	lastY         int32
+	clicks        int
+	// cursor is the cursor last shown over the window.
+	cursor *common.Cursor
+}
+
+// handleMouse turns a piece of raw mouse input from the backend into component events.
+// Presses, releases, moves and wheel turns go to the innermost component under the pointer that listens for them,
+// skipping disabled components, so a wheel over a button inside a scroll view still scrolls the view.
+// The component receiving a press keeps receiving moves and the release until the button is let go.
+//
+// Parameters:
+//   - in: The mouse input in window coordinates.
+func (w *wdw) handleMouse(in common.MouseInput) {
+	w.mu.Lock()
+	tops := slices.Clone(w.Components)
+	w.mu.Unlock()
+
+	var path []component.Component
+	if in.Kind != common.MouseInputLeave {
+		for i := len(tops) - 1; i >= 0 && path == nil; i-- {
+			path = component.HitPath(tops[i], in.X, in.Y)
+		}
+	}
+	w.updateHover(path, in)
+	w.updateCursor(path)
+	w.updateTooltip(path, in)
+
+	m := &w.mouse
+	switch in.Kind {
+	case common.MouseInputMove:
+		target := m.capture
+		if target == nil {
+			target = innermost(path, event.HasListeners[event.MouseMoveEvent])
+		}
+		if target != nil {
+			x, y := local(target, in.X, in.Y)
+			event.Publish(target.Events(), event.MouseMoveEvent{
+				Source: target.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y,
+				Button: m.captureButton, Modifiers: in.Modifiers,
+			})
+		}
+	case common.MouseInputDown:
+		w.countClick(in)
+		if in.Button == event.MouseButtonLeft {
+			w.focus.FocusByClick(innermostFocusable(path))
+		} else if in.Button == event.MouseButtonMiddle && middleClickPastes {
+			// pasting with the middle button focuses the text input it pastes into
+			if ti, ok := innermostFocusable(path).(component.TextInput); ok {
+				w.focus.FocusByClick(ti)
+			}
+		}
+		target := innermost(path, listensForButtons)
+		if target == nil {
+			return
+		}
+		if m.capture == nil {
+			m.capture, m.captureButton = target, in.Button
+		}
+		x, y := local(target, in.X, in.Y)
+		event.Publish(target.Events(), event.MouseDownEvent{
+			Source: target.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y,
+			Button: in.Button, Modifiers: in.Modifiers, ClickCount: m.clicks,
+		})
+		if m.clicks == 2 {
+			event.Publish(target.Events(), event.DoubleClickEvent{
+				Source: target.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y,
+				Button: in.Button, Modifiers: in.Modifiers,
+			})
+		}
+	case common.MouseInputUp:
+		target := innermost(path, listensForButtons)
+		if m.capture != nil && in.Button == m.captureButton {
+			target = m.capture
+			m.capture, m.captureButton = nil, event.MouseButtonNone
+		}
+		if target == nil {
+			return
+		}
+		x, y := local(target, in.X, in.Y)
+		event.Publish(target.Events(), event.MouseUpEvent{
+			Source: target.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y,
+			Button: in.Button, Modifiers: in.Modifiers, ClickCount: m.clicks,
+		})
+	case common.MouseInputWheel:
+		target := innermost(path, event.HasListeners[event.WheelEvent])
+		if target == nil {
+			return
+		}
+		x, y := local(target, in.X, in.Y)
+		event.Publish(target.Events(), event.WheelEvent{
+			Source: target.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y,
+			DeltaX: in.DeltaX, DeltaY: in.DeltaY, Modifiers: in.Modifiers,
+		})
+	}
+}
+
+// updateHover publishes MouseLeaveEvent for the components the pointer left and MouseEnterEvent for the ones it entered.
+// Leave events are published innermost first and enter events outermost first.
+//
+// Parameters:
+//   - path: The components now under the pointer, outermost first.
+//   - in: The mouse input that moved the pointer.
+func (w *wdw) updateHover(path []component.Component, in common.MouseInput) {
+	old := w.mouse.hovered
+	w.mouse.hovered = path
+
+	for i := len(old) - 1; i >= 0; i-- {
+		c := old[i]
+		if slices.Contains(path, c) {
+			continue
+		}
+		x, y := local(c, in.X, in.Y)
+		event.Publish(c.Events(), event.MouseLeaveEvent{
+			Source: c.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y, Modifiers: in.Modifiers,
+		})
+	}
+	for _, c := range path {
+		if slices.Contains(old, c) {
+			continue
+		}
+		x, y := local(c, in.X, in.Y)
+		event.Publish(c.Events(), event.MouseEnterEvent{
+			Source: c.ID(), X: x, Y: y, WindowX: in.X, WindowY: in.Y, Modifiers: in.Modifiers,
+		})
+	}
+}
+
+// updateCursor shows the cursor of the innermost component under the pointer that has one, the arrow otherwise.
+// The backend is only told when the cursor changes, which happens as the pointer enters and leaves components.
+// While a component captures the mouse its cursor stays, so dragging past its edge does not flicker.
+//
+// Parameters:
+//   - path: The components now under the pointer, outermost first.
+func (w *wdw) updateCursor(path []component.Component) {
+	m := &w.mouse
+	if m.capture != nil && m.cursor != nil {
+		return
+	}
+	cursor := common.CursorArrow
+	for i := len(path) - 1; i >= 0; i-- {
+		if c := path[i].Cursor(); c != nil {
+			cursor = c
+			break
+		}
+	}
+	if cursor == m.cursor {
+		return
+	}
+	m.cursor = cursor
+	setCursor(w.ID, cursor)
+}
+
+// countClick updates the click count for a press, continuing the count when the same button is pressed
+// again quickly enough and close enough to the previous press.
+//
+// Parameters:
+//   - in: The press input.
+func (w *wdw) countClick(in common.MouseInput) {
+	m := &w.mouse
+	now := time.Now()
+	if in.Button == m.lastButton && now.Sub(m.lastPress) <= doubleClickTime &&
+		abs(in.X-m.lastX) <= doubleClickDistance && abs(in.Y-m.lastY) <= doubleClickDistance {
+		m.clicks++
+	} else {
+		m.clicks = 1
+	}
+	m.lastButton, m.lastPress, m.lastX, m.lastY = in.Button, now, in.X, in.Y
+}
+
+// innermost returns the innermost enabled component of the path accepted by listens, or nil if there is none.
+func innermost(path []component.Component, listens func(*event.Dispatcher) bool) component.Component {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Enabled() && listens(path[i].Events()) {
+			return path[i]
+		}
+	}
+	return nil
+}
+
+// innermostFocusable returns the innermost component of the path that can take keyboard focus, or nil.
+func innermostFocusable(path []component.Component) component.Component {
+	for i := len(path) - 1; i >= 0; i-- {
+		if component.CanFocus(path[i]) {
+			return path[i]
+		}
+	}
+	return nil
+}
+
+// listensForButtons reports whether a dispatcher has listeners for any of the mouse button events.
+func listensForButtons(d *event.Dispatcher) bool {
+	return event.HasListeners[event.MouseDownEvent](d) ||
+		event.HasListeners[event.MouseUpEvent](d) ||
+		event.HasListeners[event.DoubleClickEvent](d) ||
+		event.HasListeners[event.MouseMoveEvent](d)
+}
+
+// local converts window coordinates into coordinates relative to the component's position.
+func local(c component.Component, x, y int32) (int32, int32) {
+	cx, cy := c.Position()
+	return x - cx, y - cy
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package window
+
+import (
+	"time"
+
+	"github.com/Carmen-Shannon/gooey/common"
+	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/theme"
+)
+
+const (
+	// defaultTooltipDelay is how long the pointer has to rest over a component before its tooltip shows.
+	defaultTooltipDelay = 500 * time.Millisecond
+	// tooltipPadding is the space between the border of a tooltip and its text.
+	tooltipPadding = 4
+)
+
+// tooltipState tracks the tooltip of a window. It is only touched from the thread running the window's event loop,
+// the delay timer hands over to the loop through postInvoke.
+type tooltipState struct {
+	delay time.Duration
+	// target is the component whose tooltip is pending or shown.
+	target component.Component
+	timer  *time.Timer
+	shown  bool
+	// suppressed is set by a click and keeps the tooltip of the target hidden until the pointer leaves it.
+	suppressed bool
+	// generation invalidates a timer that fired after its tooltip was cancelled.
+	generation uint64
+	x          int32
+	y          int32
+}
+
+// updateTooltip hides the tooltip on any mouse input and restarts the delay after which the tooltip of the
+// component under the pointer shows. A click keeps the tooltip hidden until the pointer leaves the component.
+//
+// Parameters:
+//   - path: The components now under the pointer, outermost first.
+//   - in: The mouse input.
+func (w *wdw) updateTooltip(path []component.Component, in common.MouseInput) {
+	t := &w.tooltip
+	target := tooltipTarget(path)
+	if target != t.target {
+		t.suppressed = false
+	}
+	if in.Kind == common.MouseInputDown || in.Kind == common.MouseInputWheel {
+		t.suppressed = true
+	}
+	w.hideTooltip()
+
+	t.target = target
+	if target == nil || t.suppressed || in.Kind == common.MouseInputLeave {
+		return
+	}
+	t.x, t.y = in.X, in.Y
+	generation := t.generation
+	delay := t.delay
+	if delay <= 0 {
+		delay = defaultTooltipDelay
+	}
+	t.timer = time.AfterFunc(delay, func() {
+		postInvoke(w.ID, func() { w.showTooltip(generation) })
+	})
+}
+
+// showTooltip shows the tooltip of the target once the delay passed, unless it was cancelled in the meantime.
+//
+// Parameters:
+//   - generation: The generation of the tooltip state the delay was started in.
+func (w *wdw) showTooltip(generation uint64) {
+	t := &w.tooltip
+	if generation != t.generation || t.target == nil || !t.target.Visible() {
+		return
+	}
+	text := t.target.Tooltip()
+	if text == "" {
+		return
+	}
+	style := t.target.Theme().Tooltip
+	colors := style.Resolve(theme.StateNormal)
+	openTooltip(w.ID, t.x, t.y, common.Tooltip{
+		Text:       text,
+		Font:       style.Font.Family,
+		FontSize:   style.Font.Size,
+		Color:      colors.Foreground,
+		Background: colors.Background,
+		Border:     colors.Border,
+		Padding:    tooltipPadding,
+	})
+	t.shown = true
+}
+
+// hideTooltip hides the shown tooltip and cancels a pending one.
+func (w *wdw) hideTooltip() {
+	t := &w.tooltip
+	t.generation++
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if t.shown {
+		closeTooltip(w.ID)
+		t.shown = false
+	}
+}
+
+// tooltipTarget returns the innermost component of the path with a tooltip, or nil if there is none.
+func tooltipTarget(path []component.Component) component.Component {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Tooltip() != "" {
+			return path[i]
+		}
+	}
+	return nil
+}
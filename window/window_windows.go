@@ -11,11 +11,15 @@ import (
 
 	"github.com/Carmen-Shannon/gooey/common"
 	"github.com/Carmen-Shannon/gooey/component"
+	"github.com/Carmen-Shannon/gooey/event"
 	wdws "github.com/Carmen-Shannon/gooey/internal/windows"
 
 	"golang.org/x/sys/windows"
 )
 
+// middleClickPastes reports whether a middle click pastes into a text input, which Windows does not do.
+const middleClickPastes = false
+
 // createWindow creates a new window with the specified options.
 // It takes a variadic number of NewWindowOption functions to customize the window's properties.
 // The function sets default values for the title, style, and class name if they are not provided.
@@ -46,7 +50,8 @@ func createWindow(options ...NewWindowOption) Window {
 	clsName, _ := windows.UTF16PtrFromString(opts.ClassName)
 	wdwTitle, _ := windows.UTF16PtrFromString(opts.Title)
 
-	brush := wdws.CreateSolidBrush(opts.BackgroundColor)
+	t, bgColor := windowTheme(&opts)
+	brush := wdws.CreateSolidBrush(&bgColor)
 
 	_, err := wdws.RegisterClassExW(
 		wdws.StyleOpt(uint32(style)),
@@ -70,13 +75,28 @@ func createWindow(options ...NewWindowOption) Window {
 		return nil
 	}
 
+	// The requested size includes the window frame, components are laid out against the client area.
+	clientWidth, clientHeight := wdws.GetClientRect(wdwHandle)
+	if clientWidth == 0 || clientHeight == 0 {
+		clientWidth, clientHeight = opts.Width, opts.Height
+	}
+
 	w := &wdw{
 		mu:     sync.Mutex{},
 		ID:     uintptr(wdwHandle),
-		Height: opts.Height,
-		Width:  opts.Width,
+		Height: clientHeight,
+		Width:  clientWidth,
 		Title:  opts.Title,
+
+		BackgroundColor: bgColor,
+		theme:           t,
+		fixedBackground: opts.BackgroundColor != nil,
+
+		events:             event.NewDispatcher(),
+		shortcutPrecedence: opts.ShortcutPrecedence,
+		tooltip:            tooltipState{delay: opts.TooltipDelay},
 	}
+	w.focus = component.NewFocusManager(w.ID, w.components)
 
 	wdws.RegisterDrawCallback(uintptr(wdwHandle), func(hdc uintptr) {
 		w.DrawComponents(&common.DrawCtx{
@@ -84,7 +104,11 @@ func createWindow(options ...NewWindowOption) Window {
 			Hdc:  hdc,
 		})
 	})
-	wdws.SetWindowColor(uintptr(wdwHandle), opts.BackgroundColor)
+	wdws.RegisterResizeCallback(uintptr(wdwHandle), w.handleResize)
+	wdws.RegisterMouseCallback(uintptr(wdwHandle), w.handleMouse)
+	wdws.RegisterInvokeQueue(uintptr(wdwHandle))
+	wdws.RegisterKeyCallback(uintptr(wdwHandle), w.handleKey)
+	wdws.SetWindowColor(uintptr(wdwHandle), &bgColor)
 
 	return w
 }
@@ -155,26 +179,10 @@ func drawComponents(w *wdw, ctx *common.DrawCtx) {
 		}
 		return
 	}
-	x, y, _, _, _ := wdws.GetMouseState(windows.Handle(w.ID))
-	for _, c := range w.Components {
-		if btn, ok := c.(component.Button); ok {
-			bx, by := btn.Position()
-			bw, bh := btn.Size()
-			inside := x >= bx && x < bx+bw && y >= by && y < by+bh
-			btn.SetHovered(inside)
-		}
-		if ti, ok := c.(component.TextInput); ok {
-			tx, ty := ti.Position()
-			tw, th := ti.Size()
-			inside := x >= tx && x < tx+tw && y >= ty && y < ty+th
-
-			wdws.SetCustomCursorDraw(inside)
-			if ti.Enabled() && inside {
-				wdws.SetCursor(wdws.LoadIBeamCursor())
-			}
-		}
-		c.Draw(ctx)
+	for _, top := range w.Components {
+		top.Draw(ctx)
 	}
+	w.focus.DrawFocusRing(ctx)
 }
 
 // run starts the window's message loop and begins processing events.
@@ -211,3 +219,60 @@ func startDrawHandler(hwnd windows.Handle, fps int) {
 		}
 	}()
 }
+
+// setWindowColor sets the color the backend paints the window's background with.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - color: The background color.
+func setWindowColor(hwnd uintptr, color *common.Color) {
+	wdws.SetWindowColor(hwnd, color)
+}
+
+// openTooltip shows a tooltip popup for the window near the pointer, replacing the one already shown.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - x: The x-coordinate of the pointer in window coordinates.
+//   - y: The y-coordinate of the pointer in window coordinates.
+//   - tip: The text and colors of the tooltip.
+func openTooltip(hwnd uintptr, x, y int32, tip common.Tooltip) {
+	wdws.ShowTooltip(hwnd, x, y, tip)
+}
+
+// closeTooltip hides the tooltip popup of the window.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+func closeTooltip(hwnd uintptr) {
+	wdws.HideTooltip(hwnd)
+}
+
+// setCursor sets the mouse pointer shown over the window.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - cursor: The cursor to show.
+func setCursor(hwnd uintptr, cursor *common.Cursor) {
+	wdws.SetWindowCursor(hwnd, cursor)
+}
+
+// postInvoke queues fn to run on the event loop of the window and wakes the loop.
+//
+// Parameters:
+//   - hwnd: The handle of the window.
+//   - fn: The function to run on the event loop.
+//
+// Returns:
+//   - bool: True if fn was queued, false if the window is closed.
+func postInvoke(hwnd uintptr, fn func()) bool {
+	return wdws.PostInvoke(hwnd, fn)
+}
+
+// currentThreadID returns the ID of the OS thread the caller runs on.
+//
+// Returns:
+//   - uint64: The ID of the current OS thread.
+func currentThreadID() uint64 {
+	return uint64(windows.GetCurrentThreadId())
+}